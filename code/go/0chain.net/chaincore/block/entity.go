@@ -933,24 +933,7 @@ func (b *Block) ComputeState(ctx context.Context, c Chainer, waitC ...chan struc
 			}
 		}
 
-		b.Events = append(b.Events, event.Event{
-			BlockNumber: b.Round,
-			TxHash:      txn.Hash,
-			Type:        event.TypeStats,
-			Tag:         event.TagAddTransactions,
-			Index:       txn.Hash,
-			Data:        transactionNodeToEventTransaction(txn, b.Hash, b.Round),
-		})
-
-		b.Events = append(b.Events, event.Event{
-			Type:  event.TypeStats,
-			Tag:   event.TagUpdateUserPayedFees,
-			Index: txn.ClientID,
-			Data: event.UserAggregate{
-				UserID:    txn.ClientID,
-				PayedFees: int64(txn.Fee),
-			},
-		})
+		b.Events = append(b.Events, CreateTxnEvents(b, txn)...)
 
 		events, err := c.UpdateState(ctx, b, bState, txn, waitC...)
 		switch err {
@@ -1044,6 +1027,29 @@ func (b *Block) ComputeState(ctx context.Context, c Chainer, waitC ...chan struc
 	return nil
 }
 
+// CreateTxnEvents returns the events recording the transaction of the block and its fee
+func CreateTxnEvents(b *Block, txn *transaction.Transaction) []event.Event {
+	return []event.Event{
+		{
+			BlockNumber: b.Round,
+			TxHash:      txn.Hash,
+			Type:        event.TypeStats,
+			Tag:         event.TagAddTransactions,
+			Index:       txn.Hash,
+			Data:        transactionNodeToEventTransaction(txn, b.Hash, b.Round),
+		},
+		{
+			Type:  event.TypeStats,
+			Tag:   event.TagUpdateUserPayedFees,
+			Index: txn.ClientID,
+			Data: event.UserAggregate{
+				UserID:    txn.ClientID,
+				PayedFees: int64(txn.Fee),
+			},
+		},
+	}
+}
+
 func transactionNodeToEventTransaction(tr *transaction.Transaction, blockHash string, round int64) event.Transaction {
 	return event.Transaction{
 		Hash:              tr.Hash,
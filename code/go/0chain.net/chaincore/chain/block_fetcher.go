@@ -481,6 +481,12 @@ func (c *Chain) getFinalizedBlockFromSharders(ctx context.Context, ticket *LFBTi
 	return nil, common.NewError("fetch_fb_from_sharders", "no FB given")
 }
 
+// GetFinalizedBlockFromSharders - request for a finalized block of the round, and of
+// the hash if given, from the sharders of the latest finalized magic block.
+func (c *Chain) GetFinalizedBlockFromSharders(ctx context.Context, hash string, rn int64) (*block.Block, error) {
+	return c.getFinalizedBlockFromSharders(ctx, &LFBTicket{Round: rn, LFBHash: hash})
+}
+
 func fbHandlerFunc(bc chan *block.Block, ticket *LFBTicket) datastore.JSONEntityReqResponderF {
 	return func(ctx context.Context, entity datastore.Entity) (resp interface{}, err error) {
 		var gfb, ok = entity.(*block.Block)
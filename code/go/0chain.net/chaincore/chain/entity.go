@@ -19,6 +19,7 @@ import (
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
 	"0chain.net/smartcontract/zcnsc"
@@ -627,6 +628,12 @@ func (c *Chain) setupInitialState(initStates *state.InitStates, gb *block.Block)
 		panic(err)
 	}
 
+	err = governancesc.InitConfig(stateCtx)
+	if err != nil {
+		logging.Logger.Error("chain.stateDB governancesc InitConfig failed", zap.Error(err))
+		panic(err)
+	}
+
 	gbInitedKey := encryption.RawHash("genesis block state init")
 	_, err = c.stateDB.GetNode(gbInitedKey)
 	switch err {
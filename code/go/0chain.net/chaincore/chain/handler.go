@@ -26,6 +26,7 @@ import (
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/config"
 	"0chain.net/core/metric"
+	"github.com/0chain/common/core/currency"
 	"go.uber.org/zap"
 
 	"0chain.net/core/build"
@@ -70,6 +71,11 @@ func minerHandlersMap(c Chainer) map[string]func(http.ResponseWriter, *http.Requ
 		),
 	))
 	m[GetBlockV1Pattern] = common.UserRateLimit(common.ToJSONResponse(GetBlockHandler))
+	m["/v1/estimate_txn_fee"] = common.WithCORS(common.UserRateLimit(
+		common.ToJSONResponse(
+			memorystore.WithConnectionHandler(MinerSuggestedFeeHandler),
+		),
+	))
 	return m
 }
 
@@ -117,6 +123,11 @@ func handlersMap(c Chainer) map[string]func(http.ResponseWriter, *http.Request)
 				SuggestedFeeHandler,
 			),
 		)),
+		"/v1/transaction/simulate": common.WithCORS(common.UserRateLimit(
+			common.ToJSONResponse(
+				SimulateTransactionHandler,
+			),
+		)),
 		"/v1/fees_table": common.WithCORS(common.UserRateLimit(
 			common.ToJSONResponse(
 				FeesTableHandler,
@@ -1376,6 +1387,10 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 	}
 	lfb = lfb.Clone()
 
+	if txn.IsExpired(lfb.Round, common.Now()) {
+		return nil, transaction.ErrTxnExpired
+	}
+
 	s, err := GetStateById(lfb.ClientState, txn.ClientID)
 	if cstate.ErrInvalidState(err) {
 		return nil, common.NewErrInternal("miner state not ready")
@@ -1397,6 +1412,16 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 		return nil, errors.New("invalid future transaction")
 	}
 
+	maxNonce := nonce + int64(sc.ChainConfig.TxnFutureNonce())
+	if err := transaction.CheckPendingSchedules(ctx, txn, nonce, maxNonce, lfb.Round, common.Now()); err != nil {
+		logging.Logger.Error("invalid transaction schedule",
+			zap.String("txn", txn.Hash),
+			zap.Int64("txn_nonce", txn.Nonce),
+			zap.Int64("nonce", nonce),
+			zap.Error(err))
+		return nil, err
+	}
+
 	if nonce+1 == txn.Nonce && txn.TransactionType == transaction.TxnTypeSend && s.Balance < txn.Value {
 		return nil, errors.New("insufficient balance to send")
 	}
@@ -1440,6 +1465,11 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 		}
 	}
 
+	pending, err := pendingTransactionToReplace(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+
 	txnRsp, err := transaction.PutTransaction(ctx, txn)
 	if err != nil {
 		logging.Logger.Error("failed to save transaction",
@@ -1448,9 +1478,110 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 		return nil, common.NewErrInternal("failed to save transaction")
 	}
 
+	if err := replacePendingTransaction(ctx, txn, pending); err != nil {
+		return nil, err
+	}
+
+	if err := transaction.IndexTxnSchedule(ctx, txn); err != nil {
+		logging.Logger.Error("failed to index transaction schedule",
+			zap.String("txn", txn.Hash),
+			zap.Error(err))
+	}
+
 	return txnRsp, nil
 }
 
+// pendingTransactionToReplace returns the pending transaction with the same client and nonce
+// if the txn pays a higher fee for it, or rejects the txn otherwise. It returns nil if there is
+// no pending transaction with the nonce.
+func pendingTransactionToReplace(ctx context.Context, txn *transaction.Transaction) (*transaction.Transaction, error) {
+	pending, err := transaction.GetPendingTxnByNonce(ctx, txn.ClientID, txn.Nonce)
+	if err != nil {
+		logging.Logger.Error("failed to get pending transaction by nonce",
+			zap.String("txn", txn.Hash),
+			zap.Error(err))
+		return nil, common.NewErrInternal("failed to get pending transaction")
+	}
+
+	if pending == nil {
+		if txn.IsCancellation() {
+			return nil, transaction.ErrNoPendingTxnToCancel
+		}
+		return nil, nil
+	}
+
+	if pending.Hash == txn.Hash {
+		return pending, nil
+	}
+
+	if err := txn.ValidateReplacement(pending); err != nil {
+		logging.Logger.Error("invalid transaction replacement",
+			zap.String("txn", txn.Hash),
+			zap.String("pending_txn", pending.Hash),
+			zap.Any("fee", txn.Fee),
+			zap.Any("pending_fee", pending.Fee),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// replacePendingTransaction swaps the nonce index to the txn, already put in the pool, and
+// removes the replaced pending transaction, nil if there is none. The pending transaction is
+// kept in the pool until the txn takes its place, and only one of concurrent replacements
+// gets the nonce index, the txn is removed from the pool if it doesn't.
+func replacePendingTransaction(ctx context.Context, txn, pending *transaction.Transaction) error {
+	if pending != nil && pending.Hash == txn.Hash {
+		return nil
+	}
+
+	if err := indexTxnNonce(ctx, txn, pending); err != nil {
+		if err := txn.Delete(ctx); err != nil {
+			logging.Logger.Error("failed to remove transaction without the nonce index",
+				zap.String("txn", txn.Hash),
+				zap.Error(err))
+		}
+		return err
+	}
+
+	if pending == nil {
+		return nil
+	}
+
+	if err := pending.Delete(ctx); err != nil {
+		logging.Logger.Error("failed to remove replaced transaction",
+			zap.String("pending_txn", pending.Hash),
+			zap.Error(err))
+		return common.NewErrInternal("failed to replace pending transaction")
+	}
+
+	logging.Logger.Info("pending transaction replaced",
+		zap.String("txn", txn.Hash),
+		zap.String("replaced_txn", pending.Hash),
+		zap.Int64("nonce", txn.Nonce),
+		zap.Bool("cancellation", txn.IsCancellation()))
+	return nil
+}
+
+func indexTxnNonce(ctx context.Context, txn, pending *transaction.Transaction) error {
+	err := transaction.IndexTxnNonce(ctx, txn, pending)
+	switch err {
+	case nil:
+		return nil
+	case transaction.ErrTxnNonceConflict:
+		logging.Logger.Error("concurrent transaction with the same nonce",
+			zap.String("txn", txn.Hash),
+			zap.Int64("nonce", txn.Nonce))
+		return err
+	default:
+		logging.Logger.Error("failed to index transaction nonce",
+			zap.String("txn", txn.Hash),
+			zap.Error(err))
+		return common.NewErrInternal("failed to index transaction nonce")
+	}
+}
+
 // RoundInfoHandler collects and writes information about current round
 func RoundInfoHandler(c Chainer) common.ReqRespHandlerf {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1946,27 +2077,73 @@ func SetupSharderHandlers(c Chainer) {
 	setupHandlers(handlersMap(c))
 }
 
+// TxnFeeEstimate is the response of the transaction fee estimation
+type TxnFeeEstimate struct {
+	Fee uint64 `json:"fee"`
+	// Replacement is set by miners if there is a pending transaction with the same
+	// client and nonce in the pool, the fee must be at least the min replacement fee.
+	Replacement *transaction.ReplacementInfo `json:"replacement,omitempty"`
+}
+
 func SuggestedFeeHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	_, fee, err := suggestedFee(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxnFeeEstimate{Fee: uint64(fee)}, nil
+}
+
+// MinerSuggestedFeeHandler estimates the transaction fee like SuggestedFeeHandler,
+// and includes the replacement rules if the transaction would replace a pending one.
+func MinerSuggestedFeeHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	tx, fee, err := suggestedFee(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &TxnFeeEstimate{Fee: uint64(fee)}
+	if tx.Nonce <= 0 {
+		return rsp, nil
+	}
+
+	pending, err := transaction.GetPendingTxnByNonce(ctx, tx.ClientID, tx.Nonce)
+	if err != nil {
+		logging.Logger.Error("failed to get pending transaction by nonce",
+			zap.String("client_id", tx.ClientID),
+			zap.Int64("nonce", tx.Nonce),
+			zap.Error(err))
+		return nil, common.NewErrInternal("failed to get pending transaction")
+	}
+
+	if pending != nil && pending.Hash != tx.Hash {
+		rsp.Replacement = transaction.NewReplacementInfo(pending)
+	}
+
+	return rsp, nil
+}
+
+func suggestedFee(ctx context.Context, r *http.Request) (*transaction.Transaction, currency.Coin, error) {
 	txData, err := io.ReadAll(r.Body)
 	if err != nil {
 		logging.Logger.Error("failed to get transaction data from request body",
 			zap.Error(err))
-		return nil, err
+		return nil, 0, err
 	}
 	defer r.Body.Close()
 
 	var tx transaction.Transaction
 	if err := json.Unmarshal(txData, &tx); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if err := tx.ComputeProperties(); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	c := GetServerChain()
 	lfb := c.GetLatestFinalizedBlock()
 	if lfb == nil {
-		return nil, errors.New("LFB not ready yet")
+		return nil, 0, errors.New("LFB not ready yet")
 	}
 
 	lfb = lfb.Clone()
@@ -1975,13 +2152,56 @@ func SuggestedFeeHandler(ctx context.Context, r *http.Request) (interface{}, err
 	if err != nil {
 		logging.Logger.Error("failed to calculate the transaction cost",
 			zap.Int("tx-type", tx.TransactionType), zap.Error(err))
+		return nil, 0, err
+	}
+
+	return &tx, fee, nil
+}
+
+// SimulateTransactionHandler executes a signed or unsigned transaction against the latest
+// finalized state without adding it to the transactions pool or changing the state.
+func SimulateTransactionHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	if r.Method != http.MethodPost {
+		return nil, common.NewError("invalid_method", "only POST method is allowed")
+	}
+
+	txData, err := io.ReadAll(r.Body)
+	if err != nil {
+		logging.Logger.Error("failed to get transaction data from request body",
+			zap.Error(err))
 		return nil, err
 	}
+	defer r.Body.Close()
+
+	var tx transaction.Transaction
+	if err := json.Unmarshal(txData, &tx); err != nil {
+		return nil, common.NewError("invalid_request", err.Error())
+	}
+
+	if err := tx.ComputeUnsignedProperties(); err != nil {
+		return nil, common.NewError("invalid_request", err.Error())
+	}
+
+	if tx.Signature != "" {
+		if err := tx.VerifyHash(ctx); err != nil {
+			return nil, err
+		}
+		if err := tx.VerifySignature(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	c := GetServerChain()
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return nil, errors.New("LFB not ready yet")
+	}
 
-	return map[string]uint64{
-		"fee": uint64(fee),
-	}, nil
+	lfb = lfb.Clone()
+
+	return c.SimulateTransaction(ctx, lfb, &tx)
 }
+
 func FeesTableHandler(ctx context.Context, r *http.Request) (interface{}, error) {
 
 	c := GetServerChain()
@@ -46,6 +46,9 @@ var (
 
 	// FBRequestor represents FB from sharders reqeustor.
 	FBRequestor node.EntityRequestor
+
+	// LatestFinalizedBlockRequestor - request the latest finalized block of a sharder.
+	LatestFinalizedBlockRequestor node.EntityRequestor
 )
 
 // setupX2MRequestors - setup requestors */
@@ -76,6 +79,8 @@ func setupX2SRequestors() {
 	}
 	FBRequestor = node.RequestEntityHandler("/v1/_x2s/block/get", &opts,
 		datastore.GetEntityMetadata("block"))
+	LatestFinalizedBlockRequestor = node.RequestEntityHandler("/v1/_m2s/block/latest_finalized/get", &opts,
+		datastore.GetEntityMetadata("block"))
 }
 
 func SetupX2XResponders(c *Chain) {
@@ -0,0 +1,158 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/minersc"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// TxnSimulationResult represents the outcome of a dry-run execution of a transaction
+// against the latest finalized state. Events, transfers and mints are only reported
+// for successful executions.
+type TxnSimulationResult struct {
+	Round         int64             `json:"round"`
+	BlockHash     string            `json:"block_hash"`
+	TxnHash       string            `json:"txn_hash"`
+	Status        int               `json:"transaction_status"`
+	Output        string            `json:"transaction_output,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	Events        []event.Event     `json:"events"`
+	Transfers     []*state.Transfer `json:"transfers"`
+	Mints         []*state.Mint     `json:"mints"`
+	EstimatedCost int               `json:"estimated_cost"`
+	EstimatedFee  currency.Coin     `json:"estimated_fee"`
+}
+
+// SimulateTransaction executes the transaction against a throwaway state context built on
+// top of the given block's client state. Neither the transactions pool nor the block state
+// is changed. Errors caused by the transaction itself are reported in the result, only
+// internal errors (e.g. missing state nodes) are returned.
+func (c *Chain) SimulateTransaction(ctx context.Context, b *block.Block,
+	txn *transaction.Transaction) (*TxnSimulationResult, error) {
+	if b.ClientState == nil {
+		return nil, errors.New("block state is not computed")
+	}
+
+	cost, fee, err := c.EstimateTransactionCostFee(ctx, b, txn)
+	if err != nil {
+		if bcstate.ErrInvalidState(err) {
+			return nil, common.NewErrInternal("state not ready")
+		}
+		return nil, fmt.Errorf("could not get estimated txn cost: %v", err)
+	}
+
+	var (
+		clientState = CreateTxnMPT(b.ClientState) // never merged back
+		sctx        = c.NewStateContext(b, clientState, txn, nil)
+		res         = &TxnSimulationResult{
+			Round:         b.Round,
+			BlockHash:     b.Hash,
+			TxnHash:       txn.Hash,
+			EstimatedCost: cost,
+			EstimatedFee:  fee,
+		}
+	)
+
+	output, err := c.simulateTransaction(ctx, sctx, txn, fee)
+	switch {
+	case err == nil:
+		res.Status = transaction.TxnSuccess
+		res.Output = output
+		res.Events = sctx.GetEvents()
+		res.Transfers = sctx.GetTransfers()
+		res.Mints = sctx.GetMints()
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, transaction.ErrSmartContractContext), bcstate.ErrInvalidState(err):
+		logging.Logger.Error("simulate transaction - internal error",
+			zap.String("txn", txn.Hash),
+			zap.Int64("round", b.Round),
+			zap.Error(err))
+		return nil, common.NewErrInternal(err.Error())
+	default:
+		// changes made by a failed transaction are rejected, same as in block state computation
+		res.Status = transaction.TxnError
+		res.Error = err.Error()
+	}
+
+	return res, nil
+}
+
+func (c *Chain) simulateTransaction(ctx context.Context, sctx *bcstate.StateContext,
+	txn *transaction.Transaction, estimatedFee currency.Coin) (string, error) {
+	if txn.Value > config.MaxTokenSupply {
+		return "", errors.New("invalid transaction value, exceeds max token supply")
+	}
+
+	if err := sctx.Validate(); err != nil {
+		return "", err
+	}
+
+	var output string
+	switch txn.TransactionType {
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeSmartContractBatch:
+		var err error
+		output, err = c.executeSmartContractTxn(ctx, txn, sctx)
+		if err != nil {
+			return "", err
+		}
+	case transaction.TxnTypeData:
+	case transaction.TxnTypeSend:
+		balance, err := sctx.GetClientBalance(txn.ClientID)
+		if !isValid(err) {
+			return "", err
+		}
+
+		if balance < txn.Fee+txn.Value {
+			return "", errors.New("insufficient balance to send")
+		}
+
+		if err := sctx.AddTransfer(state.NewTransfer(txn.ClientID, txn.ToClientID, txn.Value)); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
+	}
+
+	if c.ChainConfig.IsFeeEnabled() {
+		// unsigned transactions usually come without a fee, charge the estimated one instead
+		fee := txn.Fee
+		if fee == 0 {
+			fee = estimatedFee
+		}
+		if err := sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, fee)); err != nil {
+			return "", err
+		}
+	}
+
+	for _, t := range sctx.GetTransfers() {
+		if _, err := c.transferAmount(sctx, t.ClientID, t.ToClientID, t.Amount); err != nil {
+			return "", err
+		}
+	}
+
+	for _, st := range sctx.GetSignedTransfers() {
+		if _, err := c.transferAmount(sctx, st.ClientID, st.ToClientID, st.Amount); err != nil {
+			return "", err
+		}
+	}
+
+	for _, m := range sctx.GetMints() {
+		if _, err := c.mintAmount(sctx, m.ToClientID, m.Amount); err != nil {
+			return "", err
+		}
+	}
+
+	return output, nil
+}
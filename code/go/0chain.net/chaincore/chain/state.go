@@ -158,6 +158,63 @@ func (c *Chain) ExecuteSmartContract(
 	}
 }
 
+// ExecuteSmartContractBatch - executes the calls of a batch transaction in order
+// within the given state context. Each call runs in its own state context on top of
+// the shared state, and its transfers, mints and events are collected into the
+// batch state context once the call succeeds. Any failed call fails the whole batch.
+// The output is the JSON encoded list of the calls outputs.
+func (c *Chain) ExecuteSmartContractBatch(
+	ctx context.Context,
+	txn *transaction.Transaction,
+	balances *bcstate.StateContext) (string, error) {
+	calls, err := txn.BatchCallTransactions()
+	if err != nil {
+		return "", err
+	}
+
+	outputs := make([]string, 0, len(calls))
+	for i, ct := range calls {
+		callCtx := c.NewStateContext(balances.GetBlock(), balances.GetState(), ct, nil)
+		output, err := c.ExecuteSmartContract(ctx, ct, callCtx)
+		switch err {
+		case nil:
+		case context.DeadlineExceeded, context.Canceled, transaction.ErrSmartContractContext, util.ErrNodeNotFound:
+			// internal errors are returned as is, to be handled upwards
+			return "", err
+		default:
+			if bcstate.ErrInvalidState(err) {
+				// the missing nodes are internal errors too, not to be charged
+				return "", err
+			}
+			return "", common.NewErrorf("batch_call_failed", "call %d (%s): %v", i, ct.FunctionName, err)
+		}
+
+		if err := callCtx.Validate(); err != nil {
+			return "", common.NewErrorf("batch_call_failed", "call %d (%s): %v", i, ct.FunctionName, err)
+		}
+
+		balances.AddBatchCallResults(callCtx)
+		outputs = append(outputs, output)
+	}
+
+	out, err := json.Marshal(outputs)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func (c *Chain) executeSmartContractTxn(
+	ctx context.Context,
+	txn *transaction.Transaction,
+	balances *bcstate.StateContext) (string, error) {
+	if txn.TransactionType == transaction.TxnTypeSmartContractBatch {
+		return c.ExecuteSmartContractBatch(ctx, txn, balances)
+	}
+	return c.ExecuteSmartContract(ctx, txn, balances)
+}
+
 // UpdateState - update the state of the transaction w.r.t the given block.
 // Note, don't call this from within state computation logic since their is
 // already a lock on StateMutex. This API is for someone reading the state from
@@ -206,6 +263,27 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 		sctx        = c.NewStateContext(b, clientState, txn, nil)
 	)
 
+	checkMissingNodes := func() error {
+		missingKeys := sctx.GetMissingNodeKeys()
+		if len(missingKeys) == 0 {
+			return nil
+		}
+
+		syncOpts := &SyncReplyC{}
+		for _, opt := range opts {
+			opt(syncOpts)
+		}
+
+		logging.Logger.Error("Internal error while estimate transaction cost",
+			zap.Error(util.ErrNodeNotFound),
+			zap.Int64("round", b.Round),
+			zap.String("block", b.Hash))
+		if syncOpts.sync {
+			c.SyncMissingNodes(b.Round, missingKeys, syncOpts.replyC...)
+		}
+		return util.ErrNodeNotFound
+	}
+
 	switch txn.TransactionType {
 
 	case transaction.TxnTypeSmartContract:
@@ -219,23 +297,40 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 		}
 
 		cost, err := smartcontract.EstimateTransactionCost(txn, scData, sctx)
-		if missingKeys := sctx.GetMissingNodeKeys(); len(missingKeys) > 0 {
-			syncOpts := &SyncReplyC{}
-			for _, opt := range opts {
-				opt(syncOpts)
-			}
+		if err := checkMissingNodes(); err != nil {
+			return math.MaxInt32, err
+		}
+
+		return cost, err
+
+	case transaction.TxnTypeSmartContractBatch:
+		calls, err := txn.BatchCallTransactions()
+		if err != nil {
+			logging.Logger.Error("Error while decoding the batch transaction",
+				zap.String("input", txn.TransactionData), zap.Error(err))
+			return math.MaxInt32, err
+		}
 
-			logging.Logger.Error("Internal error while estimate transaction cost",
-				zap.Error(util.ErrNodeNotFound),
-				zap.Int64("round", b.Round),
-				zap.String("block", b.Hash))
-			if syncOpts.sync {
-				c.SyncMissingNodes(b.Round, missingKeys, syncOpts.replyC...)
+		var total int
+		for _, ct := range calls {
+			scData := sci.SmartContractTransactionData{
+				FunctionName: ct.FunctionName,
+				InputData:    ct.InputData,
 			}
-			return math.MaxInt32, util.ErrNodeNotFound
+			cost, err := smartcontract.EstimateTransactionCost(ct, scData, sctx)
+			if err := checkMissingNodes(); err != nil {
+				return math.MaxInt32, err
+			}
+			if err != nil {
+				return cost, err
+			}
+			if cost > math.MaxInt32-total {
+				return math.MaxInt32, nil
+			}
+			total += cost
 		}
 
-		return cost, err
+		return total, nil
 
 	case transaction.TxnTypeSend:
 		return c.ChainConfig.TxnTransferCost(), nil
@@ -410,9 +505,9 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 	}
 
 	switch txn.TransactionType {
-	case transaction.TxnTypeSmartContract:
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeSmartContractBatch:
 		t := time.Now()
-		output, err := c.ExecuteSmartContract(ctx, txn, sctx)
+		output, err := c.executeSmartContractTxn(ctx, txn, sctx)
 		switch err {
 		//internal errors
 		case context.DeadlineExceeded, transaction.ErrSmartContractContext, util.ErrNodeNotFound:
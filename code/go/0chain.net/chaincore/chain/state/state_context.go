@@ -91,6 +91,14 @@ type TimedQueryStateContextI interface {
 
 type Appender func(events []event.Event, current event.Event) []event.Event
 
+// CallStateContextI is implemented by the state contexts able to execute smart
+// contract calls made on behalf of another client within the same state
+type CallStateContextI interface {
+	StateContextI
+	NewCallStateContext(t *transaction.Transaction) StateContextI
+	AddBatchCallResults(call StateContextI)
+}
+
 // StateContextI - a state context interface. These interface are available for the smart contract
 //
 //go:generate mockery --case underscore --name=StateContextI --output=./mocks
@@ -342,6 +350,37 @@ func (sc *StateContext) EmitError(err error) {
 	}
 }
 
+// NewCallStateContext creates a state context for the given smart contract call
+// transaction on top of the same block and state as this one, e.g. for a call
+// made by a multi-sig wallet. The results of the call are added back with
+// AddBatchCallResults once the call is validated.
+func (sc *StateContext) NewCallStateContext(t *transaction.Transaction) StateContextI {
+	return NewStateContext(sc.block, sc.state, t,
+		sc.getMagicBlock,
+		sc.getLastestFinalizedMagicBlock,
+		sc.getChainCurrentMagicBlock,
+		sc.getSignature,
+		sc.getLatestFinalizedBlock,
+		sc.eventDb,
+	)
+}
+
+// AddBatchCallResults appends the transfers, signed transfers, mints and events
+// collected by the state context of a batch transaction call or of a call made
+// by a smart contract. The call context has to share the same state with this one.
+func (sc *StateContext) AddBatchCallResults(call StateContextI) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.transfers = append(sc.transfers, call.GetTransfers()...)
+	sc.signedTransfers = append(sc.signedTransfers, call.GetSignedTransfers()...)
+	sc.mints = append(sc.mints, call.GetMints()...)
+	// the events belong to the batch transaction, not to the hash of the call
+	for _, e := range call.GetEvents() {
+		e.TxHash = sc.txn.Hash
+		sc.events = append(sc.events, e)
+	}
+}
+
 func (sc *StateContext) GetEvents() []event.Event {
 	return sc.events
 }
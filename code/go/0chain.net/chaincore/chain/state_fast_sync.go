@@ -0,0 +1,507 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// ErrStateNodesUnavailable is returned when some state nodes of the synced state can't
+// be downloaded from any sharder, for example because the state is already pruned
+var ErrStateNodesUnavailable = common.NewError("state_nodes_unavailable",
+	"state nodes are not available on the sharders")
+
+// fastSyncTargetAttempts is the number of the state roots the fast sync tries to download
+// before giving up, a new recent round is chosen if the state of the previous one is pruned
+const fastSyncTargetAttempts = 3
+
+// FastSyncConfig is the configuration of the fast state sync
+type FastSyncConfig struct {
+	Enabled bool
+	// ChunkSize is the number of the state nodes requested at once
+	ChunkSize int
+	// Workers is the number of the chunks downloaded in parallel
+	Workers int
+	// Retries is the number of times the sharders are requested for a chunk
+	Retries int
+	// Lag is the number of rounds the synced round is behind the latest finalized round
+	// of the sharders, so that the block is available on all of them
+	Lag int64
+	// MinRoundsBehind is the number of rounds the node must be behind the network for
+	// the fast sync to be started
+	MinRoundsBehind int64
+	// ProgressFile keeps the synced round so that the sync resumes it after a restart
+	ProgressFile string
+}
+
+// ReadFastSyncConfig reads the fast state sync configuration
+func ReadFastSyncConfig(workdir string) FastSyncConfig {
+	conf := FastSyncConfig{
+		Enabled:         viper.GetBool("server_chain.state.fast_sync.enabled"),
+		ChunkSize:       viper.GetInt("server_chain.state.fast_sync.chunk_size"),
+		Workers:         viper.GetInt("server_chain.state.fast_sync.workers"),
+		Retries:         viper.GetInt("server_chain.state.fast_sync.retries"),
+		Lag:             viper.GetInt64("server_chain.state.fast_sync.lag"),
+		MinRoundsBehind: viper.GetInt64("server_chain.state.fast_sync.min_rounds_behind"),
+		ProgressFile:    viper.GetString("server_chain.state.fast_sync.progress_file"),
+	}
+	if conf.ProgressFile == "" {
+		conf.ProgressFile = "data/state_fast_sync.json"
+	}
+	if !filepath.IsAbs(conf.ProgressFile) {
+		conf.ProgressFile = filepath.Join(workdir, conf.ProgressFile)
+	}
+	return conf
+}
+
+// fastSyncProgress is the synced round that is saved to resume the sync after a restart,
+// the state nodes downloaded before are taken from the state DB
+type fastSyncProgress struct {
+	Round     int64  `json:"round"`
+	BlockHash string `json:"block_hash"`
+	StateRoot string `json:"state_root"`
+}
+
+func readFastSyncProgress(path string) (*fastSyncProgress, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	p := new(fastSyncProgress)
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *fastSyncProgress) save(path string) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// FastSyncState downloads the full state of a recent finalized block from the sharders
+// when the node is at least MinRoundsBehind rounds behind them, or when a previous sync
+// is not finished. It returns the block which state is synced, or nil if the sync is not
+// needed. The latest finalized magic block must be updated from the sharders before.
+func (c *Chain) FastSyncState(ctx context.Context, conf FastSyncConfig, localRound int64) (*block.Block, error) {
+	progress, err := readFastSyncProgress(conf.ProgressFile)
+	if err != nil {
+		logging.Logger.Warn("fast sync - can't read progress, starting over", zap.Error(err))
+		progress = nil
+	}
+
+	for i := 0; i < fastSyncTargetAttempts; i++ {
+		var b *block.Block
+		if progress != nil {
+			b, err = c.GetFinalizedBlockFromSharders(ctx, progress.BlockHash, progress.Round)
+			if err != nil {
+				logging.Logger.Warn("fast sync - can't fetch the block of the previous sync, starting over",
+					zap.Int64("round", progress.Round), zap.Error(err))
+				progress, b = nil, nil
+			} else if util.ToHex(b.ClientStateHash) != progress.StateRoot {
+				logging.Logger.Warn("fast sync - state root of the previous sync mismatch, starting over",
+					zap.Int64("round", progress.Round))
+				progress, b = nil, nil
+			}
+		}
+
+		if b == nil {
+			lfbRound, err := c.getShardersLatestFinalizedRound(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if lfbRound-localRound < conf.MinRoundsBehind {
+				logging.Logger.Info("fast sync - not needed",
+					zap.Int64("local_round", localRound),
+					zap.Int64("sharders_round", lfbRound))
+				return nil, nil
+			}
+			targetRound := lfbRound - conf.Lag
+			if targetRound < 1 {
+				targetRound = 1
+			}
+			if b, err = c.GetFinalizedBlockFromSharders(ctx, "", targetRound); err != nil {
+				return nil, common.NewErrorf("fast_sync", "fetch block of the round %d: %v", targetRound, err)
+			}
+			progress = &fastSyncProgress{
+				Round:     b.Round,
+				BlockHash: b.Hash,
+				StateRoot: util.ToHex(b.ClientStateHash),
+			}
+			if err := progress.save(conf.ProgressFile); err != nil {
+				return nil, common.NewErrorf("fast_sync", "save progress: %v", err)
+			}
+		}
+
+		logging.Logger.Info("fast sync - start",
+			zap.Int64("round", b.Round),
+			zap.String("block", b.Hash),
+			zap.String("state_root", util.ToHex(b.ClientStateHash)))
+
+		mb := c.GetLatestFinalizedMagicBlock(ctx)
+		if mb == nil {
+			return nil, common.NewError("fast_sync", "can't get latest finalized magic block")
+		}
+
+		ss := &stateSync{
+			db:        c.GetStateDB(),
+			root:      b.ClientStateHash,
+			sharders:  mb.MagicBlock.Sharders.ShuffleNodes(true),
+			fetch:     c.fetchStateNodesFrom,
+			chunkSize: conf.ChunkSize,
+			workers:   conf.Workers,
+			retries:   conf.Retries,
+		}
+		err = ss.run(ctx)
+		switch {
+		case err == nil:
+			if err := os.Remove(conf.ProgressFile); err != nil && !os.IsNotExist(err) {
+				logging.Logger.Error("fast sync - remove progress", zap.Error(err))
+			}
+			logging.Logger.Info("fast sync - done",
+				zap.Int64("round", b.Round),
+				zap.String("block", b.Hash),
+				zap.Int64("downloaded_nodes", ss.downloaded),
+				zap.Int64("local_nodes", ss.local))
+			return b, nil
+		case errors.Is(err, ErrStateNodesUnavailable):
+			// most likely the state is pruned on the sharders, the downloaded nodes are
+			// still used by the sync of a more recent round
+			logging.Logger.Warn("fast sync - state is not available, choosing a newer round",
+				zap.Int64("round", b.Round), zap.Error(err))
+			progress = nil
+		default:
+			return nil, err
+		}
+	}
+
+	return nil, common.NewError("fast_sync", "could not sync state of any recent round")
+}
+
+// getShardersLatestFinalizedRound returns the highest latest finalized round of the
+// sharders of the latest finalized magic block
+func (c *Chain) getShardersLatestFinalizedRound(ctx context.Context) (int64, error) {
+	var lfbRound int64
+	handler := func(ctx context.Context, entity datastore.Entity) (interface{}, error) {
+		b, ok := entity.(*block.Block)
+		if !ok {
+			return nil, datastore.ErrInvalidEntity
+		}
+		for {
+			r := atomic.LoadInt64(&lfbRound)
+			if b.Round <= r || atomic.CompareAndSwapInt64(&lfbRound, r, b.Round) {
+				break
+			}
+		}
+		return b, nil
+	}
+
+	mb := c.getLatestFinalizedMagicBlock(ctx)
+	if mb == nil {
+		return 0, common.NewError("fast_sync", "can't get latest finalized magic block")
+	}
+	mb.Sharders.RequestEntityFromAll(ctx, LatestFinalizedBlockRequestor, nil, handler)
+
+	if lfbRound == 0 {
+		return 0, common.NewError("fast_sync", "no latest finalized block given by sharders")
+	}
+	return lfbRound, nil
+}
+
+// fetchStateNodesFrom requests the state nodes of the keys from the sharder
+func (c *Chain) fetchStateNodesFrom(ctx context.Context, sharder *node.Node, keys []util.Key) ([]util.Node, error) {
+	params := &url.Values{}
+	for _, key := range keys {
+		params.Add("nodes", util.ToHex(key))
+	}
+
+	var ns *state.Nodes
+	handler := func(_ context.Context, entity datastore.Entity) (interface{}, error) {
+		rns, ok := entity.(*state.Nodes)
+		if !ok {
+			return nil, datastore.ErrInvalidEntity
+		}
+		ns = rns
+		return rns, nil
+	}
+
+	if !sharder.RequestEntityFromNode(ctx, StateNodesRequestor, params, handler) || ns == nil {
+		return nil, common.NewError("state_nodes_error", "error getting the state nodes")
+	}
+	return ns.Nodes, nil
+}
+
+// stateNodesFetcher requests the state nodes of the keys from the sharder
+type stateNodesFetcher func(ctx context.Context, sharder *node.Node, keys []util.Key) ([]util.Node, error)
+
+// stateSyncChunk is a set of the state nodes downloaded at once
+type stateSyncChunk struct {
+	id    int
+	keys  []util.Key
+	nodes []util.Node
+	err   error
+}
+
+// stateSync downloads all the nodes of a state that are missing in the state DB. The
+// nodes are saved as soon as a chunk is downloaded, so an interrupted sync continues
+// from the nodes saved before: the nodes found in the DB are only walked down.
+type stateSync struct {
+	db        util.NodeDB
+	root      util.Key
+	sharders  []*node.Node
+	fetch     stateNodesFetcher
+	chunkSize int
+	workers   int
+	retries   int
+
+	// downloaded and local are the numbers of the downloaded and the found state nodes
+	downloaded int64
+	local      int64
+}
+
+func (ss *stateSync) run(ctx context.Context) error {
+	sharders := make([]*node.Node, 0, len(ss.sharders))
+	for _, s := range ss.sharders {
+		if !node.Self.IsEqual(s) {
+			sharders = append(sharders, s)
+		}
+	}
+	if len(sharders) == 0 {
+		return common.NewError("fast_sync", "no sharders to sync the state from")
+	}
+	ss.sharders = sharders
+	if ss.chunkSize <= 0 {
+		ss.chunkSize = MaxStateNodesForSync
+	}
+	if ss.workers <= 0 {
+		ss.workers = 1
+	}
+	if ss.retries <= 0 {
+		ss.retries = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunkC := make(chan *stateSyncChunk)
+	resultC := make(chan *stateSyncChunk, ss.workers)
+	for i := 0; i < ss.workers; i++ {
+		go func() {
+			for ch := range chunkC {
+				ch.nodes, ch.err = ss.download(ctx, ch)
+				resultC <- ch
+			}
+		}()
+	}
+	defer close(chunkC)
+
+	var (
+		// keys are the nodes to check, a stack keeps the number of the pending keys
+		// about the trie depth times the branching factor
+		keys     = []util.Key{ss.root}
+		next     *stateSyncChunk
+		inflight int
+		chunks   int
+		lastLog  = time.Now()
+	)
+
+	for {
+		if next == nil {
+			var err error
+			if next, keys, err = ss.nextChunk(keys); err != nil {
+				return err
+			}
+			if next != nil {
+				chunks++
+				next.id = chunks
+			}
+		}
+
+		if next == nil && inflight == 0 {
+			return nil
+		}
+
+		var sendC chan *stateSyncChunk
+		if next != nil {
+			sendC = chunkC
+		}
+
+		select {
+		case sendC <- next:
+			next = nil
+			inflight++
+		case ch := <-resultC:
+			inflight--
+			if ch.err != nil {
+				return ch.err
+			}
+			chKeys := make([]util.Key, len(ch.nodes))
+			for i, n := range ch.nodes {
+				chKeys[i] = n.GetHashBytes()
+				keys = append(keys, childKeys(n)...)
+			}
+			if err := ss.db.MultiPutNode(chKeys, ch.nodes); err != nil {
+				return common.NewErrorf("fast_sync", "save state nodes: %v", err)
+			}
+			ss.downloaded += int64(len(ch.nodes))
+
+			if time.Since(lastLog) > 10*time.Second {
+				lastLog = time.Now()
+				logging.Logger.Info("fast sync - progress",
+					zap.Int64("downloaded_nodes", ss.downloaded),
+					zap.Int64("local_nodes", ss.local),
+					zap.Int("pending_keys", len(keys)))
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// nextChunk takes the keys of the nodes missing in the state DB from the stack, the
+// found nodes are replaced by their children
+func (ss *stateSync) nextChunk(keys []util.Key) (*stateSyncChunk, []util.Key, error) {
+	var missing []util.Key
+	for len(keys) > 0 && len(missing) < ss.chunkSize {
+		key := keys[len(keys)-1]
+		keys = keys[:len(keys)-1]
+
+		n, err := ss.db.GetNode(key)
+		switch {
+		case err == nil:
+			ss.local++
+			keys = append(keys, childKeys(n)...)
+		case errors.Is(err, util.ErrNodeNotFound):
+			missing = append(missing, key)
+		default:
+			return nil, nil, common.NewErrorf("fast_sync", "get state node: %v", err)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, keys, nil
+	}
+	return &stateSyncChunk{keys: missing}, keys, nil
+}
+
+// download requests the chunk nodes from the sharders in turn starting from the one
+// chosen by the chunk id, so that the parallel chunks are spread over the sharders.
+// A response having a node which hash is not one of the requested keys is rejected
+// entirely, the missing nodes of a valid response are requested from the next sharder.
+func (ss *stateSync) download(ctx context.Context, ch *stateSyncChunk) ([]util.Node, error) {
+	var (
+		pending = make(map[string]struct{}, len(ch.keys))
+		nodes   = make([]util.Node, 0, len(ch.keys))
+	)
+	for _, k := range ch.keys {
+		pending[string(k)] = struct{}{}
+	}
+
+	for i := 0; i < ss.retries*len(ss.sharders) && len(pending) > 0; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		sharder := ss.sharders[(ch.id+i)%len(ss.sharders)]
+		keys := make([]util.Key, 0, len(pending))
+		for _, k := range ch.keys {
+			if _, ok := pending[string(k)]; ok {
+				keys = append(keys, k)
+			}
+		}
+
+		got, err := ss.fetch(ctx, sharder, keys)
+		if err != nil {
+			logging.Logger.Debug("fast sync - request state nodes failed",
+				zap.String("sharder", sharder.GetPseudoName()),
+				zap.Int("keys", len(keys)),
+				zap.Error(err))
+			continue
+		}
+		if err := verifyStateNodes(keys, got); err != nil {
+			logging.Logger.Error("fast sync - invalid state nodes",
+				zap.String("sharder", sharder.GetPseudoName()),
+				zap.Error(err))
+			continue
+		}
+		for _, n := range got {
+			delete(pending, string(n.GetHashBytes()))
+			nodes = append(nodes, n)
+		}
+	}
+
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("%w: %d of %d nodes missing",
+			ErrStateNodesUnavailable, len(pending), len(ch.keys))
+	}
+	return nodes, nil
+}
+
+// verifyStateNodes checks that the hashes of the received nodes match the requested keys
+func verifyStateNodes(keys []util.Key, nodes []util.Node) error {
+	requested := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		requested[string(k)] = false
+	}
+	for _, n := range nodes {
+		if n == nil {
+			return errors.New("nil state node")
+		}
+		h := n.GetHashBytes()
+		received, ok := requested[string(h)]
+		if !ok {
+			return common.NewErrorf("state_nodes_error", "not requested node: %s", util.ToHex(h))
+		}
+		if received {
+			return common.NewErrorf("state_nodes_error", "duplicate node: %s", util.ToHex(h))
+		}
+		requested[string(h)] = true
+	}
+	return nil
+}
+
+// childKeys returns the keys of the child nodes
+func childKeys(n util.Node) []util.Key {
+	switch nd := n.(type) {
+	case *util.FullNode:
+		var keys []util.Key
+		for _, c := range nd.Children {
+			if len(c) > 0 {
+				keys = append(keys, c)
+			}
+		}
+		return keys
+	case *util.ExtensionNode:
+		return []util.Key{nd.NodeKey}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,168 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/node"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newFastSyncTestState(t *testing.T, values int) *util.MerklePatriciaTrie {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+	for i := 0; i < values; i++ {
+		v := util.SecureSerializableValue{Buffer: []byte("value " + strconv.Itoa(i))}
+		_, err := mpt.Insert(util.Path(encryption.Hash("key "+strconv.Itoa(i))), &v)
+		require.NoError(t, err)
+	}
+	return mpt
+}
+
+func newFastSyncTestSharders(n int) []*node.Node {
+	sharders := make([]*node.Node, n)
+	for i := range sharders {
+		sharders[i] = node.Provider()
+		sharders[i].ID = "sharder " + strconv.Itoa(i)
+		sharders[i].SetIndex = i
+	}
+	return sharders
+}
+
+// serveStateNodes returns a fetcher of the nodes of the db, the handle can change the
+// nodes returned by each sharder
+func serveStateNodes(db util.NodeDB, handle func(sharder *node.Node, nodes []util.Node) ([]util.Node, error)) stateNodesFetcher {
+	return func(ctx context.Context, sharder *node.Node, keys []util.Key) ([]util.Node, error) {
+		nodes, _ := db.MultiGetNode(keys)
+		if handle != nil {
+			return handle(sharder, nodes)
+		}
+		return nodes, nil
+	}
+}
+
+func requireStateSynced(t *testing.T, mpt *util.MerklePatriciaTrie, db util.NodeDB, values int) {
+	synced := util.NewMerklePatriciaTrie(db, 1, mpt.GetRoot())
+	for i := 0; i < values; i++ {
+		var v util.SecureSerializableValue
+		require.NoError(t, synced.GetNodeValue(util.Path(encryption.Hash("key "+strconv.Itoa(i))), &v))
+		require.Equal(t, []byte("value "+strconv.Itoa(i)), v.Buffer)
+	}
+}
+
+func TestStateSync(t *testing.T) {
+	const values = 200
+	mpt := newFastSyncTestState(t, values)
+
+	t.Run("parallel chunks from sharders", func(t *testing.T) {
+		var (
+			mutex    sync.Mutex
+			requests = make(map[string]int)
+		)
+		db := util.NewMemoryNodeDB()
+		ss := &stateSync{
+			db:       db,
+			root:     mpt.GetRoot(),
+			sharders: newFastSyncTestSharders(3),
+			fetch: serveStateNodes(mpt.GetNodeDB(), func(sharder *node.Node, nodes []util.Node) ([]util.Node, error) {
+				mutex.Lock()
+				requests[sharder.ID]++
+				mutex.Unlock()
+				return nodes, nil
+			}),
+			chunkSize: 10,
+			workers:   4,
+		}
+		require.NoError(t, ss.run(context.TODO()))
+		requireStateSynced(t, mpt, db, values)
+		var stateNodes int64
+		err := mpt.Iterate(context.TODO(), func(ctx context.Context, path util.Path, key util.Key, node util.Node) error {
+			stateNodes++
+			return nil
+		}, util.NodeTypeLeafNode|util.NodeTypeFullNode|util.NodeTypeExtensionNode)
+		require.NoError(t, err)
+		require.Equal(t, stateNodes, ss.downloaded)
+		require.Len(t, requests, 3)
+	})
+
+	t.Run("invalid and partial chunks", func(t *testing.T) {
+		sharders := newFastSyncTestSharders(3)
+		other := newFastSyncTestState(t, 10)
+		otherNodes, err := other.GetNodeDB().MultiGetNode([]util.Key{other.GetRoot()})
+		require.NoError(t, err)
+
+		db := util.NewMemoryNodeDB()
+		ss := &stateSync{
+			db:       db,
+			root:     mpt.GetRoot(),
+			sharders: sharders,
+			fetch: serveStateNodes(mpt.GetNodeDB(), func(sharder *node.Node, nodes []util.Node) ([]util.Node, error) {
+				switch sharder.ID {
+				case sharders[0].ID:
+					// a node of another state
+					return append(nodes, otherNodes[0]), nil
+				case sharders[1].ID:
+					// the half of the nodes only
+					return nodes[:len(nodes)/2], nil
+				default:
+					return nodes, nil
+				}
+			}),
+			chunkSize: 16,
+			workers:   2,
+		}
+		require.NoError(t, ss.run(context.TODO()))
+		requireStateSynced(t, mpt, db, values)
+		_, err = db.GetNode(other.GetRoot())
+		require.ErrorIs(t, err, util.ErrNodeNotFound)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		db := util.NewMemoryNodeDB()
+		var fetched int
+		ss := &stateSync{
+			db:       db,
+			root:     mpt.GetRoot(),
+			sharders: newFastSyncTestSharders(1),
+			fetch: serveStateNodes(mpt.GetNodeDB(), func(sharder *node.Node, nodes []util.Node) ([]util.Node, error) {
+				fetched++
+				if fetched > 3 {
+					return nil, errors.New("interrupted")
+				}
+				return nodes, nil
+			}),
+			chunkSize: 8,
+			workers:   1,
+		}
+		require.ErrorIs(t, ss.run(context.TODO()), ErrStateNodesUnavailable)
+		saved := ss.downloaded
+		require.NotZero(t, saved)
+
+		ss = &stateSync{
+			db:        db,
+			root:      mpt.GetRoot(),
+			sharders:  newFastSyncTestSharders(1),
+			fetch:     serveStateNodes(mpt.GetNodeDB(), nil),
+			chunkSize: 8,
+			workers:   1,
+		}
+		require.NoError(t, ss.run(context.TODO()))
+		require.Equal(t, saved, ss.local)
+		requireStateSynced(t, mpt, db, values)
+	})
+}
+
+func TestVerifyStateNodes(t *testing.T) {
+	mpt := newFastSyncTestState(t, 10)
+	nodes, err := mpt.GetNodeDB().MultiGetNode([]util.Key{mpt.GetRoot()})
+	require.NoError(t, err)
+
+	require.NoError(t, verifyStateNodes([]util.Key{mpt.GetRoot()}, nodes))
+	require.NoError(t, verifyStateNodes([]util.Key{mpt.GetRoot(), util.Key("missing")}, nodes))
+	require.Error(t, verifyStateNodes([]util.Key{util.Key("other")}, nodes))
+	require.Error(t, verifyStateNodes([]util.Key{mpt.GetRoot()}, append(nodes, nodes[0])))
+}
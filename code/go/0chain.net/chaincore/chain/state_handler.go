@@ -18,6 +18,7 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/faucetsc"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
@@ -53,6 +54,7 @@ func SetupScRestApiHandlers() {
 		storagesc.SetupRestHandler(restHandler)
 		vestingsc.SetupRestHandler(restHandler)
 		zcnsc.SetupRestHandler(restHandler)
+		governancesc.SetupRestHandler(restHandler)
 
 	} else {
 		logging.Logger.Warn("cannot find event database, REST API will not be supported on this sharder")
@@ -242,6 +244,8 @@ func GetFunctionNames(address string) []string {
 		endpoints = vestingsc.GetEndpoints(nil)
 	case zcnsc.ADDRESS:
 		endpoints = zcnsc.GetEndpoints(nil)
+	case governancesc.ADDRESS:
+		endpoints = governancesc.GetEndpoints(nil)
 	default:
 		return []string{}
 	}
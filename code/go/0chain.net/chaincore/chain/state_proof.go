@@ -0,0 +1,145 @@
+package chain
+
+import (
+	"bytes"
+	"net/http"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/core/stateproof"
+	"github.com/0chain/common/core/util"
+)
+
+// StateProofPath returns the MPT path of the state proof request, which is
+// one of a client balance (client_id), a smart contract trie key (key) or
+// a raw MPT path (path).
+func StateProofPath(r *http.Request) (util.Path, error) {
+	var (
+		clientID = r.FormValue("client_id")
+		key      = r.FormValue("key")
+		path     = r.FormValue("path")
+		paths    []util.Path
+	)
+	if clientID != "" {
+		paths = append(paths, util.Path(clientID))
+	}
+	if key != "" {
+		paths = append(paths, util.Path(encryption.Hash(key)))
+	}
+	if path != "" {
+		paths = append(paths, util.Path(path))
+	}
+	if len(paths) != 1 {
+		return nil, common.InvalidRequest("exactly one of client_id, key or path is required")
+	}
+
+	for _, c := range paths[0] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return nil, common.InvalidRequest("the path must be a hex string")
+		}
+	}
+	return paths[0], nil
+}
+
+// GetStateProof returns the proof of the value at the path of the client
+// state of the block, or of its absence.
+func (c *Chain) GetStateProof(b *block.Block, path util.Path) (*stateproof.Proof, error) {
+	if len(b.ClientStateHash) == 0 {
+		return nil, common.NewError("state_proof", "block has no state hash")
+	}
+
+	nodes, value, err := getStateProofNodes(c.GetStateDB(), b.ClientStateHash, path)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &stateproof.Proof{
+		Block:  newStateProofHeader(b),
+		Path:   string(path),
+		Nodes:  nodes,
+		Value:  value,
+		Exists: value != nil,
+	}
+	return proof, nil
+}
+
+func newStateProofHeader(b *block.Block) stateproof.Header {
+	h := stateproof.Header{
+		Hash:              b.Hash,
+		MinerID:           b.MinerID,
+		PrevHash:          b.PrevHash,
+		CreationDate:      int64(b.CreationDate),
+		Round:             b.Round,
+		RoundRandomSeed:   b.GetRoundRandomSeed(),
+		StateChangesCount: b.StateChangesCount,
+		MerkleTreeRoot:    b.GetMerkleTree().GetRoot(),
+		ReceiptMerkleRoot: b.GetReceiptsMerkleTree().GetRoot(),
+		StateHash:         util.ToHex(b.ClientStateHash),
+		Signature:         b.Signature,
+	}
+	if b.MagicBlock != nil {
+		h.MagicBlockHash = b.MagicBlock.Hash
+		if h.MagicBlockHash == "" {
+			h.MagicBlockHash = b.MagicBlock.GetHash()
+		}
+	}
+	for _, vt := range b.GetVerificationTickets() {
+		h.VerificationTickets = append(h.VerificationTickets, stateproof.Ticket{
+			VerifierID: vt.VerifierID,
+			Signature:  vt.Signature,
+		})
+	}
+	return h
+}
+
+// getStateProofNodes collects the encoded nodes from the root down the path,
+// up to the value or to the node proving there is no value at the path
+func getStateProofNodes(db util.NodeDB, root util.Key, path util.Path) ([]string, []byte, error) {
+	var (
+		nodes []string
+		key   = root
+	)
+	for {
+		n, err := db.GetNode(key)
+		if err != nil {
+			if err == util.ErrNodeNotFound {
+				return nil, nil, common.NewErrorf("state_proof",
+					"state node %s is not available", util.ToHex(key))
+			}
+			return nil, nil, err
+		}
+		nodes = append(nodes, util.ToHex(n.Encode()))
+
+		switch nodeImpl := n.(type) {
+		case *util.LeafNode:
+			if !bytes.Equal(nodeImpl.Path, path) {
+				return nodes, nil, nil
+			}
+			return nodes, valueOrNil(nodeImpl.GetValueBytes()), nil
+		case *util.FullNode:
+			if len(path) == 0 {
+				return nodes, valueOrNil(nodeImpl.GetValueBytes()), nil
+			}
+			child := nodeImpl.GetChild(path[0])
+			if child == nil {
+				return nodes, nil, nil
+			}
+			key, path = child, path[1:]
+		case *util.ExtensionNode:
+			if len(nodeImpl.Path) == 0 || !bytes.HasPrefix(path, nodeImpl.Path) {
+				return nodes, nil, nil
+			}
+			key, path = nodeImpl.NodeKey, path[len(nodeImpl.Path):]
+		default:
+			return nil, nil, common.NewErrorf("state_proof", "unexpected state node type %T", n)
+		}
+	}
+}
+
+func valueOrNil(v []byte) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
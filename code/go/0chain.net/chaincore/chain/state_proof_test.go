@@ -0,0 +1,74 @@
+package chain
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/encryption"
+	"0chain.net/core/stateproof"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStateProofNodes(t *testing.T) {
+	const values = 100
+	mpt := newFastSyncTestState(t, values)
+	root := mpt.GetRoot()
+
+	for i := 0; i < values; i++ {
+		path := util.Path(encryption.Hash("key " + strconv.Itoa(i)))
+		nodes, value, err := getStateProofNodes(mpt.GetNodeDB(), root, path)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value "+strconv.Itoa(i)), value)
+
+		verified, err := stateproof.VerifyPath(root, path, decodeProofNodes(t, nodes))
+		require.NoError(t, err)
+		require.Equal(t, value, verified)
+	}
+
+	// exclusion
+	path := util.Path(encryption.Hash("missing key"))
+	nodes, value, err := getStateProofNodes(mpt.GetNodeDB(), root, path)
+	require.NoError(t, err)
+	require.Nil(t, value)
+	verified, err := stateproof.VerifyPath(root, path, decodeProofNodes(t, nodes))
+	require.NoError(t, err)
+	require.Nil(t, verified)
+
+	// the inclusion nodes of a key don't prove another key
+	other := util.Path(encryption.Hash("key 1"))
+	nodes, _, err = getStateProofNodes(mpt.GetNodeDB(), root, util.Path(encryption.Hash("key 0")))
+	require.NoError(t, err)
+	verified, err = stateproof.VerifyPath(root, other, decodeProofNodes(t, nodes))
+	require.True(t, err != nil || verified == nil)
+}
+
+func TestStateProofHeader(t *testing.T) {
+	b := block.NewBlock("", 10)
+	b.MinerID = encryption.Hash("miner")
+	b.PrevHash = encryption.Hash("block 9")
+	b.RoundRandomSeed = 42
+	b.ClientStateHash = util.Key(encryption.RawHash("state"))
+	b.HashBlock()
+
+	h := newStateProofHeader(b)
+	require.NoError(t, h.Verify())
+	require.Equal(t, util.ToHex(b.ClientStateHash), h.StateHash)
+
+	b.MagicBlock = block.NewMagicBlock()
+	b.HashBlock()
+	h = newStateProofHeader(b)
+	require.NoError(t, h.Verify())
+}
+
+func decodeProofNodes(t *testing.T, nodes []string) [][]byte {
+	decoded := make([][]byte, len(nodes))
+	for i, n := range nodes {
+		var err error
+		decoded[i], err = hex.DecodeString(n)
+		require.NoError(t, err)
+	}
+	return decoded
+}
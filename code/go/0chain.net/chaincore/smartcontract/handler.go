@@ -89,6 +89,26 @@ func EstimateTransactionCost(t *transaction.Transaction, scData sci.SmartContrac
 		//return math.MaxInt, errors.New("no cost found for function")
 		return math.MaxInt, nil
 	}
+
+	caller, ok := contractObj.(sci.SmartContractCallerI)
+	if !ok {
+		return cost, nil
+	}
+	calls, err := caller.GetCalls(scData.FunctionName, scData.InputData)
+	if err != nil {
+		return math.MaxInt, err
+	}
+	for _, call := range calls {
+		ct := &transaction.Transaction{ClientID: t.ClientID, ToClientID: call.Address}
+		callCost, err := EstimateTransactionCost(ct, call.SmartContractTransactionData, balances)
+		if err != nil {
+			return callCost, err
+		}
+		if callCost > math.MaxInt-cost {
+			return math.MaxInt, nil
+		}
+		cost += callCost
+	}
 	return cost, nil
 }
 
@@ -40,6 +40,20 @@ type SmartContractInterface interface {
 	GetCostTable(balances c_state.StateContextI) (map[string]int, error)
 }
 
+// SmartContractCall is a call of a smart contract function made by another
+// smart contract within the calling transaction.
+type SmartContractCall struct {
+	Address string
+	SmartContractTransactionData
+}
+
+// SmartContractCallerI is implemented by the smart contracts whose functions
+// may call other smart contracts. The costs of the calls are a part of the
+// cost of the calling function.
+type SmartContractCallerI interface {
+	GetCalls(funcName string, input []byte) ([]SmartContractCall, error)
+}
+
 /*
 BCContextI interface for smart contracts to access blockchain.
 These functions should not modify blockchain states in anyway.
@@ -0,0 +1,124 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+)
+
+// MaxSmartContractBatchCalls is the max number of calls a batch transaction could carry
+const MaxSmartContractBatchCalls = 20
+
+var (
+	ErrEmptyBatch        = errors.New("batch transaction has no calls")
+	ErrBatchTooLarge     = fmt.Errorf("batch transaction exceeds the max number of calls (%d)", MaxSmartContractBatchCalls)
+	ErrBatchValueExceeds = errors.New("sum of the batch calls values exceeds the transaction value")
+)
+
+// SmartContractCall represents a single smart contract call of a batch transaction
+type SmartContractCall struct {
+	SmartContractData
+	// Address of the smart contract to call
+	Address string `json:"address"`
+	// Value is the part of the transaction value passed to the call
+	Value currency.Coin `json:"value,omitempty"`
+}
+
+// SmartContractBatchData is passed in Transaction.TransactionData of the
+// TxnTypeSmartContractBatch transactions. The calls are executed in order in
+// one state context and either all of them are committed or none.
+type SmartContractBatchData struct {
+	Calls []SmartContractCall `json:"calls"`
+}
+
+// Validate checks the batch calls against the transaction value
+func (bd *SmartContractBatchData) Validate(value currency.Coin) error {
+	if len(bd.Calls) == 0 {
+		return ErrEmptyBatch
+	}
+
+	if len(bd.Calls) > MaxSmartContractBatchCalls {
+		return ErrBatchTooLarge
+	}
+
+	var total currency.Coin
+	for i, call := range bd.Calls {
+		if !encryption.IsHash(call.Address) {
+			return fmt.Errorf("batch call %d: invalid smart contract address", i)
+		}
+		if call.FunctionName == "" {
+			return fmt.Errorf("batch call %d: missing function name", i)
+		}
+
+		var err error
+		total, err = currency.AddCoin(total, call.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if total > value {
+		return ErrBatchValueExceeds
+	}
+
+	return nil
+}
+
+// GetBatchData decodes and validates the calls of a batch transaction
+func (t *Transaction) GetBatchData() (*SmartContractBatchData, error) {
+	if t.TransactionType != TxnTypeSmartContractBatch {
+		return nil, fmt.Errorf("not a batch transaction: %d", t.TransactionType)
+	}
+
+	var bd SmartContractBatchData
+	if err := json.Unmarshal([]byte(t.TransactionData), &bd); err != nil {
+		return nil, fmt.Errorf("invalid batch transaction data: %v", err)
+	}
+
+	if err := bd.Validate(t.Value); err != nil {
+		return nil, err
+	}
+
+	return &bd, nil
+}
+
+// BatchCallHash returns the hash of the i-th call of the batch transaction, the calls
+// have distinct hashes since the smart contracts use the hash as an ID
+func (t *Transaction) BatchCallHash(i int) string {
+	return encryption.Hash(fmt.Sprintf("%s:%d", t.Hash, i))
+}
+
+// BatchCallTransactions returns a smart contract transaction for each call of the
+// batch transaction. The calls keep the client and nonce of the batch transaction,
+// so that they are seen by the smart contracts as part of it, and get a hash of
+// their own. The fee is charged on the batch transaction only.
+func (t *Transaction) BatchCallTransactions() ([]*Transaction, error) {
+	bd, err := t.GetBatchData()
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*Transaction, 0, len(bd.Calls))
+	for i, call := range bd.Calls {
+		data, err := json.Marshal(call.SmartContractData)
+		if err != nil {
+			return nil, err
+		}
+
+		ct := t.Clone()
+		ct.Hash = t.BatchCallHash(i)
+		ct.TransactionType = TxnTypeSmartContract
+		ct.TransactionData = string(data)
+		ct.ToClientID = call.Address
+		ct.Value = call.Value
+		ct.Fee = 0
+		scData := call.SmartContractData
+		ct.SmartContractData = &scData
+		txns = append(txns, ct)
+	}
+
+	return txns, nil
+}
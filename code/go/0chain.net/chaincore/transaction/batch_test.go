@@ -0,0 +1,106 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTxn(t *testing.T, value currency.Coin, calls ...SmartContractCall) *Transaction {
+	data, err := json.Marshal(SmartContractBatchData{Calls: calls})
+	require.NoError(t, err)
+	return &Transaction{
+		HashIDField:     datastore.HashIDField{Hash: encryption.Hash("batch")},
+		ClientID:        encryption.Hash("client"),
+		TransactionType: TxnTypeSmartContractBatch,
+		TransactionData: string(data),
+		Value:           value,
+		Fee:             10,
+		Nonce:           3,
+	}
+}
+
+func TestBatchCallTransactions(t *testing.T) {
+	var (
+		storageSC = encryption.Hash("storage")
+		minerSC   = encryption.Hash("miner")
+	)
+
+	txn := newBatchTxn(t, 30,
+		SmartContractCall{
+			SmartContractData: SmartContractData{FunctionName: "stake_pool_lock", InputData: json.RawMessage(`{"provider_id":"p"}`)},
+			Address:           minerSC,
+			Value:             10,
+		},
+		SmartContractCall{
+			SmartContractData: SmartContractData{FunctionName: "new_allocation_request", InputData: json.RawMessage(`{}`)},
+			Address:           storageSC,
+			Value:             20,
+		},
+	)
+
+	calls, err := txn.BatchCallTransactions()
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+
+	require.Equal(t, TxnTypeSmartContract, calls[0].TransactionType)
+	require.Equal(t, minerSC, calls[0].ToClientID)
+	require.Equal(t, "stake_pool_lock", calls[0].FunctionName)
+	require.Equal(t, currency.Coin(10), calls[0].Value)
+	require.JSONEq(t, `{"name":"stake_pool_lock","input":{"provider_id":"p"}}`, calls[0].TransactionData)
+
+	require.Equal(t, storageSC, calls[1].ToClientID)
+	require.Equal(t, currency.Coin(20), calls[1].Value)
+
+	require.NotEqual(t, calls[0].Hash, calls[1].Hash)
+	for i, c := range calls {
+		require.Equal(t, txn.BatchCallHash(i), c.Hash)
+		require.NotEqual(t, txn.Hash, c.Hash)
+		require.Equal(t, txn.ClientID, c.ClientID)
+		require.Equal(t, txn.Nonce, c.Nonce)
+		require.Zero(t, c.Fee)
+	}
+}
+
+func TestBatchDataValidate(t *testing.T) {
+	call := SmartContractCall{
+		SmartContractData: SmartContractData{FunctionName: "write_pool_lock"},
+		Address:           encryption.Hash("storage"),
+		Value:             5,
+	}
+
+	tt := []struct {
+		name  string
+		value currency.Coin
+		calls []SmartContractCall
+		err   error
+	}{
+		{name: "ok", value: 10, calls: []SmartContractCall{call, call}},
+		{name: "empty", value: 10, err: ErrEmptyBatch},
+		{name: "value exceeds", value: 9, calls: []SmartContractCall{call, call}, err: ErrBatchValueExceeds},
+		{name: "too large", value: 1000, calls: make([]SmartContractCall, MaxSmartContractBatchCalls+1), err: ErrBatchTooLarge},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bd := SmartContractBatchData{Calls: tc.calls}
+			err := bd.Validate(tc.value)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("invalid address", func(t *testing.T) {
+		bad := call
+		bad.Address = "not a hash"
+		bd := SmartContractBatchData{Calls: []SmartContractCall{bad}}
+		require.Error(t, bd.Validate(10))
+	})
+}
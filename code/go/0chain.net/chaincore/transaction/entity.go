@@ -68,6 +68,12 @@ type Transaction struct {
 	Fee             currency.Coin    `json:"transaction_fee" msgpack:"f"`
 	Nonce           int64            `json:"transaction_nonce" msgpack:"n"`
 
+	// the optional schedule of the transaction, see IsScheduled
+	ValidFromRound int64            `json:"valid_from_round,omitempty" msgpack:"vfr,omitempty"`
+	ValidFrom      common.Timestamp `json:"valid_from,omitempty" msgpack:"vf,omitempty"`
+	ExpiresAtRound int64            `json:"expires_at_round,omitempty" msgpack:"ear,omitempty"`
+	ExpiresAt      common.Timestamp `json:"expires_at,omitempty" msgpack:"ea,omitempty"`
+
 	TransactionType   int    `json:"transaction_type" msgpack:"tt"`
 	TransactionOutput string `json:"transaction_output,omitempty" msgpack:"o,omitempty"`
 	OutputHash        string `json:"txn_output_hash" msgpack:"oh"`
@@ -89,6 +95,34 @@ func (t *Transaction) GetEntityMetadata() datastore.EntityMetadata {
 
 /*ComputeProperties - Entity implementation */
 func (t *Transaction) ComputeProperties() error {
+	if err := t.computeDataProperties(); err != nil {
+		return err
+	}
+	return t.ComputeClientID()
+}
+
+// ComputeUnsignedProperties computes the transaction properties like ComputeProperties,
+// but allows the public key to be omitted when the client id is given, so that
+// unsigned transactions could be processed, e.g. by the simulation endpoint.
+// The hash is computed when it is not provided.
+func (t *Transaction) ComputeUnsignedProperties() error {
+	if err := t.computeDataProperties(); err != nil {
+		return err
+	}
+
+	if t.PublicKey != "" || t.ClientID == "" {
+		if err := t.ComputeClientID(); err != nil {
+			return err
+		}
+	}
+
+	if t.Hash == "" {
+		t.Hash = t.ComputeHash()
+	}
+	return nil
+}
+
+func (t *Transaction) computeDataProperties() error {
 	t.EntityCollection = txnEntityCollection
 	if t.ChainID == "" {
 		t.ChainID = datastore.ToKey(config.GetServerChainID())
@@ -100,7 +134,12 @@ func (t *Transaction) ComputeProperties() error {
 			return fmt.Errorf("invalid smart contract data: %v", err)
 		}
 	}
-	return t.ComputeClientID()
+	if t.TransactionType == TxnTypeSmartContractBatch {
+		if _, err := t.GetBatchData(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SmartContractData represents the smart contract data
@@ -177,7 +216,10 @@ func (t *Transaction) ValidateWrtTimeForBlock(ctx context.Context, ts common.Tim
 	if t.Hash == "" {
 		return common.InvalidRequest("hash required for transaction")
 	}
-	if !common.WithinTime(int64(ts), int64(t.CreationDate), TXN_TIME_TOLERANCE) {
+	if err := t.validateSchedule(); err != nil {
+		return err
+	}
+	if !t.WithinTime(ts) {
 		return common.InvalidRequest(fmt.Sprintf("Transaction creation time not within tolerance: ts=%v txn.creation_date=%v", ts, t.CreationDate))
 	}
 	if t.ClientID == t.ToClientID {
@@ -236,6 +278,9 @@ func (t *Transaction) Delete(ctx context.Context) error {
 
 var txnEntityCollection *datastore.EntityCollection
 
+// txnCollectionDuration is how long the transactions are kept in the pool collection
+const txnCollectionDuration = time.Hour
+
 /*GetCollectionName - override to partition by chain id */
 func (t *Transaction) GetCollectionName() string {
 	return txnEntityCollection.GetCollectionName(t.ChainID)
@@ -274,6 +319,10 @@ func (t *Transaction) HashData() string {
 	s.WriteString(strconv.FormatUint(uint64(t.Value), 10))
 	s.WriteString(":")
 	s.WriteString(encryption.Hash(t.TransactionData))
+	if t.IsScheduled() {
+		s.WriteString(":")
+		s.WriteString(t.scheduleHashData())
+	}
 	return s.String()
 }
 
@@ -380,7 +429,7 @@ func SetupEntity(store datastore.Store) {
 	transactionEntityMetadata.Store = store
 
 	datastore.RegisterEntityMetadata("txn", transactionEntityMetadata)
-	txnEntityCollection = &datastore.EntityCollection{CollectionName: "collection.txn", CollectionSize: 60000000, CollectionDuration: time.Hour}
+	txnEntityCollection = &datastore.EntityCollection{CollectionName: "collection.txn", CollectionSize: 60000000, CollectionDuration: txnCollectionDuration}
 
 	var chunkingOptions = datastore.ChunkingOptions{
 		EntityMetadata:   transactionEntityMetadata,
@@ -452,6 +501,10 @@ func (t *Transaction) Clone() *Transaction {
 		CreationDate:      t.CreationDate,
 		Fee:               t.Fee,
 		Nonce:             t.Nonce,
+		ValidFromRound:    t.ValidFromRound,
+		ValidFrom:         t.ValidFrom,
+		ExpiresAtRound:    t.ExpiresAtRound,
+		ExpiresAt:         t.ExpiresAt,
 		TransactionType:   t.TransactionType,
 		TransactionOutput: t.TransactionOutput,
 		OutputHash:        t.OutputHash,
@@ -0,0 +1,142 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/memorystore"
+	"github.com/0chain/common/core/currency"
+	"github.com/gomodule/redigo/redis"
+)
+
+// CancelTxnData is the transaction data of a cancellation transaction. A cancellation is a
+// zero value TxnTypeData transaction replacing the pending transaction with the same nonce,
+// so that only the fee is charged and the nonce is consumed.
+const CancelTxnData = "cancel"
+
+var (
+	ErrTxnReplacementUnderpriced = common.NewError("replacement_underpriced",
+		"replacement transaction fee must be higher than the fee of the pending transaction")
+	ErrNoPendingTxnToCancel = common.NewError("no_pending_transaction",
+		"no pending transaction with the same nonce to cancel")
+	ErrTxnNonceConflict = common.NewError("nonce_conflict",
+		"another transaction with the same nonce was just submitted, retry")
+)
+
+// ReplacementInfo describes the pending transaction that would be replaced by a
+// transaction with the same client and nonce.
+type ReplacementInfo struct {
+	PendingTxnHash    string        `json:"pending_txn_hash"`
+	PendingFee        currency.Coin `json:"pending_fee"`
+	MinReplacementFee currency.Coin `json:"min_replacement_fee"`
+}
+
+// NewReplacementInfo returns the replacement rules of the pending transaction
+func NewReplacementInfo(pending *Transaction) *ReplacementInfo {
+	return &ReplacementInfo{
+		PendingTxnHash:    pending.Hash,
+		PendingFee:        pending.Fee,
+		MinReplacementFee: MinReplacementFee(pending),
+	}
+}
+
+// MinReplacementFee returns the min fee a transaction must pay to replace the pending one
+func MinReplacementFee(pending *Transaction) currency.Coin {
+	return pending.Fee + 1
+}
+
+// IsCancellation checks whether the transaction is a cancellation of a pending transaction
+func (t *Transaction) IsCancellation() bool {
+	return t.TransactionType == TxnTypeData && t.Value == 0 && t.TransactionData == CancelTxnData
+}
+
+// ValidateReplacement checks whether the transaction could replace the pending one
+func (t *Transaction) ValidateReplacement(pending *Transaction) error {
+	if t.ClientID != pending.ClientID || t.Nonce != pending.Nonce {
+		return common.NewError("invalid_replacement", "client and nonce must match the pending transaction")
+	}
+
+	if t.Fee < MinReplacementFee(pending) {
+		return ErrTxnReplacementUnderpriced
+	}
+
+	return nil
+}
+
+func nonceIndexKey(clientID string, nonce int64) string {
+	return fmt.Sprintf("txn_nonce:%s:%d", clientID, nonce)
+}
+
+var (
+	// swapNonceIndexScript sets the nonce index to the hash of the new transaction
+	// (ARGV[2]) only if it still has the hash of the replaced one (ARGV[1]), or has no
+	// hash if there is no pending transaction, so that two concurrent replacements
+	// of the same transaction can't both get into the pool. Indexing the same
+	// transaction again succeeds.
+	swapNonceIndexScript = redis.NewScript(1, `
+local cur = redis.call("GET", KEYS[1]) or ""
+if cur ~= ARGV[1] and cur ~= ARGV[2] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "EX", ARGV[3])
+return 1`)
+
+	// deleteNonceIndexScript removes the nonce index only if it still has the given hash
+	deleteNonceIndexScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// GetPendingTxnByNonce returns the transaction of the client with the given nonce from
+// the transactions pool, or nil if there is no such transaction.
+func GetPendingTxnByNonce(ctx context.Context, clientID string, nonce int64) (*Transaction, error) {
+	c := memorystore.GetEntityCon(ctx, transactionEntityMetadata)
+	key := nonceIndexKey(clientID, nonce)
+	hash, err := redis.String(c.Do("GET", key))
+	if err == redis.ErrNil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	txn := transactionEntityMetadata.Instance().(*Transaction)
+	if err := txn.Read(ctx, hash); err != nil {
+		if cerr, ok := err.(*common.Error); ok && cerr.Code == datastore.EntityNotFound {
+			// already included in a block or expired, the stale index is removed
+			// unless it was replaced meanwhile
+			if _, err := deleteNonceIndexScript.Do(c, key, hash); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return txn, nil
+}
+
+// IndexTxnNonce indexes the pending transaction by its client and nonce in place of the
+// given pending transaction, nil if there is none. It fails with ErrTxnNonceConflict if
+// the index has changed since the pending transaction was read. The index expires
+// together with the transaction.
+func IndexTxnNonce(ctx context.Context, t *Transaction, pending *Transaction) error {
+	var replaced string
+	if pending != nil {
+		replaced = pending.Hash
+	}
+
+	c := memorystore.GetEntityCon(ctx, transactionEntityMetadata)
+	ok, err := redis.Bool(swapNonceIndexScript.Do(c, nonceIndexKey(t.ClientID, t.Nonce), replaced, t.Hash, t.poolTTL()))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTxnNonceConflict
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package transaction
+
+import (
+	"testing"
+
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReplacement(t *testing.T) {
+	clientID := encryption.Hash("client")
+	pending := &Transaction{
+		HashIDField: datastore.HashIDField{Hash: encryption.Hash("pending")},
+		ClientID:    clientID,
+		Nonce:       5,
+		Fee:         100,
+	}
+
+	tt := []struct {
+		name string
+		txn  *Transaction
+		err  bool
+	}{
+		{name: "higher fee", txn: &Transaction{ClientID: clientID, Nonce: 5, Fee: 101}},
+		{name: "same fee", txn: &Transaction{ClientID: clientID, Nonce: 5, Fee: 100}, err: true},
+		{name: "lower fee", txn: &Transaction{ClientID: clientID, Nonce: 5, Fee: 10}, err: true},
+		{name: "other nonce", txn: &Transaction{ClientID: clientID, Nonce: 6, Fee: 1000}, err: true},
+		{name: "other client", txn: &Transaction{ClientID: encryption.Hash("other"), Nonce: 5, Fee: 1000}, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.txn.ValidateReplacement(pending)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	info := NewReplacementInfo(pending)
+	require.Equal(t, pending.Hash, info.PendingTxnHash)
+	require.Equal(t, pending.Fee, info.PendingFee)
+	require.Equal(t, pending.Fee+1, info.MinReplacementFee)
+}
+
+func TestIsCancellation(t *testing.T) {
+	require.True(t, (&Transaction{TransactionType: TxnTypeData, TransactionData: CancelTxnData}).IsCancellation())
+	require.False(t, (&Transaction{TransactionType: TxnTypeData, TransactionData: CancelTxnData, Value: 1}).IsCancellation())
+	require.False(t, (&Transaction{TransactionType: TxnTypeSend, TransactionData: CancelTxnData}).IsCancellation())
+	require.False(t, (&Transaction{TransactionType: TxnTypeData, TransactionData: "data"}).IsCancellation())
+}
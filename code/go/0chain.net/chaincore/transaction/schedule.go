@@ -0,0 +1,200 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/core/memorystore"
+	"github.com/gomodule/redigo/redis"
+)
+
+// A transaction can be scheduled to become valid only from a round and/or a time, and to
+// expire after a round and/or a time. The schedule is a part of the transaction hash, so
+// it's signed by the client. A scheduled transaction is kept in the transactions pool and
+// included by the miners once it's eligible, at most MaxScheduleDuration after its
+// creation. It still consumes the client nonce in order, so a transaction which would
+// wait in the pool for a scheduled transaction not yet eligible is rejected when it's
+// submitted, see CheckPendingSchedules.
+
+// MaxScheduleDuration is how long a scheduled transaction stays valid after its creation
+// at most, the duration of the transactions collection of the pool
+const MaxScheduleDuration = txnCollectionDuration
+
+var (
+	ErrTxnNotYetValid = common.NewError("txn_not_yet_valid",
+		"transaction is scheduled for a later round or time")
+	ErrTxnExpired          = common.NewError("txn_expired", "transaction has expired")
+	ErrTxnWaitsForSchedule = common.NewError("txn_waits_for_schedule",
+		"transaction would wait for a scheduled transaction of the client not yet valid")
+)
+
+// IsScheduled checks whether the transaction has a schedule
+func (t *Transaction) IsScheduled() bool {
+	return t.ValidFromRound != 0 || t.ValidFrom != 0 || t.ExpiresAtRound != 0 || t.ExpiresAt != 0
+}
+
+func (t *Transaction) scheduleHashData() string {
+	s := strings.Builder{}
+	s.WriteString(strconv.FormatInt(t.ValidFromRound, 10))
+	s.WriteString(":")
+	s.WriteString(common.TimeToString(t.ValidFrom))
+	s.WriteString(":")
+	s.WriteString(strconv.FormatInt(t.ExpiresAtRound, 10))
+	s.WriteString(":")
+	s.WriteString(common.TimeToString(t.ExpiresAt))
+	return s.String()
+}
+
+func (t *Transaction) validateSchedule() error {
+	if t.ValidFromRound < 0 || t.ValidFrom < 0 || t.ExpiresAtRound < 0 || t.ExpiresAt < 0 {
+		return common.InvalidRequest("transaction schedule can't be negative")
+	}
+	if t.ExpiresAtRound > 0 && t.ExpiresAtRound < t.ValidFromRound {
+		return common.InvalidRequest("transaction expires before the round it becomes valid")
+	}
+	if t.ExpiresAt > 0 && (t.ExpiresAt < t.ValidFrom || t.ExpiresAt < t.CreationDate) {
+		return common.InvalidRequest("transaction expires before it becomes valid")
+	}
+	if t.ValidFrom > t.CreationDate+maxScheduleSeconds {
+		return common.InvalidRequest("transaction is scheduled too far after its creation")
+	}
+	return nil
+}
+
+const maxScheduleSeconds = common.Timestamp(MaxScheduleDuration / time.Second)
+
+// expiresAt returns the time the scheduled transaction expires: its ExpiresAt, but no
+// later than MaxScheduleDuration after its creation
+func (t *Transaction) expiresAt() common.Timestamp {
+	expiresAt := t.CreationDate + maxScheduleSeconds
+	if t.ExpiresAt > 0 && t.ExpiresAt < expiresAt {
+		return t.ExpiresAt
+	}
+	return expiresAt
+}
+
+// IsEligible checks whether the transaction could be included in a block of the round
+// created at the given time
+func (t *Transaction) IsEligible(round int64, ts common.Timestamp) bool {
+	return round >= t.ValidFromRound && ts >= t.ValidFrom
+}
+
+// IsExpired checks whether the scheduled transaction has expired by the round and the time
+func (t *Transaction) IsExpired(round int64, ts common.Timestamp) bool {
+	if !t.IsScheduled() {
+		return false
+	}
+	return t.ExpiresAtRound > 0 && round > t.ExpiresAtRound || ts > t.expiresAt()
+}
+
+// ValidateSchedule checks the transaction could be included in a block of the round
+// created at the given time
+func (t *Transaction) ValidateSchedule(round int64, ts common.Timestamp) error {
+	if t.IsExpired(round, ts) {
+		return ErrTxnExpired
+	}
+	if !t.IsEligible(round, ts) {
+		return ErrTxnNotYetValid
+	}
+	return nil
+}
+
+// WithinTime checks the transaction creation date is within the time tolerance of the
+// given time. A scheduled transaction is usually created long before it's eligible, so
+// only its creation in the future is limited, and it's kept valid until it expires.
+func (t *Transaction) WithinTime(ts common.Timestamp) bool {
+	if !t.IsScheduled() {
+		return common.WithinTime(int64(ts), int64(t.CreationDate), TXN_TIME_TOLERANCE)
+	}
+	return int64(t.CreationDate) <= int64(ts)+TXN_TIME_TOLERANCE && ts <= t.expiresAt()
+}
+
+// poolTTL returns the number of seconds the transaction could stay in the pool
+func (t *Transaction) poolTTL() int64 {
+	if !t.IsScheduled() {
+		return TXN_TIME_TOLERANCE
+	}
+	if ttl := int64(t.expiresAt()-common.Now()) + 1; ttl > 0 {
+		return ttl
+	}
+	return 1
+}
+
+func scheduleIndexKey(clientID string, nonce int64) string {
+	return fmt.Sprintf("txn_schedule:%s:%d", clientID, nonce)
+}
+
+// IndexTxnSchedule indexes the schedule of the pending transaction by its client and
+// nonce, or removes the index of a replaced scheduled transaction. The index expires
+// together with the transaction.
+func IndexTxnSchedule(ctx context.Context, t *Transaction) error {
+	c := memorystore.GetEntityCon(ctx, transactionEntityMetadata)
+	key := scheduleIndexKey(t.ClientID, t.Nonce)
+	if !t.IsScheduled() {
+		_, err := c.Do("DEL", key)
+		return err
+	}
+	schedule := fmt.Sprintf("%d:%d:%d", t.ValidFromRound, t.ValidFrom, t.ExpiresAtRound)
+	_, err := c.Do("SET", key, schedule, "EX", t.poolTTL())
+	return err
+}
+
+// CheckPendingSchedules rejects the transaction if it would wait in the pool for a scheduled
+// transaction of its client not yet eligible by the round and the time: a pending scheduled
+// transaction with a nonce between the client nonce and the transaction nonce, or, for a
+// scheduled transaction, a pending transaction with a nonce up to maxNonce.
+func CheckPendingSchedules(ctx context.Context, t *Transaction, clientNonce, maxNonce int64,
+	round int64, ts common.Timestamp) error {
+
+	c := memorystore.GetEntityCon(ctx, transactionEntityMetadata)
+
+	if t.IsScheduled() && !t.IsEligible(round, ts) && t.Nonce < maxNonce {
+		keys := make([]interface{}, 0, maxNonce-t.Nonce)
+		for n := t.Nonce + 1; n <= maxNonce; n++ {
+			keys = append(keys, nonceIndexKey(t.ClientID, n))
+		}
+		hashes, err := redis.Strings(c.Do("MGET", keys...))
+		if err != nil {
+			return err
+		}
+		for _, h := range hashes {
+			if h != "" {
+				return ErrTxnWaitsForSchedule
+			}
+		}
+	}
+
+	if t.Nonce <= clientNonce+1 {
+		return nil
+	}
+
+	keys := make([]interface{}, 0, t.Nonce-clientNonce-1)
+	for n := clientNonce + 1; n < t.Nonce; n++ {
+		keys = append(keys, scheduleIndexKey(t.ClientID, n))
+	}
+	schedules, err := redis.Strings(c.Do("MGET", keys...))
+	if err != nil {
+		return err
+	}
+	for _, s := range schedules {
+		if s == "" {
+			continue
+		}
+		pending := &Transaction{}
+		if _, err := fmt.Sscanf(s, "%d:%d:%d",
+			&pending.ValidFromRound, &pending.ValidFrom, &pending.ExpiresAtRound); err != nil {
+			return err
+		}
+		if pending.ExpiresAtRound > 0 && round > pending.ExpiresAtRound {
+			continue
+		}
+		if !pending.IsEligible(round, ts) {
+			return ErrTxnWaitsForSchedule
+		}
+	}
+	return nil
+}
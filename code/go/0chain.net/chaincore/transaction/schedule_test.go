@@ -0,0 +1,110 @@
+package transaction
+
+import (
+	"testing"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	txn := &Transaction{
+		CreationDate:   100,
+		ValidFromRound: 10,
+		ValidFrom:      1000,
+		ExpiresAtRound: 20,
+		ExpiresAt:      2000,
+	}
+
+	tt := []struct {
+		name  string
+		round int64
+		ts    common.Timestamp
+		err   error
+	}{
+		{name: "eligible", round: 10, ts: 1000},
+		{name: "last round", round: 20, ts: 2000},
+		{name: "round not yet valid", round: 9, ts: 1500, err: ErrTxnNotYetValid},
+		{name: "time not yet valid", round: 15, ts: 999, err: ErrTxnNotYetValid},
+		{name: "expired by round", round: 21, ts: 1500, err: ErrTxnExpired},
+		{name: "expired by time", round: 15, ts: 2001, err: ErrTxnExpired},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := txn.ValidateSchedule(tc.round, tc.ts)
+			if tc.err != nil {
+				require.Equal(t, tc.err, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	require.Error(t, (&Transaction{ValidFromRound: 10, ExpiresAtRound: 5}).validateSchedule())
+	require.Error(t, (&Transaction{CreationDate: 100, ExpiresAt: 50}).validateSchedule())
+	require.Error(t, (&Transaction{ValidFrom: -1}).validateSchedule())
+	require.Error(t, (&Transaction{CreationDate: 100, ValidFrom: 100 + maxScheduleSeconds + 1}).validateSchedule())
+	require.NoError(t, txn.validateSchedule())
+}
+
+func TestScheduledTxnExpiry(t *testing.T) {
+	// a scheduled transaction without an expiration expires MaxScheduleDuration after its creation
+	txn := &Transaction{CreationDate: 100, ValidFromRound: 10}
+	require.False(t, txn.IsExpired(1000, 100+maxScheduleSeconds))
+	require.True(t, txn.IsExpired(1000, 100+maxScheduleSeconds+1))
+	require.False(t, txn.WithinTime(100+maxScheduleSeconds+1))
+
+	txn.ExpiresAt = 200
+	require.True(t, txn.IsExpired(1000, 201))
+
+	txn.ExpiresAt = 0
+	txn.ExpiresAtRound = 20
+	require.True(t, txn.IsExpired(21, 150))
+	require.False(t, (&Transaction{CreationDate: 100}).IsExpired(1000, 100+maxScheduleSeconds+1))
+
+	// the pool keeps a scheduled transaction no longer than it's valid
+	txn = &Transaction{CreationDate: common.Now(), ValidFromRound: 10}
+	require.True(t, txn.poolTTL() > 0)
+	require.True(t, txn.poolTTL() <= int64(maxScheduleSeconds)+1)
+}
+
+func TestScheduledTxnWithinTime(t *testing.T) {
+	prev := TXN_TIME_TOLERANCE
+	SetTxnTimeout(10)
+	defer SetTxnTimeout(prev)
+
+	txn := &Transaction{CreationDate: 100}
+	require.True(t, txn.WithinTime(105))
+	require.False(t, txn.WithinTime(1000))
+
+	// a scheduled transaction is valid long after its creation, but not before it
+	txn.ValidFrom = 500
+	require.True(t, txn.WithinTime(1000))
+	require.False(t, txn.WithinTime(50))
+
+	txn.ExpiresAt = 900
+	require.False(t, txn.WithinTime(1000))
+}
+
+func TestScheduleHash(t *testing.T) {
+	txn := &Transaction{
+		ClientID:        encryption.Hash("client"),
+		ToClientID:      encryption.Hash("to"),
+		CreationDate:    100,
+		Nonce:           1,
+		TransactionData: "data",
+	}
+	hash := txn.ComputeHash()
+
+	// the hash of the transactions without a schedule doesn't change
+	require.Equal(t, encryption.Hash("100:1:"+txn.ClientID+":"+txn.ToClientID+":0:"+encryption.Hash("data")), hash)
+
+	txn.ValidFromRound = 10
+	scheduled := txn.ComputeHash()
+	require.NotEqual(t, hash, scheduled)
+
+	txn.ValidFromRound = 11
+	require.NotEqual(t, scheduled, txn.ComputeHash())
+}
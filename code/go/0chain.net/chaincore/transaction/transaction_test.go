@@ -12,6 +12,7 @@ import (
 	"0chain.net/core/config"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"0chain.net/core/logging"
 	"0chain.net/core/memorystore"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +25,7 @@ var sigSchemes = make([]encryption.SignatureScheme, 0, 1000)
 var clientSignatureScheme = "bls0chain"
 
 func init() {
+	logging.InitLogging("testing", "")
 	client.SetClientSignatureScheme(clientSignatureScheme)
 }
 
@@ -195,3 +197,34 @@ func processWorker(txnChannel <-chan *Transaction, done chan<- bool) {
 		done <- true
 	}
 }
+
+func TestComputeUnsignedProperties(t *testing.T) {
+	clientID := encryption.Hash("client")
+
+	t.Run("client id without public key", func(t *testing.T) {
+		txn := &Transaction{
+			ClientID:        clientID,
+			TransactionType: TxnTypeSmartContract,
+			TransactionData: `{"name":"new_allocation_request","input":{}}`,
+			CreationDate:    common.Now(),
+			Nonce:           1,
+		}
+		require.NoError(t, txn.ComputeUnsignedProperties())
+		require.Equal(t, "new_allocation_request", txn.FunctionName)
+		require.Equal(t, txn.ComputeHash(), txn.Hash)
+	})
+
+	t.Run("missing client id and public key", func(t *testing.T) {
+		txn := &Transaction{TransactionType: TxnTypeSend}
+		require.ErrorIs(t, txn.ComputeUnsignedProperties(), ErrTxnMissingPublicKey)
+	})
+
+	t.Run("invalid smart contract data", func(t *testing.T) {
+		txn := &Transaction{
+			ClientID:        clientID,
+			TransactionType: TxnTypeSmartContract,
+			TransactionData: "not json",
+		}
+		require.Error(t, txn.ComputeUnsignedProperties())
+	})
+}
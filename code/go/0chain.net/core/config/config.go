@@ -36,6 +36,12 @@ func SetupDefaultConfig() {
 	viper.SetDefault("server_chain.block.consensus.threshold_by_count", 66)
 	viper.SetDefault("server_chain.block.generation.timeout", 37)
 	viper.SetDefault("server_chain.state.sync.timeout", 10)
+	viper.SetDefault("server_chain.state.fast_sync.enabled", false)
+	viper.SetDefault("server_chain.state.fast_sync.chunk_size", 1000)
+	viper.SetDefault("server_chain.state.fast_sync.workers", 8)
+	viper.SetDefault("server_chain.state.fast_sync.retries", 3)
+	viper.SetDefault("server_chain.state.fast_sync.lag", 10)
+	viper.SetDefault("server_chain.state.fast_sync.min_rounds_behind", 1000)
 	viper.SetDefault("server_chain.stuck.check_interval", 10)
 	viper.SetDefault("server_chain.stuck.time_threshold", 60)
 	viper.SetDefault("server_chain.transaction.timeout", 30)
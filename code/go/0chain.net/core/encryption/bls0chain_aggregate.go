@@ -1,12 +1,13 @@
 package encryption
 
 import (
+	"encoding/hex"
 	"errors"
 
 	"github.com/herumi/bls/ffi/go/bls"
 )
 
-//BLS0ChainAggregateSignatureScheme - a scheme that can aggregate signatures for BLS0Chain signature scheme
+// BLS0ChainAggregateSignatureScheme - a scheme that can aggregate signatures for BLS0Chain signature scheme
 type BLS0ChainAggregateSignatureScheme struct {
 	Total     int
 	BatchSize int
@@ -14,7 +15,7 @@ type BLS0ChainAggregateSignatureScheme struct {
 	AGt       []*bls.GT
 }
 
-//NewBLS0ChainAggregateSignature - create a new instance
+// NewBLS0ChainAggregateSignature - create a new instance
 func NewBLS0ChainAggregateSignature(total int, batchSize int) *BLS0ChainAggregateSignatureScheme {
 	b0a := &BLS0ChainAggregateSignatureScheme{Total: total, BatchSize: batchSize}
 	numBatches := total / batchSize
@@ -26,7 +27,7 @@ func NewBLS0ChainAggregateSignature(total int, batchSize int) *BLS0ChainAggregat
 	return b0a
 }
 
-//Aggregate - implement interface
+// Aggregate - implement interface
 func (b0a BLS0ChainAggregateSignatureScheme) Aggregate(ss SignatureScheme, idx int, signature string, hash string) error {
 	b0sig, ok := ss.(*BLS0ChainScheme)
 	if !ok {
@@ -54,7 +55,7 @@ func (b0a BLS0ChainAggregateSignatureScheme) Aggregate(ss SignatureScheme, idx i
 	return nil
 }
 
-//Verify - implement interface
+// Verify - implement interface
 func (b0a BLS0ChainAggregateSignatureScheme) Verify() (bool, error) {
 	agtmul := b0a.AGt[0]
 	asig := b0a.ASigs[0]
@@ -73,3 +74,37 @@ func (b0a BLS0ChainAggregateSignatureScheme) Verify() (bool, error) {
 	}
 	return true, nil
 }
+
+// BLS0ChainVerifyAggregatedHash - verify a signature aggregated from the signatures of the same hash
+// by all the given public keys with a single pairing check. The public keys are summed up, so the
+// possession of every key must have been proven beforehand to rule out rogue key attacks
+func BLS0ChainVerifyAggregatedHash(publicKeys []string, signature string, hash string) (bool, error) {
+	if len(publicKeys) == 0 {
+		return false, errors.New("no public keys to verify the aggregate signature")
+	}
+	var aggPk bls.PublicKey
+	for i, publicKey := range publicKeys {
+		publicKeyBytes, err := hex.DecodeString(MiraclToHerumiPK(publicKey))
+		if err != nil {
+			return false, err
+		}
+		pk, err := decodePublicKey(publicKeyBytes)
+		if err != nil {
+			return false, errors.New("failed to decode public key")
+		}
+		if i == 0 {
+			aggPk = *pk
+			continue
+		}
+		aggPk.Add(pk)
+	}
+	var sign bls.Sign
+	if err := sign.DeserializeHexStr(MiraclToHerumiSig(signature)); err != nil {
+		return false, err
+	}
+	rawHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return false, err
+	}
+	return sign.Verify(&aggPk, string(rawHash)), nil
+}
@@ -291,3 +291,43 @@ func TestBLS0ChainAggregateSignatureScheme_Aggregate(t *testing.T) {
 		})
 	}
 }
+
+func TestBLS0ChainVerifyAggregatedHash(t *testing.T) {
+	total := 5
+	hash := Hash("testing aggregate signature of the same message")
+	pubKeys := make([]string, total)
+	signatures := make([]string, total)
+	for i := 0; i < total; i++ {
+		scheme := NewBLS0ChainScheme()
+		require.NoError(t, scheme.GenerateKeys())
+		pubKeys[i] = scheme.GetPublicKey()
+		sig, err := scheme.Sign(hash)
+		require.NoError(t, err)
+		signatures[i] = sig
+	}
+
+	aggSig, err := NewBLS0ChainScheme().AggregateSignatures(signatures[:3])
+	require.NoError(t, err)
+
+	ok, err := BLS0ChainVerifyAggregatedHash(pubKeys[:3], aggSig, hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// a public key that did not sign
+	ok, err = BLS0ChainVerifyAggregatedHash(pubKeys[:4], aggSig, hash)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// a missing public key
+	ok, err = BLS0ChainVerifyAggregatedHash(pubKeys[:2], aggSig, hash)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// another message
+	ok, err = BLS0ChainVerifyAggregatedHash(pubKeys[:3], aggSig, Hash("another message"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = BLS0ChainVerifyAggregatedHash(nil, aggSig, hash)
+	require.Error(t, err)
+}
@@ -0,0 +1,318 @@
+// Package stateproof verifies the client state inclusion and exclusion proofs
+// served by the sharders. It depends neither on the node storage nor on the
+// BLS libraries, so it can be used by the light clients and the bridges as is.
+//
+// A proof links a value of the state MPT to the ClientStateHash of a finalized
+// block. Note that neither the block hash nor the block signatures and the
+// verification tickets cover the ClientStateHash, so a single proof only shows
+// the value is in the state with the given root; the root itself is as
+// trustworthy as the sharder serving it. To not depend on a single sharder,
+// get the proof from several independent sharders and check them with
+// VerifyQuorum. Binding the state root to the signed block data would need a
+// change of the consensus.
+package stateproof
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// ErrInvalidProof is returned when the nodes of a proof don't lead from
+	// the state root to the value (or to its absence).
+	ErrInvalidProof = errors.New("invalid state proof")
+	// ErrInvalidBlockHash is returned when the block header fields don't hash
+	// to the block hash.
+	ErrInvalidBlockHash = errors.New("invalid block hash")
+	// ErrStateMismatch is returned when the proofs of different sharders
+	// don't agree on the block, its state root or the value.
+	ErrStateMismatch = errors.New("state proofs mismatch")
+)
+
+// the MPT node types and the separator of the node fields
+const (
+	nodeTypeLeaf      = 2
+	nodeTypeFull      = 4
+	nodeTypeExtension = 8
+	nodeTypesAll      = 1 | nodeTypeLeaf | nodeTypeFull | nodeTypeExtension
+
+	separator = ':'
+	// type byte, version and origin
+	nodePrefixLen = 1 + 8 + 8
+	keyLen        = 32
+)
+
+// Ticket is a verification ticket of the block.
+type Ticket struct {
+	VerifierID string `json:"verifier_id"`
+	Signature  string `json:"signature"`
+}
+
+// Header is the set of the block fields needed to recompute the block hash
+// and to get its state root.
+type Header struct {
+	Hash                string   `json:"hash"`
+	MinerID             string   `json:"miner_id"`
+	PrevHash            string   `json:"prev_hash"`
+	CreationDate        int64    `json:"creation_date"`
+	Round               int64    `json:"round"`
+	RoundRandomSeed     int64    `json:"round_random_seed"`
+	StateChangesCount   int      `json:"state_changes_count"`
+	MerkleTreeRoot      string   `json:"merkle_tree_root"`
+	ReceiptMerkleRoot   string   `json:"receipt_merkle_tree_root"`
+	MagicBlockHash      string   `json:"magic_block_hash,omitempty"`
+	StateHash           string   `json:"state_hash"`
+	Signature           string   `json:"signature"`
+	VerificationTickets []Ticket `json:"verification_tickets"`
+}
+
+// ComputeHash computes the block hash from the header fields the same way the
+// miners do.
+func (h *Header) ComputeHash() string {
+	var sb strings.Builder
+	sb.WriteString(h.MinerID)
+	sb.WriteByte(':')
+	sb.WriteString(h.PrevHash)
+	sb.WriteByte(':')
+	sb.WriteString(strconv.FormatInt(h.CreationDate, 10))
+	sb.WriteByte(':')
+	sb.WriteString(strconv.FormatInt(h.Round, 10))
+	sb.WriteByte(':')
+	sb.WriteString(strconv.FormatInt(h.RoundRandomSeed, 10))
+	sb.WriteByte(':')
+	sb.WriteString(strconv.Itoa(h.StateChangesCount))
+	sb.WriteByte(':')
+	sb.WriteString(h.MerkleTreeRoot)
+	sb.WriteByte(':')
+	sb.WriteString(h.ReceiptMerkleRoot)
+	if h.MagicBlockHash != "" {
+		sb.WriteByte(':')
+		sb.WriteString(h.MagicBlockHash)
+	}
+	return hex.EncodeToString(rawHash([]byte(sb.String())))
+}
+
+// Verify checks the header fields hash to the block hash.
+func (h *Header) Verify() error {
+	if h.ComputeHash() != h.Hash {
+		return ErrInvalidBlockHash
+	}
+	return nil
+}
+
+// Proof is a proof of a value of the client state at a finalized block, or of
+// its absence.
+type Proof struct {
+	Block Header `json:"block"`
+	// Path is the MPT path of the value.
+	Path string `json:"path"`
+	// Nodes are the hex encoded MPT nodes from the state root down the path.
+	Nodes []string `json:"nodes"`
+	// Value is the msgpack encoded value, empty when the value doesn't exist.
+	Value  []byte `json:"value,omitempty"`
+	Exists bool   `json:"exists"`
+}
+
+// Verify verifies the block hash and the path of the nodes from the state root
+// of the block, and returns the proved value, nil for an exclusion proof. The
+// state root is taken as is, see VerifyQuorum.
+func (p *Proof) Verify() ([]byte, error) {
+	if err := p.Block.Verify(); err != nil {
+		return nil, err
+	}
+
+	root, err := hex.DecodeString(p.Block.StateHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: state hash: %v", ErrInvalidProof, err)
+	}
+
+	nodes := make([][]byte, len(p.Nodes))
+	for i, n := range p.Nodes {
+		if nodes[i], err = hex.DecodeString(n); err != nil {
+			return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+		}
+	}
+
+	value, err := VerifyPath(root, []byte(p.Path), nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Exists != (value != nil) || !bytes.Equal(p.Value, value) {
+		return nil, fmt.Errorf("%w: the value doesn't match the nodes", ErrInvalidProof)
+	}
+	return value, nil
+}
+
+// VerifyQuorum verifies the proofs of the same path at the same block served by
+// different sharders, and returns the proved value if at least quorum proofs
+// agree on the block, its state root and the value. Telling the sharders apart
+// is left to the caller.
+func VerifyQuorum(quorum int, proofs ...*Proof) ([]byte, error) {
+	if quorum < 1 || len(proofs) < quorum {
+		return nil, fmt.Errorf("%w: %d proofs for a quorum of %d", ErrStateMismatch, len(proofs), quorum)
+	}
+
+	first := proofs[0]
+	value, err := first.Verify()
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range proofs[1:] {
+		if p.Block.Hash != first.Block.Hash || p.Block.StateHash != first.Block.StateHash || p.Path != first.Path {
+			return nil, fmt.Errorf("%w: proof %d", ErrStateMismatch, i+1)
+		}
+		v, err := p.Verify()
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(v, value) {
+			return nil, fmt.Errorf("%w: proof %d", ErrStateMismatch, i+1)
+		}
+	}
+	return value, nil
+}
+
+// VerifyPath walks the encoded MPT nodes from the root along the path, and
+// returns the value at the path or nil if the nodes prove there is no value.
+func VerifyPath(root, path []byte, nodes [][]byte) ([]byte, error) {
+	key := root
+	for i, enc := range nodes {
+		if len(enc) < nodePrefixLen || !bytes.Equal(nodeHash(enc), key) {
+			return nil, fmt.Errorf("%w: node %d doesn't match its key", ErrInvalidProof, i)
+		}
+
+		last := i == len(nodes)-1
+		body := enc[nodePrefixLen:]
+		switch enc[0] & nodeTypesAll {
+		case nodeTypeLeaf:
+			leafPath, value, err := decodeLeaf(body)
+			if err != nil {
+				return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+			}
+			if !last {
+				return nil, fmt.Errorf("%w: nodes after a leaf", ErrInvalidProof)
+			}
+			if !bytes.Equal(leafPath, path) || len(value) == 0 {
+				return nil, nil
+			}
+			return value, nil
+		case nodeTypeFull:
+			children, value, err := decodeFull(body)
+			if err != nil {
+				return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+			}
+			if len(path) == 0 {
+				if !last {
+					return nil, fmt.Errorf("%w: nodes after the end of the path", ErrInvalidProof)
+				}
+				if len(value) == 0 {
+					return nil, nil
+				}
+				return value, nil
+			}
+			idx, ok := childIndex(path[0])
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid path", ErrInvalidProof)
+			}
+			if children[idx] == nil {
+				if !last {
+					return nil, fmt.Errorf("%w: nodes after a missing child", ErrInvalidProof)
+				}
+				return nil, nil
+			}
+			key, path = children[idx], path[1:]
+		case nodeTypeExtension:
+			extPath, nodeKey, err := decodeExtension(body)
+			if err != nil {
+				return nil, fmt.Errorf("%w: node %d: %v", ErrInvalidProof, i, err)
+			}
+			if len(extPath) == 0 || !bytes.HasPrefix(path, extPath) {
+				if !last {
+					return nil, fmt.Errorf("%w: nodes after a diverged path", ErrInvalidProof)
+				}
+				return nil, nil
+			}
+			key, path = nodeKey, path[len(extPath):]
+		default:
+			return nil, fmt.Errorf("%w: node %d: unexpected node type %d", ErrInvalidProof, i, enc[0])
+		}
+	}
+
+	if len(root) == 0 && len(nodes) == 0 {
+		// empty state
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: missing nodes", ErrInvalidProof)
+}
+
+// nodeHash returns the hash of an encoded node, that is the hash of its origin
+// and body
+func nodeHash(enc []byte) []byte {
+	return rawHash(enc[nodePrefixLen-8:])
+}
+
+func decodeLeaf(body []byte) (path, value []byte, err error) {
+	// prefix:path:value
+	idx := bytes.IndexByte(body, separator)
+	if idx < 0 {
+		return nil, nil, errors.New("invalid leaf node")
+	}
+	body = body[idx+1:]
+	idx = bytes.IndexByte(body, separator)
+	if idx < 0 {
+		return nil, nil, errors.New("invalid leaf node")
+	}
+	return body[:idx], body[idx+1:], nil
+}
+
+func decodeFull(body []byte) (children [16][]byte, value []byte, err error) {
+	for i := range children {
+		idx := bytes.IndexByte(body, separator)
+		if idx < 0 {
+			return children, nil, errors.New("invalid full node")
+		}
+		if idx > 0 {
+			child := make([]byte, keyLen)
+			if n, err := hex.Decode(child, body[:idx]); err != nil || n != keyLen {
+				return children, nil, errors.New("invalid full node child")
+			}
+			children[i] = child
+		}
+		body = body[idx+1:]
+	}
+	return children, body, nil
+}
+
+func decodeExtension(body []byte) (path, key []byte, err error) {
+	idx := bytes.IndexByte(body, separator)
+	if idx < 0 {
+		return nil, nil, errors.New("invalid extension node")
+	}
+	return body[:idx], body[idx+1:], nil
+}
+
+func childIndex(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'a' && c <= 'f':
+		return int(10 + c - 'a'), true
+	case c >= 'A' && c <= 'F':
+		return int(10 + c - 'A'), true
+	default:
+		return 0, false
+	}
+}
+
+func rawHash(data []byte) []byte {
+	h := sha3.New256()
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,143 @@
+package stateproof
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeNode(nodeType byte, origin int64, body []byte) []byte {
+	buf := bytes.NewBuffer([]byte{nodeType})
+	_ = binary.Write(buf, binary.LittleEndian, origin) // version
+	_ = binary.Write(buf, binary.LittleEndian, origin)
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func leafNode(prefix, path, value string) []byte {
+	return encodeNode(nodeTypeLeaf, 1, []byte(prefix+":"+path+":"+value))
+}
+
+func fullNode(children map[byte][]byte) []byte {
+	var body []byte
+	for i := 0; i < 16; i++ {
+		c := "0123456789abcdef"[i]
+		if child, ok := children[c]; ok {
+			body = append(body, hex.EncodeToString(nodeHash(child))...)
+		}
+		body = append(body, separator)
+	}
+	return encodeNode(nodeTypeFull, 1, body)
+}
+
+func extensionNode(path string, child []byte) []byte {
+	return encodeNode(nodeTypeExtension, 1, append([]byte(path+":"), nodeHash(child)...))
+}
+
+func TestVerifyPath(t *testing.T) {
+	var (
+		leaf1 = leafNode("e1", "23", "value 1")
+		leaf2 = leafNode("ea", "bc", "value 2")
+		full  = fullNode(map[byte][]byte{'1': leaf1, 'a': leaf2})
+		ext   = extensionNode("e", full)
+		root  = nodeHash(ext)
+	)
+
+	tests := []struct {
+		name  string
+		path  string
+		nodes [][]byte
+		value []byte
+		err   bool
+	}{
+		{name: "inclusion", path: "e123", nodes: [][]byte{ext, full, leaf1}, value: []byte("value 1")},
+		{name: "other leaf", path: "eabc", nodes: [][]byte{ext, full, leaf2}, value: []byte("value 2")},
+		{name: "leaf path mismatch", path: "e124", nodes: [][]byte{ext, full, leaf1}},
+		{name: "missing child", path: "e523", nodes: [][]byte{ext, full}},
+		{name: "diverged extension", path: "f123", nodes: [][]byte{ext}},
+		{name: "missing nodes", path: "e123", nodes: [][]byte{ext, full}, err: true},
+		{name: "extra nodes", path: "e523", nodes: [][]byte{ext, full, leaf1}, err: true},
+		{name: "wrong child", path: "e123", nodes: [][]byte{ext, full, leaf2}, err: true},
+		{name: "tampered leaf", path: "e123", nodes: [][]byte{ext, full, leafNode("e1", "23", "value 3")}, err: true},
+		{name: "short node", path: "e123", nodes: [][]byte{{nodeTypeLeaf}}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := VerifyPath(root, []byte(tt.path), tt.nodes)
+			if tt.err {
+				require.ErrorIs(t, err, ErrInvalidProof)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestProofVerify(t *testing.T) {
+	leaf := leafNode("", "abc", "value")
+	p := &Proof{
+		Block: Header{
+			MinerID:           "miner",
+			PrevHash:          "prev",
+			CreationDate:      100,
+			Round:             10,
+			RoundRandomSeed:   42,
+			MerkleTreeRoot:    "txns",
+			ReceiptMerkleRoot: "receipts",
+			StateHash:         hex.EncodeToString(nodeHash(leaf)),
+		},
+		Path:   "abc",
+		Nodes:  []string{hex.EncodeToString(leaf)},
+		Value:  []byte("value"),
+		Exists: true,
+	}
+	p.Block.Hash = p.Block.ComputeHash()
+
+	value, err := p.Verify()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	p.Exists = false
+	_, err = p.Verify()
+	require.ErrorIs(t, err, ErrInvalidProof)
+
+	p.Exists = true
+	p.Block.Round++
+	_, err = p.Verify()
+	require.ErrorIs(t, err, ErrInvalidBlockHash)
+}
+
+func TestVerifyQuorum(t *testing.T) {
+	newProof := func(value string) *Proof {
+		leaf := leafNode("", "abc", value)
+		p := &Proof{
+			Block: Header{
+				MinerID:   "miner",
+				Round:     10,
+				StateHash: hex.EncodeToString(nodeHash(leaf)),
+			},
+			Path:   "abc",
+			Nodes:  []string{hex.EncodeToString(leaf)},
+			Value:  []byte(value),
+			Exists: true,
+		}
+		p.Block.Hash = p.Block.ComputeHash()
+		return p
+	}
+
+	value, err := VerifyQuorum(2, newProof("value"), newProof("value"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	_, err = VerifyQuorum(2, newProof("value"))
+	require.ErrorIs(t, err, ErrStateMismatch)
+
+	// the block hash doesn't cover the state root, a sharder can serve any
+	// state for a genuine block
+	_, err = VerifyQuorum(2, newProof("value"), newProof("forged"))
+	require.ErrorIs(t, err, ErrStateMismatch)
+}
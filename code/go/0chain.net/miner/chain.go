@@ -248,6 +248,26 @@ func (mc *Chain) SetLatestFinalizedBlock(ctx context.Context, b *block.Block) {
 	}
 }
 
+// FastSync downloads the state of a recent finalized block from the sharders when
+// the miner is far behind them, and sets the block as the latest finalized one, so
+// that the miner computes the states of the next blocks from it.
+func (mc *Chain) FastSync(ctx context.Context, conf chain.FastSyncConfig) error {
+	b, err := mc.FastSyncState(ctx, conf, mc.GetLatestFinalizedBlock().Round)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return nil
+	}
+
+	b.SetStateStatus(block.StateSuccessful)
+	if err := mc.InitBlockState(b); err != nil {
+		return common.NewErrorf("fast_sync", "init state of the round %d: %v", b.Round, err)
+	}
+	mc.SetLatestFinalizedBlock(ctx, b)
+	return nil
+}
+
 func (mc *Chain) deleteTxns(txns []datastore.Entity) error {
 	transactionMetadataProvider := datastore.GetEntityMetadata("txn")
 	ctx := memorystore.WithEntityConnection(common.GetRootContext(), transactionMetadataProvider)
@@ -329,6 +329,14 @@ func main() {
 	// to subscribe to its events
 	go mc.RestartRoundEventWorker(ctx)
 
+	// sync the state of a recent round from the sharders before the blocks processing,
+	// the synced block becomes the LFB
+	if fsConf := chain.ReadFastSyncConfig(workdir); fsConf.Enabled {
+		if err := mc.FastSync(ctx, fsConf); err != nil {
+			logging.Logger.Fatal("fast state sync failed", zap.Error(err))
+		}
+	}
+
 	var activeMiner = mb.Miners.HasNode(node.Self.Underlying().GetKey())
 	if activeMiner {
 		mb = mc.GetLatestMagicBlock()
@@ -506,7 +514,7 @@ func initWorkers(ctx context.Context) {
 	serverChain := chain.GetServerChain()
 	serverChain.SetupWorkers(ctx)
 	//miner.SetupWorkers(ctx)
-	transaction.SetupWorkers(ctx)
+	transaction.SetupWorkers(ctx, serverChain.GetCurrentRound)
 }
 
 func initProfHandlers(mux *http.ServeMux) {
@@ -148,9 +148,38 @@ func (mc *Chain) createGenerateChallengeTxn(b *block.Block) (*transaction.Transa
 	return brTxn, nil
 }
 
+func (mc *Chain) createRenewAllocationsTxn(b *block.Block) (*transaction.Transaction, error) {
+	raTxn := transaction.Provider().(*transaction.Transaction)
+	raTxn.ClientID = node.Self.ID
+	raTxn.PublicKey = node.Self.PublicKey
+	raTxn.ToClientID = storagesc.ADDRESS
+	raTxn.CreationDate = b.CreationDate
+	raTxn.TransactionType = transaction.TxnTypeSmartContract
+	raTxn.TransactionData = fmt.Sprintf(`{"name":"renew_allocations","input":{"round":%d}}`, b.Round)
+	raTxn.Fee = 0
+	if err := raTxn.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	return raTxn, nil
+}
+
+// autoRenewTriggerPeriod returns the auto_renew.trigger_period of the storage SC
+// configurations in the state of the block, 0 if they can't be read
+func (mc *Chain) autoRenewTriggerPeriod(b *block.Block) int64 {
+	conf := &storagesc.Config{}
+	if err := mc.GetBlockStateNode(b, storagesc.ConfigKey(), conf); err != nil {
+		logging.Logger.Debug("can't get storage SC config", zap.Int64("round", b.Round), zap.Error(err))
+		return 0
+	}
+	return conf.AutoRenew.TriggerPeriod
+}
+
 func (mc *Chain) validateTransaction(b *block.Block,
 	bState util.MerklePatriciaTrieI, txn *transaction.Transaction, waitC chan struct{}) (int64, error) {
-	if !common.WithinTime(int64(b.CreationDate), int64(txn.CreationDate), transaction.TXN_TIME_TOLERANCE) {
+	if err := txn.ValidateSchedule(b.Round, b.CreationDate); err != nil {
+		return 0, err
+	}
+	if !txn.WithinTime(b.CreationDate) {
 		return 0, ErrNotTimeTolerant
 	}
 	state, err := chain.GetStateById(bState, txn.ClientID)
@@ -200,7 +229,8 @@ func (mc *Chain) UpdatePendingBlock(ctx context.Context, b *block.Block, txns []
 
 func (mc *Chain) verifySmartContracts(ctx context.Context, b *block.Block) error {
 	for _, txn := range b.Txns {
-		if txn.TransactionType == transaction.TxnTypeSmartContract {
+		if txn.TransactionType == transaction.TxnTypeSmartContract ||
+			txn.TransactionType == transaction.TxnTypeSmartContractBatch {
 			err := txn.VerifyOutputHash(ctx)
 			if err != nil {
 				logging.Logger.Error("Smart contract output verification failed", zap.Error(err), zap.String("output", txn.TransactionOutput))
@@ -508,11 +538,11 @@ func (mc *Chain) ValidateTransactions(ctx context.Context, b *block.Block) error
 		hasDuplicateBuildInTxns := func(txn *transaction.Transaction) bool {
 			bicLock.Lock()
 			defer bicLock.Unlock()
-			if mc.isBuildInTxn(txn) {
-				if _, ok := buildInTxnsMap[txn.FunctionName]; ok {
+			for _, name := range mc.buildInTxnFunctions(txn) {
+				if _, ok := buildInTxnsMap[name]; ok {
 					return true
 				}
-				buildInTxnsMap[txn.FunctionName] = struct{}{}
+				buildInTxnsMap[name] = struct{}{}
 			}
 			return false
 		}
@@ -542,6 +572,9 @@ func (mc *Chain) ValidateTransactions(ctx context.Context, b *block.Block) error
 					return
 				}
 				err := txn.ValidateWrtTimeForBlock(ctx, b.CreationDate, !aggregate)
+				if err == nil {
+					err = txn.ValidateSchedule(b.Round, b.CreationDate)
+				}
 				if err != nil {
 					cancel = true
 					logging.Logger.Error("validate transactions", zap.Int64("round", b.Round), zap.String("block", b.Hash), zap.String("txn", datastore.ToJSON(txn).String()), zap.Error(err))
@@ -772,6 +805,23 @@ func txnProcessorHandlerFunc(mc *Chain, b *block.Block) txnProcessorHandler {
 					zap.Int32("iterate count", tii.count))
 			}
 			return false, nil
+		case transaction.ErrTxnNotYetValid:
+			// keep the scheduled transaction in the pool until it's eligible
+			if debugTxn {
+				logging.Logger.Debug("generate block (debug transaction) - scheduled transaction is not eligible yet",
+					zap.String("txn", txn.Hash),
+					zap.Int64("round", b.Round),
+					zap.Int64("valid_from_round", txn.ValidFromRound),
+					zap.Any("valid_from", txn.ValidFrom))
+			}
+			return false, nil
+		case transaction.ErrTxnExpired:
+			tii.invalidTxns = append(tii.invalidTxns, txn)
+			if debugTxn {
+				logging.Logger.Info("generate block (debug transaction) error - scheduled transaction expired",
+					zap.String("txn", txn.Hash), zap.Int64("round", b.Round))
+			}
+			return false, nil
 		case ErrNotTimeTolerant:
 			tii.invalidTxns = append(tii.invalidTxns, txn)
 			if debugTxn {
@@ -1350,7 +1400,7 @@ l:
 }
 
 func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block) ([]*transaction.Transaction, int, error) {
-	txns := make([]*transaction.Transaction, 0, 4)
+	txns := make([]*transaction.Transaction, 0, 5)
 
 	if mc.ChainConfig.IsFeeEnabled() {
 		feeTxn, err := mc.createFeeTxn(b)
@@ -1378,6 +1428,14 @@ func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block) ([]*trans
 		txns = append(txns, brTxn)
 	}
 
+	if renewPeriod := mc.autoRenewTriggerPeriod(lfb); renewPeriod > 0 && b.Round%renewPeriod == 0 {
+		raTxn, err := mc.createRenewAllocationsTxn(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		txns = append(txns, raTxn)
+	}
+
 	if mc.SmartContractSettingUpdatePeriod() != 0 &&
 		b.Round%mc.SmartContractSettingUpdatePeriod() == 0 {
 		cscTxn, err := mc.storageScCommitSettingChangesTx(b)
@@ -7,6 +7,7 @@ const (
 	commitSettingsChangesTxnName = "commit_settings_changes"
 	blobberBlockRewardsTxnName   = "blobber_block_rewards"
 	generateChallengeTxnName     = "generate_challenge"
+	renewAllocationsTxnName      = "renew_allocations"
 )
 
 var gBuildInTxnsMap = map[string]struct{}{
@@ -14,14 +15,29 @@ var gBuildInTxnsMap = map[string]struct{}{
 	commitSettingsChangesTxnName: {},
 	blobberBlockRewardsTxnName:   {},
 	generateChallengeTxnName:     {},
+	renewAllocationsTxnName:      {},
 }
 
-// isBuildInTxn checks if the txn is build-in txn.
-func (mc *Chain) isBuildInTxn(txn *transaction.Transaction) bool {
-	if txn.TransactionType != transaction.TxnTypeSmartContract {
-		return false
+// buildInTxnFunctions returns the build-in functions called by the txn, by a smart
+// contract txn or by the calls of a batch txn.
+func (mc *Chain) buildInTxnFunctions(txn *transaction.Transaction) []string {
+	switch txn.TransactionType {
+	case transaction.TxnTypeSmartContract:
+		if _, ok := gBuildInTxnsMap[txn.FunctionName]; ok {
+			return []string{txn.FunctionName}
+		}
+	case transaction.TxnTypeSmartContractBatch:
+		bd, err := txn.GetBatchData()
+		if err != nil {
+			return nil
+		}
+		var names []string
+		for _, call := range bd.Calls {
+			if _, ok := gBuildInTxnsMap[call.FunctionName]; ok {
+				names = append(names, call.FunctionName)
+			}
+		}
+		return names
 	}
-
-	_, ok := gBuildInTxnsMap[txn.FunctionName]
-	return ok
+	return nil
 }
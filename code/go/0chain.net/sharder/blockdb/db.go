@@ -54,6 +54,11 @@ func (bdb *BlockDB) SetIndex(index Index) {
 	bdb.index = index
 }
 
+// GetIndex - get the index object, it's loaded by Open
+func (bdb *BlockDB) GetIndex() Index {
+	return bdb.index
+}
+
 //Create - create the database
 func (bdb *BlockDB) Create() error {
 	dir := filepath.Dir(bdb.file)
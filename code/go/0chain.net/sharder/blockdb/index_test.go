@@ -162,3 +162,54 @@ func Test_mapIndex_GetKeys(t *testing.T) {
 		})
 	}
 }
+
+func Test_fixedKeyArrayIndex_GetOffset(t *testing.T) {
+	t.Parallel()
+
+	mi := &mapIndex{
+		index: map[Key]int64{
+			"11": 1,
+			"22": 2,
+			"33": 3,
+			"44": 4,
+		},
+	}
+	buf := bytes.Buffer{}
+	if err := mi.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	fkai := newFixedKeyArrayIndex(2)
+	if err := fkai.Decode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     Key
+		want    int64
+		wantErr bool
+	}{
+		{name: "Test_fixedKeyArrayIndex_GetOffset_First_OK", key: "11", want: 1},
+		{name: "Test_fixedKeyArrayIndex_GetOffset_Last_OK", key: "44", want: 4},
+		{name: "Test_fixedKeyArrayIndex_GetOffset_Middle_OK", key: "33", want: 3},
+		{name: "Test_fixedKeyArrayIndex_GetOffset_Lower_Key_ERR", key: "00", want: -1, wantErr: true},
+		{name: "Test_fixedKeyArrayIndex_GetOffset_Between_Keys_ERR", key: "23", want: -1, wantErr: true},
+		{name: "Test_fixedKeyArrayIndex_GetOffset_Greater_Key_ERR", key: "55", want: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := fkai.GetOffset(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetOffset() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetOffset() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,501 @@
+package blockstore
+
+// Archive is an optional storage mode that compacts the finalized blocks older than
+// the configured depth into packed BlockDB files, one per range of rounds, instead of
+// keeping a compressed file (and an inode) for each block.
+//
+// Every block written to the disk is logged in the pending log of its rounds range.
+// Once the latest stored round is past the end of a range by the depth, the logged
+// blocks are packed into the range's BlockDB file and their files are removed.
+// The archive files are listed by rounds range at startup, a block is read from the
+// file of its round through the index of the file, which is loaded once the file is
+// opened. The blocks read by hash only are looked for from the latest archive file.
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0chain/common/core/logging"
+	simpleLru "github.com/hashicorp/golang-lru/v2"
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/sharder/blockdb"
+)
+
+const (
+	archiveDirName        = "archive"
+	archivePendingDirName = "pending"
+	archivePendingExt     = "log"
+	archiveTmpExt         = "tmp"
+	// archiveKeyLength is the length of the keys of the archive files, only the
+	// blocks with regular hashes are archived
+	archiveKeyLength = 64
+	// DefaultArchiveOpenFiles is the number of archive files kept open for reading
+	DefaultArchiveOpenFiles = 16
+)
+
+type archiveRange struct {
+	start int64
+	end   int64
+}
+
+func (r archiveRange) contains(round int64) bool {
+	return round >= r.start && round <= r.end
+}
+
+func (r archiveRange) name() string {
+	return fmt.Sprintf("%d-%d", r.start, r.end)
+}
+
+func parseArchiveRange(name string) (r archiveRange, err error) {
+	if _, err = fmt.Sscanf(name, "%d-%d", &r.start, &r.end); err != nil {
+		return
+	}
+	if r.start > r.end {
+		err = fmt.Errorf("invalid archive range: %s", name)
+	}
+	return
+}
+
+// archiveHeader is the header of an archive file
+type archiveHeader struct {
+	StartRound int64 `json:"start_round" msgpack:"s"`
+	EndRound   int64 `json:"end_round" msgpack:"e"`
+}
+
+func (h *archiveHeader) Encode(writer io.Writer) error {
+	_, err := common.ToMsgpack(h).WriteTo(writer)
+	return err
+}
+
+func (h *archiveHeader) Decode(reader io.Reader) error {
+	return common.FromMsgpack(reader, h)
+}
+
+// blockRecord is a block stored in an archive file. The key differs from the
+// block hash for the magic blocks, which are stored by the magic block hash too.
+type blockRecord struct {
+	key blockdb.Key
+	b   *block.Block
+}
+
+func (r *blockRecord) GetKey() blockdb.Key {
+	return r.key
+}
+
+func (r *blockRecord) Encode(writer io.Writer) error {
+	return datastore.WriteMsgpack(writer, r.b)
+}
+
+func (r *blockRecord) Decode(reader io.Reader) error {
+	return datastore.ReadMsgpack(reader, r.b)
+}
+
+// archiveDB serializes the reads of an opened archive file
+type archiveDB struct {
+	mutex sync.Mutex
+	db    *blockdb.BlockDB
+}
+
+func (adb *archiveDB) read(record blockdb.Record) error {
+	adb.mutex.Lock()
+	defer adb.mutex.Unlock()
+	return adb.db.Read(record.GetKey(), record)
+}
+
+func (adb *archiveDB) has(key blockdb.Key) bool {
+	adb.mutex.Lock()
+	defer adb.mutex.Unlock()
+	_, err := adb.db.GetIndex().GetOffset(key)
+	return err == nil
+}
+
+func (adb *archiveDB) close() {
+	adb.mutex.Lock()
+	defer adb.mutex.Unlock()
+	if err := adb.db.Close(); err != nil {
+		logging.Logger.Error("archive - close file", zap.Error(err))
+	}
+}
+
+type archive struct {
+	path      string
+	depth     int64
+	rangeSize int64
+
+	mutex sync.RWMutex
+	// ranges are the archived rounds ranges sorted by the start round
+	ranges []archiveRange
+	opened *simpleLru.Cache[int64, *archiveDB]
+
+	pendingMutex sync.Mutex
+	latestRound  int64
+	roundCh      chan struct{}
+}
+
+func newArchive(path string, depth, rangeSize int64, openFiles int) (*archive, error) {
+	if depth < 0 {
+		return nil, fmt.Errorf("invalid archive depth: %d", depth)
+	}
+	if rangeSize <= 0 {
+		return nil, fmt.Errorf("invalid archive range size: %d", rangeSize)
+	}
+	if openFiles <= 0 {
+		openFiles = DefaultArchiveOpenFiles
+	}
+
+	if err := os.MkdirAll(filepath.Join(path, archivePendingDirName), 0700); err != nil {
+		return nil, err
+	}
+
+	opened, err := simpleLru.NewWithEvict[int64, *archiveDB](openFiles,
+		func(_ int64, adb *archiveDB) { adb.close() })
+	if err != nil {
+		return nil, err
+	}
+
+	a := &archive{
+		path:      path,
+		depth:     depth,
+		rangeSize: rangeSize,
+		opened:    opened,
+		roundCh:   make(chan struct{}, 1),
+	}
+	if err := a.loadRanges(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// loadRanges lists the archive files, the files are complete once their index is saved
+func (a *archive) loadRanges() error {
+	files, err := filepath.Glob(filepath.Join(a.path, "*."+blockdb.FileExtHeader))
+	if err != nil {
+		return err
+	}
+
+	ranges := make([]archiveRange, 0, len(files))
+	for _, f := range files {
+		r, err := parseArchiveRange(strings.TrimSuffix(filepath.Base(f), "."+blockdb.FileExtHeader))
+		if err != nil {
+			logging.Logger.Warn("archive - skip unknown file", zap.String("file", f), zap.Error(err))
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+	a.ranges = ranges
+
+	logging.Logger.Info("archive - loaded archive files", zap.Int("files", len(ranges)))
+	return nil
+}
+
+func (a *archive) rangeOf(round int64) archiveRange {
+	start := round - round%a.rangeSize
+	return archiveRange{start: start, end: start + a.rangeSize - 1}
+}
+
+func (a *archive) findRange(round int64) (archiveRange, bool) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	i := sort.Search(len(a.ranges), func(i int) bool { return a.ranges[i].end >= round })
+	if i < len(a.ranges) && a.ranges[i].contains(round) {
+		return a.ranges[i], true
+	}
+	return archiveRange{}, false
+}
+
+func (a *archive) addRange(r archiveRange) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	i := sort.Search(len(a.ranges), func(i int) bool { return a.ranges[i].start >= r.start })
+	a.ranges = append(a.ranges, archiveRange{})
+	copy(a.ranges[i+1:], a.ranges[i:])
+	a.ranges[i] = r
+}
+
+func (a *archive) pendingLogPath(r archiveRange) string {
+	return filepath.Join(a.path, archivePendingDirName, r.name()+"."+archivePendingExt)
+}
+
+// logBlock adds the stored block file to the pending log of its rounds range. The blocks
+// of the already archived ranges, e.g. synced late, are kept as separate files.
+func (a *archive) logBlock(round int64, hash string) error {
+	if len(hash) != archiveKeyLength || round < 0 {
+		return nil
+	}
+
+	a.pendingMutex.Lock()
+	defer a.pendingMutex.Unlock()
+	if _, ok := a.findRange(round); ok {
+		return nil
+	}
+
+	f, err := os.OpenFile(a.pendingLogPath(a.rangeOf(round)), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%d %s\n", round, hash)
+	return err
+}
+
+// notify signals the archive worker about the stored round, never blocks
+func (a *archive) notify(round int64) {
+	for {
+		latest := atomic.LoadInt64(&a.latestRound)
+		if round <= latest || atomic.CompareAndSwapInt64(&a.latestRound, latest, round) {
+			break
+		}
+	}
+
+	select {
+	case a.roundCh <- struct{}{}:
+	default:
+	}
+}
+
+// run compacts the rounds ranges that are older than the depth from the latest stored round
+func (a *archive) run(ctx context.Context, bStore *BlockStore) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.roundCh:
+			a.compactBefore(atomic.LoadInt64(&a.latestRound)-a.depth, bStore)
+		}
+	}
+}
+
+type pendingBlock struct {
+	round int64
+	hash  string
+}
+
+func (a *archive) compactBefore(round int64, bStore *BlockStore) {
+	logs, err := filepath.Glob(filepath.Join(a.path, archivePendingDirName, "*."+archivePendingExt))
+	if err != nil {
+		logging.Logger.Error("archive - list pending logs", zap.Error(err))
+		return
+	}
+
+	ranges := make([]archiveRange, 0, len(logs))
+	for _, l := range logs {
+		r, err := parseArchiveRange(strings.TrimSuffix(filepath.Base(l), "."+archivePendingExt))
+		if err != nil {
+			logging.Logger.Warn("archive - skip unknown pending log", zap.String("file", l), zap.Error(err))
+			continue
+		}
+		if r.end < round {
+			ranges = append(ranges, r)
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+
+	for _, r := range ranges {
+		if err := a.compact(r, bStore); err != nil {
+			logging.Logger.Error("archive - compact rounds range",
+				zap.String("range", r.name()), zap.Error(err))
+			return
+		}
+	}
+}
+
+func (a *archive) readPendingLog(r archiveRange) ([]pendingBlock, error) {
+	a.pendingMutex.Lock()
+	defer a.pendingMutex.Unlock()
+	f, err := os.Open(a.pendingLogPath(r))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		blocks []pendingBlock
+		seen   = make(map[string]struct{})
+		sc     = bufio.NewScanner(f)
+	)
+	for sc.Scan() {
+		var pb pendingBlock
+		if _, err := fmt.Sscanf(sc.Text(), "%d %s", &pb.round, &pb.hash); err != nil {
+			// could be a partially written line
+			logging.Logger.Warn("archive - skip invalid pending log line",
+				zap.String("range", r.name()), zap.String("line", sc.Text()))
+			continue
+		}
+		if _, ok := seen[pb.hash]; ok {
+			continue
+		}
+		seen[pb.hash] = struct{}{}
+		blocks = append(blocks, pb)
+	}
+	return blocks, sc.Err()
+}
+
+// compact packs the logged blocks of the range into the range's archive file and removes
+// the block files. The archive file is written under a temporary name and renamed once
+// saved, so that a partially written file is never read.
+func (a *archive) compact(r archiveRange, bStore *BlockStore) error {
+	blocks, err := a.readPendingLog(r)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := a.findRange(r.start); !ok {
+		if err := a.writeArchive(r, blocks, bStore); err != nil {
+			return err
+		}
+		a.addRange(r)
+	} else {
+		// the archive file is already saved if the node stopped before removing the
+		// block files, remove only the files of the blocks that are archived
+		adb, err := a.open(r)
+		if err != nil {
+			return err
+		}
+		archived := blocks[:0]
+		for _, pb := range blocks {
+			if adb.has(blockdb.Key(pb.hash)) {
+				archived = append(archived, pb)
+			}
+		}
+		blocks = archived
+	}
+
+	for _, pb := range blocks {
+		bp, err := getBlockFilePath(pb.hash)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(filepath.Join(bStore.basePath, bp)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	logging.Logger.Info("archive - compacted rounds range",
+		zap.String("range", r.name()), zap.Int("blocks", len(blocks)))
+
+	a.pendingMutex.Lock()
+	defer a.pendingMutex.Unlock()
+	return os.Remove(a.pendingLogPath(r))
+}
+
+func (a *archive) writeArchive(r archiveRange, blocks []pendingBlock, bStore *BlockStore) error {
+	var (
+		file   = filepath.Join(a.path, r.name())
+		tmp    = file + "." + archiveTmpExt
+		header = &archiveHeader{StartRound: r.start, EndRound: r.end}
+	)
+
+	// remove the leftovers of an interrupted compaction, the files are not truncated on create
+	for _, ext := range []string{blockdb.FileExtHeader, blockdb.FileExtData} {
+		if err := os.Remove(tmp + "." + ext); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	db, err := blockdb.NewBlockDB(tmp, archiveKeyLength, true)
+	if err != nil {
+		return err
+	}
+	if err := db.Create(); err != nil {
+		return err
+	}
+	db.SetDBHeader(header)
+
+	for _, pb := range blocks {
+		b, err := bStore.readFromDisk(pb.hash)
+		if err != nil {
+			if os.IsNotExist(err) {
+				logging.Logger.Warn("archive - missing block file",
+					zap.Int64("round", pb.round), zap.String("hash", pb.hash))
+				continue
+			}
+			db.Close()
+			return err
+		}
+		if err := db.WriteData(&blockRecord{key: blockdb.Key(pb.hash), b: b}); err != nil {
+			db.Close()
+			return err
+		}
+	}
+
+	if err := db.Save(); err != nil {
+		return err
+	}
+
+	// the data file goes first, the index file marks the archive file as complete
+	if err := os.Rename(tmp+"."+blockdb.FileExtData, file+"."+blockdb.FileExtData); err != nil {
+		return err
+	}
+	return os.Rename(tmp+"."+blockdb.FileExtHeader, file+"."+blockdb.FileExtHeader)
+}
+
+func (a *archive) open(r archiveRange) (*archiveDB, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if adb, ok := a.opened.Get(r.start); ok {
+		return adb, nil
+	}
+
+	db, err := blockdb.NewBlockDB(filepath.Join(a.path, r.name()), archiveKeyLength, true)
+	if err != nil {
+		return nil, err
+	}
+	db.SetDBHeader(&archiveHeader{})
+	if err := db.Open(); err != nil {
+		return nil, err
+	}
+
+	adb := &archiveDB{db: db}
+	a.opened.Add(r.start, adb)
+	return adb, nil
+}
+
+// read reads the block from the archive file of its round, or looks for it from the
+// latest archive file if the round is not known, i.e. negative
+func (a *archive) read(hash string, round int64, b *block.Block) error {
+	if len(hash) != archiveKeyLength {
+		return blockdb.ErrKeyNotFound
+	}
+
+	if round >= 0 {
+		r, ok := a.findRange(round)
+		if !ok {
+			return blockdb.ErrKeyNotFound
+		}
+		return a.readFrom(r, hash, b)
+	}
+
+	a.mutex.RLock()
+	ranges := make([]archiveRange, len(a.ranges))
+	copy(ranges, a.ranges)
+	a.mutex.RUnlock()
+
+	for i := len(ranges) - 1; i >= 0; i-- {
+		if err := a.readFrom(ranges[i], hash, b); !errors.Is(err, blockdb.ErrKeyNotFound) {
+			return err
+		}
+	}
+	return blockdb.ErrKeyNotFound
+}
+
+func (a *archive) readFrom(r archiveRange, hash string, b *block.Block) error {
+	adb, err := a.open(r)
+	if err != nil {
+		return err
+	}
+	return adb.read(&blockRecord{key: blockdb.Key(hash), b: b})
+}
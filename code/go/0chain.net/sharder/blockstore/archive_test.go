@@ -0,0 +1,86 @@
+package blockstore
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/sharder/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockStoreArchive(t *testing.T) {
+	basePath := t.TempDir()
+	a, err := newArchive(filepath.Join(basePath, archiveDirName), 2, 5, 0)
+	require.NoError(t, err)
+
+	bStore := &BlockStore{
+		basePath:              basePath,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 noOpCache{},
+		archive:               a,
+	}
+
+	blocks := make([]*block.Block, 10)
+	for i := range blocks {
+		b := new(block.Block)
+		b.Round = int64(i)
+		b.Hash = encryption.Hash("block " + strconv.Itoa(i))
+		require.NoError(t, bStore.Write(b))
+		blocks[i] = b
+	}
+
+	// rounds 0-4 are older than the depth from the latest round 9
+	a.compactBefore(9-a.depth, bStore)
+
+	_, ok := a.findRange(3)
+	require.True(t, ok)
+	_, ok = a.findRange(5)
+	require.False(t, ok)
+
+	for _, b := range blocks {
+		bp, err := getBlockFilePath(b.Hash)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(basePath, bp))
+		if b.Round <= 4 {
+			require.True(t, os.IsNotExist(err), "block file of round %d is not removed", b.Round)
+		} else {
+			require.NoError(t, err)
+		}
+
+		rb, err := bStore.Read(b.Hash)
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, rb.Hash)
+		require.Equal(t, b.Round, rb.Round)
+
+		rb, err = bStore.ReadWithBlockSummary(&block.BlockSummary{Hash: b.Hash, Round: b.Round})
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, rb.Hash)
+	}
+
+	_, err = bStore.Read(encryption.Hash("unknown block"))
+	require.Error(t, err)
+
+	// the archived ranges are loaded on restart
+	a2, err := newArchive(filepath.Join(basePath, archiveDirName), 2, 5, 0)
+	require.NoError(t, err)
+	require.Equal(t, []archiveRange{{start: 0, end: 4}}, a2.ranges)
+
+	for _, b := range blocks[:5] {
+		// by the round and by the hash only
+		for _, round := range []int64{b.Round, -1} {
+			rb := new(block.Block)
+			require.NoError(t, a2.read(b.Hash, round, rb))
+			require.Equal(t, b.Hash, rb.Hash)
+			require.Equal(t, b.Round, rb.Round)
+		}
+	}
+
+	require.ErrorIs(t, a2.read(blocks[0].Hash, blocks[5].Round, new(block.Block)), blockdb.ErrKeyNotFound)
+	require.ErrorIs(t, a2.read(blocks[5].Hash, -1, new(block.Block)), blockdb.ErrKeyNotFound,
+		"the blocks of the pending ranges are not archived")
+}
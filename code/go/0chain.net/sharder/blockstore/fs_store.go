@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"compress/zlib"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -12,8 +13,10 @@ import (
 	"time"
 
 	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/viper"
+	"0chain.net/sharder/blockdb"
 	"github.com/0chain/common/core/logging"
 	"go.uber.org/zap"
 	"golang.org/x/sys/unix"
@@ -41,7 +44,9 @@ var (
 	store BlockStoreI
 )
 
-func hasEnoughInodesAndSize(p string) error {
+// hasEnoughInodesAndSize checks the disk for the given number of block files,
+// and for the space of the expected blocks.
+func hasEnoughInodesAndSize(p string, blockFiles uint64) error {
 	var diskStat unix.Statfs_t
 	err := unix.Statfs(p, &diskStat)
 	if err != nil {
@@ -50,7 +55,7 @@ func hasEnoughInodesAndSize(p string) error {
 
 	availableSize := diskStat.Bavail * uint64(diskStat.Bsize)
 	freeInodes := diskStat.Ffree
-	minimumInodesRequired := uint64(expectedTotalBlocksIn3Years)
+	minimumInodesRequired := blockFiles
 	for i := 0; i < subDirs; i++ {
 		minimumInodesRequired += uint64(math.Pow(16, float64(i+1)))
 	}
@@ -60,7 +65,7 @@ func hasEnoughInodesAndSize(p string) error {
 			minimumInodesRequired, freeInodes)
 	}
 
-	requiredAvgSize := minimumInodesRequired * averageBlockSize
+	requiredAvgSize := uint64(expectedTotalBlocksIn3Years) * averageBlockSize
 
 	if availableSize < uint64(requiredAvgSize) {
 		return fmt.Errorf("insufficient disk space. Required %d, available %d",
@@ -89,6 +94,8 @@ type BlockStore struct {
 	basePath              string
 	blockMetadataProvider datastore.EntityMetadata
 	cache                 cacher
+	// archive packs the old blocks into archive files, nil if the archive mode is disabled
+	archive *archive
 }
 
 func (bStore *BlockStore) writeToDisk(hash string, b *block.Block) error {
@@ -96,8 +103,12 @@ func (bStore *BlockStore) writeToDisk(hash string, b *block.Block) error {
 	if err != nil {
 		return err
 	}
-	bPath := filepath.Join(bStore.basePath, bp)
-	err = os.MkdirAll(filepath.Dir(bPath), 0700)
+	return writeBlockFile(filepath.Join(bStore.basePath, bp), b)
+}
+
+// writeBlockFile writes the block compressed to the file
+func writeBlockFile(bPath string, b *block.Block) error {
+	err := os.MkdirAll(filepath.Dir(bPath), 0700)
 	if err != nil {
 		return err
 	}
@@ -128,6 +139,15 @@ func (bStore *BlockStore) write(hash string, b *block.Block) error {
 		return err
 	}
 
+	if bStore.archive != nil {
+		if err := bStore.archive.logBlock(b.Round, hash); err != nil {
+			logging.Logger.Error("archive - log block",
+				zap.Int64("round", b.Round),
+				zap.String("hash", hash),
+				zap.Error(err))
+		}
+	}
+
 	go func() {
 		ctx, ctxCncl := context.WithTimeout(context.TODO(), CacheWriteTimeOut)
 		defer ctxCncl()
@@ -144,6 +164,10 @@ func (bStore *BlockStore) Write(b *block.Block) error {
 		return err
 	}
 
+	if bStore.archive != nil {
+		bStore.archive.notify(b.Round)
+	}
+
 	if b.MagicBlock != nil && b.Round == b.MagicBlock.StartingRound {
 		logging.Logger.Debug("save magic block",
 			zap.Int64("round", b.Round),
@@ -154,7 +178,13 @@ func (bStore *BlockStore) Write(b *block.Block) error {
 	return nil
 }
 
+// Read reads the block from the cache, the disk or the archive
 func (bStore *BlockStore) Read(hash string) (*block.Block, error) {
+	return bStore.read(hash, -1)
+}
+
+// read reads the block of the round, if it's known, i.e. not negative
+func (bStore *BlockStore) read(hash string, round int64) (*block.Block, error) {
 	b := bStore.blockMetadataProvider.Instance().(*block.Block)
 	data, err := bStore.cache.Read(hash)
 	if data != nil && err == nil {
@@ -166,6 +196,9 @@ func (bStore *BlockStore) Read(hash string) (*block.Block, error) {
 	}
 
 	b, err = bStore.readFromDisk(hash)
+	if os.IsNotExist(err) && bStore.archive != nil {
+		b, err = bStore.readFromArchive(hash, round)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +220,11 @@ func (bStore *BlockStore) readFromDisk(hash string) (*block.Block, error) {
 	if err != nil {
 		return nil, err
 	}
-	bPath := filepath.Join(bStore.basePath, bp)
+	return readBlockFile(filepath.Join(bStore.basePath, bp), bStore.blockMetadataProvider)
+}
+
+// readBlockFile reads the compressed block from the file
+func readBlockFile(bPath string, blockMetadataProvider datastore.EntityMetadata) (*block.Block, error) {
 	f, err := os.Open(bPath)
 	if err != nil {
 		return nil, err
@@ -199,7 +236,7 @@ func (bStore *BlockStore) readFromDisk(hash string) (*block.Block, error) {
 		return nil, err
 	}
 	defer r.Close()
-	b := bStore.blockMetadataProvider.Instance().(*block.Block)
+	b := blockMetadataProvider.Instance().(*block.Block)
 	err = datastore.ReadMsgpack(r, b)
 	if err != nil {
 		return nil, err
@@ -207,9 +244,20 @@ func (bStore *BlockStore) readFromDisk(hash string) (*block.Block, error) {
 	return b, nil
 }
 
+func (bStore *BlockStore) readFromArchive(hash string, round int64) (*block.Block, error) {
+	b := bStore.blockMetadataProvider.Instance().(*block.Block)
+	if err := bStore.archive.read(hash, round, b); err != nil {
+		if errors.Is(err, blockdb.ErrKeyNotFound) {
+			return nil, fmt.Errorf("block not found: %s", hash)
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
 // ReadWithBlockSummary - read the block given the block summary
 func (bStore *BlockStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
-	return bStore.Read(bs.Hash)
+	return bStore.read(bs.Hash, bs.Round)
 }
 
 // Init checks for minimum disk size, inodes requirement and assigns
@@ -219,7 +267,45 @@ func Init(workDir string, sViper *viper.Viper) {
 	logging.Logger.Info("Initializing storage")
 
 	basePath := filepath.Join(workDir, "data", "blocks")
-	err := hasEnoughInodesAndSize(basePath)
+	bStore := &BlockStore{
+		cache:                 noOpCache{},
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		basePath:              basePath,
+	}
+
+	blockFiles := uint64(expectedTotalBlocksIn3Years)
+	if sViper != nil {
+		cViper := sViper.Sub("cache")
+		if cViper != nil {
+			bStore.cache = initCache(cViper)
+		}
+
+		// the blocks are kept in the object storage instead of the disk
+		oViper := sViper.Sub("object_store")
+		if oViper != nil {
+			// the archive packs the block files of the disk, there are none to pack
+			if sViper.Sub("archive") != nil {
+				panic("block store: archive and object_store are exclusive, configure only one of them")
+			}
+			if oViper.GetString("staging_path") == "" {
+				oViper.Set("staging_path", filepath.Join(basePath, objectStoreStagingDirName))
+			}
+			oStore := initObjectStore(oViper, bStore.cache)
+			go oStore.Run(common.GetRootContext())
+			SetupStore(oStore)
+			return
+		}
+
+		aViper := sViper.Sub("archive")
+		if aViper != nil {
+			bStore.archive = initArchive(basePath, aViper)
+			// only the blocks of the ranges that are not compacted yet are kept as files
+			blockFiles = uint64(bStore.archive.depth+2*bStore.archive.rangeSize) +
+				2*uint64(expectedTotalBlocksIn3Years/bStore.archive.rangeSize)
+		}
+	}
+
+	err := hasEnoughInodesAndSize(basePath, blockFiles)
 	if err != nil {
 		// TODO comment out for build integration tests.
 		// panic(err)
@@ -231,17 +317,21 @@ func Init(workDir string, sViper *viper.Viper) {
 		}
 	}
 
-	bStore := &BlockStore{
-		cache:                 noOpCache{},
-		blockMetadataProvider: datastore.GetEntityMetadata("block"),
-		basePath:              basePath,
+	if bStore.archive != nil {
+		go bStore.archive.run(common.GetRootContext(), bStore)
 	}
+	SetupStore(bStore)
+}
 
-	if sViper != nil {
-		cViper := sViper.Sub("cache")
-		if cViper != nil {
-			bStore.cache = initCache(cViper)
-		}
+func initArchive(basePath string, aViper *viper.Viper) *archive {
+	aPath := aViper.GetString("path")
+	if aPath == "" {
+		aPath = filepath.Join(basePath, archiveDirName)
 	}
-	SetupStore(bStore)
+
+	a, err := newArchive(aPath, aViper.GetInt64("depth"), aViper.GetInt64("range"), aViper.GetInt("open_files"))
+	if err != nil {
+		panic(err)
+	}
+	return a
 }
@@ -0,0 +1,419 @@
+package blockstore
+
+// ObjectStore keeps the blocks in an S3-compatible object storage, so that sharders
+// don't need the local disk space for all the blocks. It's meant as the cold tier
+// behind the SSD cache.
+//
+// Written blocks are staged on the local disk and uploaded in batches in background,
+// the staged file is removed once the block is uploaded. The staged blocks are
+// uploaded again on restart. The downloaded blocks are checked against the
+// requested hash and the block hash is recomputed.
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"0chain.net/core/viper"
+)
+
+const (
+	DefaultObjectStoreBatchSize     = 50
+	DefaultObjectStoreBatchInterval = 5 * time.Second
+	DefaultObjectStoreMaxRetries    = 5
+	DefaultObjectStoreRetryBackoff  = time.Second
+	DefaultObjectStoreTimeout       = 30 * time.Second
+	objectStoreStagingDirName       = "staging"
+)
+
+// ObjectStoreConfig is the object storage configuration
+type ObjectStoreConfig struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// StagingPath is the local directory of the blocks waiting to be uploaded
+	StagingPath string
+	// BatchSize is the max number of blocks uploaded at once
+	BatchSize int
+	// BatchInterval is the max time a block waits for its batch to be uploaded
+	BatchInterval time.Duration
+	// MaxRetries is the max number of retries of a failed request
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled for each next one
+	RetryBackoff time.Duration
+	// Timeout is the object store request timeout
+	Timeout time.Duration
+}
+
+func (cfg *ObjectStoreConfig) setDefaults() {
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultObjectStoreBatchSize
+	}
+	if cfg.BatchInterval <= 0 {
+		cfg.BatchInterval = DefaultObjectStoreBatchInterval
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = DefaultObjectStoreMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultObjectStoreRetryBackoff
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultObjectStoreTimeout
+	}
+}
+
+type ObjectStore struct {
+	cfg                   ObjectStoreConfig
+	client                *s3Client
+	blockMetadataProvider datastore.EntityMetadata
+	cache                 cacher
+
+	// pending are the staged blocks waiting for their upload
+	mutex   sync.Mutex
+	pending []string
+	staged  map[string]struct{}
+	fullCh  chan struct{}
+}
+
+// NewObjectStore creates the object store and stages again the blocks which upload
+// was not completed. The uploads start with the Run.
+func NewObjectStore(cfg ObjectStoreConfig, c cacher) (*ObjectStore, error) {
+	cfg.setDefaults()
+	if cfg.StagingPath == "" {
+		return nil, errors.New("missing object store staging path")
+	}
+	if err := os.MkdirAll(cfg.StagingPath, 0700); err != nil {
+		return nil, err
+	}
+
+	client, err := newS3Client(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKeyID, cfg.SecretAccessKey, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		c = noOpCache{}
+	}
+
+	oStore := &ObjectStore{
+		cfg:                   cfg,
+		client:                client,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 c,
+		staged:                make(map[string]struct{}),
+		fullCh:                make(chan struct{}, 1),
+	}
+
+	staged, err := filepath.Glob(filepath.Join(cfg.StagingPath, "*."+extension))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range staged {
+		hash := strings.TrimSuffix(filepath.Base(f), "."+extension)
+		oStore.staged[hash] = struct{}{}
+		oStore.pending = append(oStore.pending, hash)
+	}
+	if len(staged) > 0 {
+		logging.Logger.Info("object store - resume staged blocks upload", zap.Int("blocks", len(staged)))
+	}
+	return oStore, nil
+}
+
+func (oStore *ObjectStore) objectKey(hash string) string {
+	return oStore.cfg.Prefix + hash + "." + extension
+}
+
+func (oStore *ObjectStore) stagingFilePath(hash string) string {
+	return filepath.Join(oStore.cfg.StagingPath, hash+"."+extension)
+}
+
+func (oStore *ObjectStore) write(hash string, b *block.Block) error {
+	if err := writeBlockFile(oStore.stagingFilePath(hash), b); err != nil {
+		return err
+	}
+
+	oStore.mutex.Lock()
+	if _, ok := oStore.staged[hash]; !ok {
+		oStore.staged[hash] = struct{}{}
+		oStore.pending = append(oStore.pending, hash)
+	}
+	full := len(oStore.pending) >= oStore.cfg.BatchSize
+	oStore.mutex.Unlock()
+
+	if full {
+		select {
+		case oStore.fullCh <- struct{}{}:
+		default:
+		}
+	}
+
+	go func() {
+		ctx, ctxCncl := context.WithTimeout(context.TODO(), CacheWriteTimeOut)
+		defer ctxCncl()
+		if err := oStore.cache.Write(ctx, hash, b); err != nil {
+			logging.Logger.Error(err.Error())
+		}
+	}()
+	return nil
+}
+
+// Write stages the block for the upload
+func (oStore *ObjectStore) Write(b *block.Block) error {
+	if err := oStore.write(b.Hash, b); err != nil {
+		return err
+	}
+
+	if b.MagicBlock != nil && b.Round == b.MagicBlock.StartingRound {
+		logging.Logger.Debug("save magic block",
+			zap.Int64("round", b.Round),
+			zap.String("mb hash", b.MagicBlock.Hash),
+		)
+		return oStore.write(b.MagicBlock.Hash, b)
+	}
+	return nil
+}
+
+// Read reads the block from the cache, the staged blocks or the object storage
+func (oStore *ObjectStore) Read(hash string) (*block.Block, error) {
+	b := oStore.blockMetadataProvider.Instance().(*block.Block)
+	data, err := oStore.cache.Read(hash)
+	if data != nil && err == nil {
+		if err = datastore.ReadMsgpack(bytes.NewReader(data), b); err == nil {
+			return b, nil
+		}
+	}
+
+	b, err = readBlockFile(oStore.stagingFilePath(hash), oStore.blockMetadataProvider)
+	if os.IsNotExist(err) {
+		b, err = oStore.download(hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		ctx, ctxCncl := context.WithTimeout(context.TODO(), CacheWriteTimeOut)
+		defer ctxCncl()
+		if err := oStore.cache.Write(ctx, hash, b); err != nil {
+			logging.Logger.Error(err.Error())
+		}
+	}()
+	return b, nil
+}
+
+// ReadWithBlockSummary - read the block given the block summary
+func (oStore *ObjectStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
+	return oStore.Read(bs.Hash)
+}
+
+func (oStore *ObjectStore) download(hash string) (*block.Block, error) {
+	var (
+		data   []byte
+		header http.Header
+	)
+	err := oStore.retry(context.TODO(), func(ctx context.Context) error {
+		var err error
+		data, header, err = oStore.client.getObject(ctx, oStore.objectKey(hash))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errObjectNotFound) {
+			return nil, fmt.Errorf("block not found: %s", hash)
+		}
+		return nil, err
+	}
+	if h := header.Get(s3MetaBlockHash); h != "" && h != hash {
+		return nil, fmt.Errorf("block integrity check failed: key %s, object block hash %s", hash, h)
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	b := oStore.blockMetadataProvider.Instance().(*block.Block)
+	if err := datastore.ReadMsgpack(r, b); err != nil {
+		return nil, err
+	}
+
+	if err := verifyBlock(hash, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// verifyBlock checks that the block is stored under its hash, or under its
+// magic block hash, and that the block hash matches its content
+func verifyBlock(key string, b *block.Block) error {
+	if b.Hash != key && (b.MagicBlock == nil || b.MagicBlock.Hash != key) {
+		return fmt.Errorf("block integrity check failed: key %s, block hash %s", key, b.Hash)
+	}
+	if h := b.ComputeHash(); h != b.Hash {
+		return fmt.Errorf("block integrity check failed: hash %s, computed hash %s", b.Hash, h)
+	}
+	return nil
+}
+
+// retry runs the request until it succeeds, the object is not found or the retries
+// are exhausted
+func (oStore *ObjectStore) retry(ctx context.Context, f func(ctx context.Context) error) error {
+	backoff := oStore.cfg.RetryBackoff
+	for i := 0; ; i++ {
+		err := f(ctx)
+		if err == nil || errors.Is(err, errObjectNotFound) || i >= oStore.cfg.MaxRetries {
+			return err
+		}
+
+		logging.Logger.Warn("object store - request failed, retrying",
+			zap.Int("attempt", i+1), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (oStore *ObjectStore) upload(ctx context.Context, hash string) error {
+	data, err := os.ReadFile(oStore.stagingFilePath(hash))
+	if os.IsNotExist(err) {
+		// uploaded already, the block was staged again meanwhile
+		oStore.mutex.Lock()
+		delete(oStore.staged, hash)
+		oStore.mutex.Unlock()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = oStore.retry(ctx, func(ctx context.Context) error {
+		return oStore.client.putObject(ctx, oStore.objectKey(hash), data, map[string]string{s3MetaBlockHash: hash})
+	})
+	if err != nil {
+		return err
+	}
+
+	oStore.mutex.Lock()
+	defer oStore.mutex.Unlock()
+	delete(oStore.staged, hash)
+	return os.Remove(oStore.stagingFilePath(hash))
+}
+
+// uploadBatch uploads the staged blocks concurrently, the failed ones stay staged
+// and are uploaded with the next batch
+func (oStore *ObjectStore) uploadBatch(ctx context.Context, batch []string) {
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		failed []string
+	)
+	for _, hash := range batch {
+		wg.Add(1)
+		go func(hash string) {
+			defer wg.Done()
+			if err := oStore.upload(ctx, hash); err != nil {
+				logging.Logger.Error("object store - upload block",
+					zap.String("hash", hash), zap.Error(err))
+				mutex.Lock()
+				failed = append(failed, hash)
+				mutex.Unlock()
+			}
+		}(hash)
+	}
+	wg.Wait()
+
+	logging.Logger.Debug("object store - uploaded blocks batch",
+		zap.Int("blocks", len(batch)-len(failed)), zap.Int("failed", len(failed)))
+	if len(failed) > 0 {
+		oStore.mutex.Lock()
+		oStore.pending = append(oStore.pending, failed...)
+		oStore.mutex.Unlock()
+	}
+}
+
+// nextBatch takes up to the batch size of the pending blocks
+func (oStore *ObjectStore) nextBatch() []string {
+	oStore.mutex.Lock()
+	defer oStore.mutex.Unlock()
+	n := len(oStore.pending)
+	if n > oStore.cfg.BatchSize {
+		n = oStore.cfg.BatchSize
+	}
+	batch := make([]string, n)
+	copy(batch, oStore.pending)
+	oStore.pending = oStore.pending[n:]
+	return batch
+}
+
+// Run uploads the staged blocks in batches of up to the batch size, a batch is
+// uploaded once full or on the batch interval
+func (oStore *ObjectStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(oStore.cfg.BatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-oStore.fullCh:
+		case <-ticker.C:
+		}
+
+		for {
+			batch := oStore.nextBatch()
+			if len(batch) == 0 {
+				break
+			}
+			oStore.uploadBatch(ctx, batch)
+			if len(batch) < oStore.cfg.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+func initObjectStore(oViper *viper.Viper, c cacher) *ObjectStore {
+	cfg := ObjectStoreConfig{
+		Endpoint:        oViper.GetString("endpoint"),
+		Region:          oViper.GetString("region"),
+		Bucket:          oViper.GetString("bucket"),
+		Prefix:          oViper.GetString("prefix"),
+		AccessKeyID:     oViper.GetString("access_key_id"),
+		SecretAccessKey: oViper.GetString("secret_access_key"),
+		StagingPath:     oViper.GetString("staging_path"),
+		BatchSize:       oViper.GetInt("batch_size"),
+		BatchInterval:   oViper.GetDuration("batch_interval"),
+		MaxRetries:      DefaultObjectStoreMaxRetries,
+		RetryBackoff:    oViper.GetDuration("retry_backoff"),
+		Timeout:         oViper.GetDuration("timeout"),
+	}
+	if oViper.IsSet("max_retries") {
+		cfg.MaxRetries = oViper.GetInt("max_retries")
+	}
+
+	oStore, err := NewObjectStore(cfg, c)
+	if err != nil {
+		panic(err)
+	}
+	return oStore
+}
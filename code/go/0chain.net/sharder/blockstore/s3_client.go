@@ -0,0 +1,233 @@
+package blockstore
+
+// A minimal client of the S3-compatible object storage API (AWS S3, MinIO, etc.)
+// covering the put and get object requests signed with the signature version 4.
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3SignAlgorithm   = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3DateFormat      = "20060102"
+	s3TimeFormat      = "20060102T150405Z"
+	s3MetaBlockHash   = "x-amz-meta-block-hash"
+	s3MaxErrorBodyLen = 1024
+)
+
+var errObjectNotFound = errors.New("object not found")
+
+type s3Client struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func newS3Client(endpoint, region, bucket, accessKey, secretKey string, timeout time.Duration) (*s3Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object store endpoint: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid object store endpoint scheme: %q", u.Scheme)
+	}
+	if bucket == "" {
+		return nil, errors.New("missing object store bucket")
+	}
+
+	return &s3Client{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// objectURL returns the path-style URL of the object
+func (c *s3Client) objectURL(key string) *url.URL {
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + c.bucket + "/" + key
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + awsURIEncode(c.bucket, false) +
+		"/" + awsURIEncode(key, false)
+	return &u
+}
+
+// putObject uploads the object, the storage verifies the content against its MD5
+func (c *s3Client) putObject(ctx context.Context, key string, data []byte, meta map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(key).String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	sum := md5.Sum(data)
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	for k, v := range meta {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.do(req, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return readS3Error(resp)
+	}
+	return nil
+}
+
+// getObject downloads the object, errObjectNotFound is returned if there is no such object
+func (c *s3Client) getObject(ctx context.Context, key string) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil, errObjectNotFound
+	default:
+		return nil, nil, readS3Error(resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, resp.Header, nil
+}
+
+func (c *s3Client) do(req *http.Request, payload []byte) (*http.Response, error) {
+	c.sign(req, payload, time.Now().UTC())
+	return c.client.Do(req)
+}
+
+// sign adds the signature version 4 authorization to the request
+func (c *s3Client) sign(req *http.Request, payload []byte, now time.Time) {
+	payloadHash := sha256.Sum256(payload)
+	payloadHex := hex.EncodeToString(payloadHash[:])
+	amzDate := now.Format(s3TimeFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+	if c.accessKey == "" {
+		// anonymous access
+		return
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHex,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := strings.Join([]string{now.Format(s3DateFormat), c.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3SignAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SignAlgorithm, c.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vs := values[k]
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, awsURIEncode(k, true)+"="+awsURIEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsURIEncode encodes all but the unreserved characters, the slashes are kept
+// unless encodeSlash is set
+func awsURIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func readS3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, s3MaxErrorBodyLen))
+	return fmt.Errorf("object store request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,365 @@
+package sharder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/chain"
+	"0chain.net/chaincore/round"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	"0chain.net/sharder/blockstore"
+	"0chain.net/sharder/chainarchive"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+// importStateNodesBatch is the number of the state nodes saved at once on import
+const importStateNodesBatch = 1024
+
+// ExportArchive writes the finalized blocks of the rounds range and the state nodes of
+// the client state of the state round block to an archive that is used to bootstrap
+// other sharders. The sharder must not be running, its stores are read directly.
+func (sc *Chain) ExportArchive(ctx context.Context, w io.Writer, fromRound, toRound, stateRound int64) error {
+	stateBlock, err := sc.getFinalizedBlockFromStore(ctx, stateRound)
+	if err != nil {
+		return err
+	}
+	if len(stateBlock.ClientStateHash) == 0 {
+		return fmt.Errorf("block of the state round %d has no client state hash", stateRound)
+	}
+	if !sc.HasClientStateStored(stateBlock.ClientStateHash) {
+		return fmt.Errorf("client state of the round %d is not stored", stateRound)
+	}
+
+	aw, err := chainarchive.NewWriter(w, chainarchive.Header{
+		ChainID:    sc.ID,
+		FromRound:  fromRound,
+		ToRound:    toRound,
+		StateRound: stateRound,
+		StateRoot:  util.ToHex(stateBlock.ClientStateHash),
+		CreatedAt:  time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the sharder needs the magic block related to the state round block to start from it
+	if stateBlock.LatestFinalizedMagicBlockRound < fromRound {
+		mb, err := blockstore.GetStore().ReadWithBlockSummary(&block.BlockSummary{
+			Hash:  stateBlock.LatestFinalizedMagicBlockHash,
+			Round: stateBlock.LatestFinalizedMagicBlockRound,
+		})
+		if err != nil {
+			return fmt.Errorf("read magic block of the round %d: %v", stateBlock.LatestFinalizedMagicBlockRound, err)
+		}
+		if err := aw.WriteMagicBlock(mb); err != nil {
+			return err
+		}
+	}
+
+	for r := fromRound; r <= toRound; r++ {
+		b, err := sc.getFinalizedBlockFromStore(ctx, r)
+		if err != nil {
+			return err
+		}
+		if err := aw.WriteBlock(b); err != nil {
+			return err
+		}
+	}
+
+	mpt := util.NewMerklePatriciaTrie(sc.GetStateDB(), util.Sequence(stateRound), stateBlock.ClientStateHash)
+	err = mpt.Iterate(ctx, func(ctx context.Context, path util.Path, key util.Key, node util.Node) error {
+		if node == nil {
+			return fmt.Errorf("missing state node: %s", util.ToHex(key))
+		}
+		return aw.WriteStateNode(node)
+	}, util.NodeTypeLeafNode|util.NodeTypeFullNode|util.NodeTypeExtensionNode)
+	if err != nil {
+		return fmt.Errorf("export state of the round %d: %v", stateRound, err)
+	}
+
+	if err := aw.Close(); err != nil {
+		return err
+	}
+
+	logging.Logger.Info("export archive - done",
+		zap.Int64("from_round", fromRound),
+		zap.Int64("to_round", toRound),
+		zap.Int64("state_round", stateRound),
+		zap.String("state_root", util.ToHex(stateBlock.ClientStateHash)))
+	return nil
+}
+
+func (sc *Chain) getFinalizedBlockFromStore(ctx context.Context, roundNum int64) (*block.Block, error) {
+	r, err := sc.GetRoundFromStore(ctx, roundNum)
+	if err != nil {
+		return nil, fmt.Errorf("read round %d: %v", roundNum, err)
+	}
+	if r.BlockHash == "" {
+		return nil, fmt.Errorf("round %d has empty block hash", roundNum)
+	}
+	b, err := sc.GetBlockFromStore(r.BlockHash, roundNum)
+	if err != nil {
+		return nil, fmt.Errorf("read block of the round %d: %v", roundNum, err)
+	}
+	return b, nil
+}
+
+// ImportArchive loads the archive into the block store, the state DB and the events DB.
+// The blocks are stored as finalized up to the state round, so that the sharder starts
+// from the state round block. The events DB gets the blocks and the transactions events,
+// the smart contracts events are not part of the archive, so they are recorded as missing
+// up to the state round.
+func (sc *Chain) ImportArchive(ctx context.Context, r io.Reader) error {
+	ar, err := chainarchive.NewReader(r)
+	if err != nil {
+		return err
+	}
+	h := ar.Header()
+	if h.ChainID != sc.ID {
+		return fmt.Errorf("archive of the chain %s can't be imported to the chain %s", h.ChainID, sc.ID)
+	}
+
+	logging.Logger.Info("import archive - start",
+		zap.Int64("from_round", h.FromRound),
+		zap.Int64("to_round", h.ToRound),
+		zap.Int64("state_round", h.StateRound))
+
+	localRound := sc.getLatestStoredRound(ctx)
+
+	var (
+		// the blocks are only stored once the checksum of the whole archive is verified,
+		// the state nodes are verified by their hashes and saved as they are read
+		blocks      []*block.Block
+		magicBlocks []*block.Block
+		keys        = make([]util.Key, 0, importStateNodesBatch)
+		nodes       = make([]util.Node, 0, importStateNodesBatch)
+	)
+	flushNodes := func() error {
+		if len(nodes) == 0 {
+			return nil
+		}
+		if err := sc.GetStateDB().MultiPutNode(keys, nodes); err != nil {
+			return err
+		}
+		keys, nodes = keys[:0], nodes[:0]
+		return nil
+	}
+
+	for {
+		rec, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case rec.Block != nil && rec.MagicBlock:
+			magicBlocks = append(magicBlocks, rec.Block)
+		case rec.Block != nil:
+			blocks = append(blocks, rec.Block)
+		case rec.StateNode != nil:
+			keys = append(keys, rec.StateNode.GetHashBytes())
+			nodes = append(nodes, rec.StateNode)
+			if len(nodes) == importStateNodesBatch {
+				if err := flushNodes(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := flushNodes(); err != nil {
+		return err
+	}
+
+	stateBlock := ar.StateBlock()
+	if stateBlock == nil {
+		return errors.New("archive has no state round block")
+	}
+	// all the nodes of the state must be there
+	mpt := util.NewMerklePatriciaTrie(sc.GetStateDB(), util.Sequence(h.StateRound), stateBlock.ClientStateHash)
+	err = mpt.Iterate(ctx, func(ctx context.Context, path util.Path, key util.Key, node util.Node) error {
+		if node == nil {
+			return fmt.Errorf("missing state node: %s", util.ToHex(key))
+		}
+		return nil
+	}, util.NodeTypeLeafNode|util.NodeTypeFullNode|util.NodeTypeExtensionNode)
+	if err != nil {
+		return fmt.Errorf("verify imported state: %v", err)
+	}
+
+	for _, b := range append(magicBlocks, blocks...) {
+		if err := sc.storeBlock(b); err != nil {
+			return fmt.Errorf("store block of the round %d: %v", b.Round, err)
+		}
+	}
+	for _, b := range blocks {
+		if err := sc.importFinalizedBlock(ctx, b, b.Round <= h.StateRound); err != nil {
+			return err
+		}
+	}
+	if err := sc.markMissingEvents(localRound+1, h.StateRound, "archive"); err != nil {
+		return err
+	}
+
+	logging.Logger.Info("import archive - done",
+		zap.Int64("blocks", ar.Footer().Blocks),
+		zap.Int64("state_nodes", ar.Footer().StateNodes))
+	return nil
+}
+
+// importFinalizedBlock stores the summaries and the events of the imported block, and its
+// round if the block is to be seen as finalized
+func (sc *Chain) importFinalizedBlock(ctx context.Context, b *block.Block, finalized bool) error {
+	if err := sc.StoreTransactions(b); err != nil {
+		return fmt.Errorf("store transactions of the round %d: %v", b.Round, err)
+	}
+	if err := sc.StoreBlockSummaryFromBlock(b); err != nil {
+		return fmt.Errorf("store block summary of the round %d: %v", b.Round, err)
+	}
+
+	if edb := sc.GetEventDb(); edb != nil {
+		_, ev := block.CreateBlockEvent(b)
+		events := []event.Event{ev, block.CreateFinalizeBlockEvent(b)}
+		for _, txn := range b.Txns {
+			events = append(events, block.CreateTxnEvents(b, txn)...)
+		}
+		if _, err := edb.ProcessEvents(ctx, events, b.Round, b.Hash, len(b.Txns), event.CommitNow()); err != nil {
+			return fmt.Errorf("process events of the round %d: %v", b.Round, err)
+		}
+	}
+
+	if !finalized {
+		return nil
+	}
+
+	r := round.NewRound(b.Round)
+	r.SetRandomSeedForNotarizedBlock(b.GetRoundRandomSeed(), sc.GetMiners(b.Round).Size())
+	r.Finalize(b)
+	if err := sc.StoreRound(r); err != nil {
+		return common.NewErrorf("import_archive", "store round %d: %v", b.Round, err)
+	}
+	return nil
+}
+
+// FastSync downloads the state of a recent finalized block from the other sharders
+// when the sharder is far behind them, and stores the block as the latest finalized
+// one, so that the sharder starts from it. It must be called before the latest blocks
+// are loaded from the store.
+func (sc *Chain) FastSync(ctx context.Context, conf chain.FastSyncConfig) error {
+	if err := sc.UpdateLatestMagicBlockFromSharders(ctx); err != nil {
+		return fmt.Errorf("update LFMB from sharders: %v", err)
+	}
+
+	localRound := sc.getLatestStoredRound(ctx)
+	b, err := sc.FastSyncState(ctx, conf, localRound)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return nil
+	}
+
+	// the magic block of the synced block is required to start from it
+	if b.LatestFinalizedMagicBlockHash != b.Hash {
+		if _, err := blockstore.GetStore().Read(b.LatestFinalizedMagicBlockHash); err != nil {
+			mb, err := sc.GetFinalizedBlockFromSharders(ctx, b.LatestFinalizedMagicBlockHash,
+				b.LatestFinalizedMagicBlockRound)
+			if err != nil {
+				return fmt.Errorf("fetch magic block of the round %d: %v", b.LatestFinalizedMagicBlockRound, err)
+			}
+			if mb.MagicBlock == nil {
+				return fmt.Errorf("block of the round %d has no magic block", mb.Round)
+			}
+			if err := sc.SaveMagicBlockHandler(ctx, mb); err != nil {
+				return fmt.Errorf("save magic block of the round %d: %v", mb.Round, err)
+			}
+		}
+	}
+
+	if err := sc.storeBlock(b); err != nil {
+		return fmt.Errorf("store block of the round %d: %v", b.Round, err)
+	}
+	if err := sc.importFinalizedBlock(ctx, b, true); err != nil {
+		return err
+	}
+
+	// only the state is synced, the smart contracts events of the skipped rounds
+	// are not replayed
+	return sc.markMissingEvents(localRound+1, b.Round, "fast_sync")
+}
+
+// markMissingEvents records in the events DB that the smart contracts events of the
+// rounds restored without them are missing
+func (sc *Chain) markMissingEvents(fromRound, toRound int64, source string) error {
+	edb := sc.GetEventDb()
+	if edb == nil {
+		return nil
+	}
+	if err := edb.AddMissingEvents(fromRound, toRound, source); err != nil {
+		return fmt.Errorf("mark missing events of the rounds %d-%d: %v", fromRound, toRound, err)
+	}
+	logging.Logger.Warn("smart contracts events are missing, the events DB must be rebuilt",
+		zap.Int64("from_round", fromRound),
+		zap.Int64("to_round", toRound),
+		zap.String("source", source))
+	return nil
+}
+
+// WarnMissingEvents logs the rounds which smart contracts events are missing in the
+// events DB, the data of the smart contracts served from it is incomplete until the
+// events DB is rebuilt
+func (sc *Chain) WarnMissingEvents() {
+	edb := sc.GetEventDb()
+	if edb == nil {
+		return
+	}
+	missing, err := edb.GetMissingEvents()
+	if err != nil {
+		logging.Logger.Error("get missing events", zap.Error(err))
+		return
+	}
+	for _, m := range missing {
+		logging.Logger.Warn("smart contracts events are missing, the events DB must be rebuilt",
+			zap.Int64("from_round", m.FromRound),
+			zap.Int64("to_round", m.ToRound),
+			zap.String("source", m.Source))
+	}
+}
+
+// getLatestStoredRound returns the number of the latest round in the store
+func (sc *Chain) getLatestStoredRound(ctx context.Context) int64 {
+	var (
+		remd = datastore.GetEntityMetadata("round")
+		rctx = ememorystore.WithEntityConnection(ctx, remd)
+	)
+	defer ememorystore.Close(rctx)
+
+	var (
+		conn = ememorystore.GetEntityCon(rctx, remd)
+		iter = conn.Conn.NewIterator(conn.ReadOptions)
+	)
+	defer iter.Close()
+
+	iter.SeekToLast()
+	if !iter.Valid() {
+		return 0
+	}
+
+	r := remd.Instance().(*round.Round)
+	if err := datastore.FromJSON(iter.Value().Data(), r); err != nil {
+		logging.Logger.Error("get latest stored round", zap.Error(err))
+		return 0
+	}
+	return r.Number
+}
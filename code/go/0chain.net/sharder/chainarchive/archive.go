@@ -0,0 +1,419 @@
+// Package chainarchive implements the archive format used to bootstrap sharders. An archive
+// carries a range of finalized blocks and all the state (MPT) nodes of the client state of
+// one of them.
+//
+// The archive is a gzip compressed stream of records, each one is a kind byte, the length
+// of the payload as uint32 and the payload:
+//
+//	header      JSON encoded Header, always the first record
+//	magic block msgpack encoded block with the magic block the state block relates to,
+//	            when it's out of the blocks range
+//	block       msgpack encoded block, the blocks of the range in the rounds order
+//	state node  the node hash followed by the encoded node
+//	footer      JSON encoded Footer, always the last record
+//
+// The footer carries the number of the records and the SHA-256 checksum of all the
+// preceding records. The Reader verifies the checksum, the hash of each block, the blocks
+// chain and the hash of each state node.
+package chainarchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/util"
+)
+
+// Version is the version of the archive format
+const Version = 1
+
+const (
+	// maxRecordSize limits the size of a record to be read
+	maxRecordSize = 256 * 1024 * 1024
+	// stateNodeKeyLength is the length of the state node hash
+	stateNodeKeyLength = 32
+)
+
+type recordKind byte
+
+const (
+	recordHeader recordKind = iota + 1
+	recordMagicBlock
+	recordBlock
+	recordStateNode
+	recordFooter
+)
+
+var (
+	ErrInvalidArchive   = errors.New("invalid archive")
+	ErrTruncatedArchive = errors.New("truncated archive")
+	ErrChecksumMismatch = errors.New("archive checksum mismatch")
+)
+
+// Header describes the content of the archive
+type Header struct {
+	Version    int    `json:"version"`
+	ChainID    string `json:"chain_id"`
+	FromRound  int64  `json:"from_round"`
+	ToRound    int64  `json:"to_round"`
+	StateRound int64  `json:"state_round"`
+	// StateRoot is the client state hash of the state round block
+	StateRoot string `json:"state_root"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Validate checks the header fields
+func (h *Header) Validate() error {
+	if h.Version != Version {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, h.Version)
+	}
+	if h.FromRound < 0 || h.FromRound > h.ToRound {
+		return fmt.Errorf("%w: invalid rounds range %d-%d", ErrInvalidArchive, h.FromRound, h.ToRound)
+	}
+	if h.StateRound < h.FromRound || h.StateRound > h.ToRound {
+		return fmt.Errorf("%w: state round %d is out of the rounds range", ErrInvalidArchive, h.StateRound)
+	}
+	if _, err := hex.DecodeString(h.StateRoot); err != nil || h.StateRoot == "" {
+		return fmt.Errorf("%w: invalid state root", ErrInvalidArchive)
+	}
+	return nil
+}
+
+// Footer closes the archive
+type Footer struct {
+	Blocks      int64  `json:"blocks"`
+	MagicBlocks int64  `json:"magic_blocks"`
+	StateNodes  int64  `json:"state_nodes"`
+	Checksum    string `json:"checksum"`
+}
+
+// Writer writes an archive
+type Writer struct {
+	zw       *gzip.Writer
+	checksum hash.Hash
+	footer   Footer
+	header   Header
+	buf      bytes.Buffer
+}
+
+// NewWriter writes the archive header to the underlying writer
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	h.Version = Version
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+
+	aw := &Writer{zw: gzip.NewWriter(w), checksum: sha256.New(), header: h}
+	data, err := json.Marshal(&h)
+	if err != nil {
+		return nil, err
+	}
+	if err := aw.writeRecord(recordHeader, data); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+func (aw *Writer) writeRecord(kind recordKind, payload []byte) error {
+	var prefix [5]byte
+	prefix[0] = byte(kind)
+	binary.LittleEndian.PutUint32(prefix[1:], uint32(len(payload)))
+
+	w := io.MultiWriter(aw.zw, aw.checksum)
+	if kind == recordFooter {
+		w = aw.zw
+	}
+	if _, err := w.Write(prefix[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func (aw *Writer) writeBlock(kind recordKind, b *block.Block) error {
+	aw.buf.Reset()
+	if err := datastore.WriteMsgpack(&aw.buf, b); err != nil {
+		return err
+	}
+	return aw.writeRecord(kind, aw.buf.Bytes())
+}
+
+// WriteMagicBlock writes the block with the magic block related to the state round block,
+// it must be written before the blocks of the range.
+func (aw *Writer) WriteMagicBlock(b *block.Block) error {
+	if aw.footer.Blocks > 0 {
+		return errors.New("magic block must be written before the blocks")
+	}
+	if b.MagicBlock == nil {
+		return fmt.Errorf("block %d has no magic block", b.Round)
+	}
+	aw.footer.MagicBlocks++
+	return aw.writeBlock(recordMagicBlock, b)
+}
+
+// WriteBlock writes the next block of the range
+func (aw *Writer) WriteBlock(b *block.Block) error {
+	if want := aw.header.FromRound + aw.footer.Blocks; b.Round != want {
+		return fmt.Errorf("unexpected block round %d, want %d", b.Round, want)
+	}
+	aw.footer.Blocks++
+	return aw.writeBlock(recordBlock, b)
+}
+
+// WriteStateNode writes a state node
+func (aw *Writer) WriteStateNode(node util.Node) error {
+	aw.buf.Reset()
+	aw.buf.Write(node.GetHashBytes())
+	aw.buf.Write(node.Encode())
+	aw.footer.StateNodes++
+	return aw.writeRecord(recordStateNode, aw.buf.Bytes())
+}
+
+// Close writes the footer and flushes the archive, the underlying writer is not closed
+func (aw *Writer) Close() error {
+	if want := aw.header.ToRound - aw.header.FromRound + 1; aw.footer.Blocks != want {
+		return fmt.Errorf("archive has %d blocks, want %d", aw.footer.Blocks, want)
+	}
+
+	aw.footer.Checksum = hex.EncodeToString(aw.checksum.Sum(nil))
+	data, err := json.Marshal(&aw.footer)
+	if err != nil {
+		return err
+	}
+	if err := aw.writeRecord(recordFooter, data); err != nil {
+		return err
+	}
+	return aw.zw.Close()
+}
+
+// Record is a block or a state node read from the archive
+type Record struct {
+	// Block is set for the blocks and the magic blocks
+	Block *block.Block
+	// MagicBlock is set if the Block is the out of range magic block
+	MagicBlock bool
+	// StateNode is set for the state nodes
+	StateNode util.Node
+}
+
+// Reader reads and verifies an archive
+type Reader struct {
+	zr       *gzip.Reader
+	checksum hash.Hash
+	header   Header
+	footer   Footer
+	prev     *block.Block
+	done     bool
+
+	stateBlock *block.Block
+	magicBlock *block.Block
+}
+
+// NewReader reads the archive header from the underlying reader
+func NewReader(r io.Reader) (*Reader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	ar := &Reader{zr: zr, checksum: sha256.New()}
+	kind, data, err := ar.readRecord()
+	if err != nil {
+		return nil, err
+	}
+	if kind != recordHeader {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidArchive)
+	}
+	if err := json.Unmarshal(data, &ar.header); err != nil {
+		return nil, fmt.Errorf("%w: invalid header: %v", ErrInvalidArchive, err)
+	}
+	if err := ar.header.Validate(); err != nil {
+		return nil, err
+	}
+	return ar, nil
+}
+
+// Header returns the archive header
+func (ar *Reader) Header() Header {
+	return ar.header
+}
+
+// Footer returns the archive footer, it's set once the whole archive is read
+func (ar *Reader) Footer() Footer {
+	return ar.footer
+}
+
+// StateBlock returns the block of the state round, once read
+func (ar *Reader) StateBlock() *block.Block {
+	return ar.stateBlock
+}
+
+func (ar *Reader) readRecord() (recordKind, []byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(ar.zr, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, ErrTruncatedArchive
+		}
+		return 0, nil, err
+	}
+
+	size := binary.LittleEndian.Uint32(prefix[1:])
+	if size > maxRecordSize {
+		return 0, nil, fmt.Errorf("%w: record size %d exceeds the limit", ErrInvalidArchive, size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(ar.zr, data); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, ErrTruncatedArchive
+		}
+		return 0, nil, err
+	}
+
+	kind := recordKind(prefix[0])
+	if kind != recordFooter {
+		ar.checksum.Write(prefix[:])
+		ar.checksum.Write(data)
+	}
+	return kind, data, nil
+}
+
+// Next returns the next verified record, io.EOF is returned once the footer is read and
+// the whole archive is verified. The records must not be used if an error is returned
+// before the io.EOF.
+func (ar *Reader) Next() (*Record, error) {
+	if ar.done {
+		return nil, io.EOF
+	}
+
+	kind, data, err := ar.readRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case recordMagicBlock:
+		b, err := decodeBlock(data)
+		if err != nil {
+			return nil, err
+		}
+		if ar.prev != nil || b.MagicBlock == nil {
+			return nil, fmt.Errorf("%w: unexpected magic block %d", ErrInvalidArchive, b.Round)
+		}
+		ar.footer.MagicBlocks++
+		ar.magicBlock = b
+		return &Record{Block: b, MagicBlock: true}, nil
+	case recordBlock:
+		b, err := decodeBlock(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := ar.verifyBlock(b); err != nil {
+			return nil, err
+		}
+		ar.footer.Blocks++
+		ar.prev = b
+		return &Record{Block: b}, nil
+	case recordStateNode:
+		node, err := decodeStateNode(data)
+		if err != nil {
+			return nil, err
+		}
+		ar.footer.StateNodes++
+		return &Record{StateNode: node}, nil
+	case recordFooter:
+		return nil, ar.verifyFooter(data)
+	default:
+		return nil, fmt.Errorf("%w: unknown record kind %d", ErrInvalidArchive, kind)
+	}
+}
+
+func (ar *Reader) verifyBlock(b *block.Block) error {
+	want := ar.header.FromRound
+	if ar.prev != nil {
+		want = ar.prev.Round + 1
+	}
+	if b.Round != want || b.Round > ar.header.ToRound {
+		return fmt.Errorf("%w: unexpected block round %d, want %d", ErrInvalidArchive, b.Round, want)
+	}
+	if ar.prev != nil && b.PrevHash != ar.prev.Hash {
+		return fmt.Errorf("%w: block %d doesn't follow the previous block", ErrInvalidArchive, b.Round)
+	}
+
+	if b.Round != ar.header.StateRound {
+		return nil
+	}
+	if util.ToHex(b.ClientStateHash) != ar.header.StateRoot {
+		return fmt.Errorf("%w: state root doesn't match the state round block", ErrInvalidArchive)
+	}
+
+	// the block with the related magic block is required to start from the state round
+	switch {
+	case b.LatestFinalizedMagicBlockHash == b.Hash:
+	case b.LatestFinalizedMagicBlockRound >= ar.header.FromRound:
+		// in the blocks range
+	case ar.magicBlock != nil && ar.magicBlock.Hash == b.LatestFinalizedMagicBlockHash:
+	default:
+		return fmt.Errorf("%w: missing magic block of the state round block", ErrInvalidArchive)
+	}
+	ar.stateBlock = b
+	return nil
+}
+
+func (ar *Reader) verifyFooter(data []byte) error {
+	var footer Footer
+	if err := json.Unmarshal(data, &footer); err != nil {
+		return fmt.Errorf("%w: invalid footer: %v", ErrInvalidArchive, err)
+	}
+
+	if footer.Checksum != hex.EncodeToString(ar.checksum.Sum(nil)) {
+		return ErrChecksumMismatch
+	}
+	ar.footer.Checksum = footer.Checksum
+	if footer != ar.footer {
+		return fmt.Errorf("%w: records count doesn't match the footer", ErrInvalidArchive)
+	}
+	if want := ar.header.ToRound - ar.header.FromRound + 1; ar.footer.Blocks != want {
+		return fmt.Errorf("%w: archive has %d blocks, want %d", ErrInvalidArchive, ar.footer.Blocks, want)
+	}
+
+	ar.done = true
+	return io.EOF
+}
+
+// decodeBlock decodes the block and checks its hash
+func decodeBlock(data []byte) (*block.Block, error) {
+	b := block.Provider().(*block.Block)
+	if err := datastore.ReadMsgpack(bytes.NewReader(data), b); err != nil {
+		return nil, fmt.Errorf("%w: invalid block: %v", ErrInvalidArchive, err)
+	}
+	if h := b.ComputeHash(); h != b.Hash {
+		return nil, fmt.Errorf("%w: block %d hash mismatch", ErrInvalidArchive, b.Round)
+	}
+	return b, nil
+}
+
+// decodeStateNode decodes the node and checks its hash
+func decodeStateNode(data []byte) (util.Node, error) {
+	if len(data) < stateNodeKeyLength {
+		return nil, fmt.Errorf("%w: invalid state node", ErrInvalidArchive)
+	}
+	key, encoded := data[:stateNodeKeyLength], data[stateNodeKeyLength:]
+	node, err := util.CreateNode(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid state node: %v", ErrInvalidArchive, err)
+	}
+	if !bytes.Equal(node.GetHashBytes(), key) {
+		return nil, fmt.Errorf("%w: state node hash mismatch", ErrInvalidArchive)
+	}
+	return node, nil
+}
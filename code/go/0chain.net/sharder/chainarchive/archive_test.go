@@ -0,0 +1,202 @@
+package chainarchive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"0chain.net/core/encryption"
+	"0chain.net/core/memorystore"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	block.SetupEntity(memorystore.GetStorageProvider())
+}
+
+type testChain struct {
+	mb     *block.Block
+	blocks []*block.Block
+	nodes  []util.Node
+	root   util.Key
+}
+
+// newTestChain creates the blocks of the rounds from 5 to 9, with the magic block at the
+// round 1, and the client state of the round 8
+func newTestChain(t *testing.T) *testChain {
+	mpt := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+	for i := 0; i < 10; i++ {
+		v := util.SecureSerializableValue{Buffer: []byte("value " + strconv.Itoa(i))}
+		_, err := mpt.Insert(util.Path(encryption.Hash("key "+strconv.Itoa(i))), &v)
+		require.NoError(t, err)
+	}
+
+	tc := &testChain{root: mpt.GetRoot()}
+	err := mpt.Iterate(context.TODO(), func(ctx context.Context, path util.Path, key util.Key, node util.Node) error {
+		tc.nodes = append(tc.nodes, node)
+		return nil
+	}, util.NodeTypeLeafNode|util.NodeTypeFullNode|util.NodeTypeExtensionNode)
+	require.NoError(t, err)
+
+	tc.mb = block.NewBlock("", 1)
+	tc.mb.MagicBlock = block.NewMagicBlock()
+	tc.mb.MagicBlock.Miners = node.NewPool(node.NodeTypeMiner)
+	tc.mb.MagicBlock.Sharders = node.NewPool(node.NodeTypeSharder)
+	tc.mb.MagicBlock.StartingRound = 1
+	tc.mb.HashBlock()
+
+	prevHash := encryption.Hash("block 4")
+	for r := int64(5); r <= 9; r++ {
+		b := block.NewBlock("", r)
+		b.PrevHash = prevHash
+		b.LatestFinalizedMagicBlockHash = tc.mb.Hash
+		b.LatestFinalizedMagicBlockRound = tc.mb.Round
+		if r == 8 {
+			b.ClientStateHash = tc.root
+		}
+		b.HashBlock()
+		prevHash = b.Hash
+		tc.blocks = append(tc.blocks, b)
+	}
+	return tc
+}
+
+func (tc *testChain) write(t *testing.T, blocks []*block.Block) *bytes.Buffer {
+	buf := bytes.NewBuffer(nil)
+	aw, err := NewWriter(buf, Header{
+		ChainID:    "chain",
+		FromRound:  5,
+		ToRound:    9,
+		StateRound: 8,
+		StateRoot:  util.ToHex(tc.root),
+	})
+	require.NoError(t, err)
+	require.NoError(t, aw.WriteMagicBlock(tc.mb))
+	for _, b := range blocks {
+		require.NoError(t, aw.WriteBlock(b))
+	}
+	for _, n := range tc.nodes {
+		require.NoError(t, aw.WriteStateNode(n))
+	}
+	require.NoError(t, aw.Close())
+	return buf
+}
+
+func readAll(r io.Reader) (*Reader, []*Record, error) {
+	ar, err := NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var recs []*Record
+	for {
+		rec, err := ar.Next()
+		if err == io.EOF {
+			return ar, recs, nil
+		}
+		if err != nil {
+			return ar, recs, err
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func TestArchiveWriteRead(t *testing.T) {
+	tc := newTestChain(t)
+	buf := tc.write(t, tc.blocks)
+
+	ar, recs, err := readAll(buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), ar.Header().StateRound)
+	require.Equal(t, tc.blocks[3].Hash, ar.StateBlock().Hash)
+	require.Equal(t, int64(5), ar.Footer().Blocks)
+	require.Equal(t, int64(1), ar.Footer().MagicBlocks)
+	require.Equal(t, int64(len(tc.nodes)), ar.Footer().StateNodes)
+	require.Len(t, recs, 1+len(tc.blocks)+len(tc.nodes))
+
+	require.True(t, recs[0].MagicBlock)
+	require.Equal(t, tc.mb.Hash, recs[0].Block.Hash)
+	for i, b := range tc.blocks {
+		require.Equal(t, b.Hash, recs[i+1].Block.Hash)
+	}
+
+	// the read nodes restore the state
+	db := util.NewMemoryNodeDB()
+	for _, rec := range recs[1+len(tc.blocks):] {
+		require.NoError(t, db.PutNode(rec.StateNode.GetHashBytes(), rec.StateNode))
+	}
+	mpt := util.NewMerklePatriciaTrie(db, 1, tc.root)
+	var v util.SecureSerializableValue
+	require.NoError(t, mpt.GetNodeValue(util.Path(encryption.Hash("key 3")), &v))
+	require.Equal(t, []byte("value 3"), v.Buffer)
+}
+
+func TestArchiveVerification(t *testing.T) {
+	tc := newTestChain(t)
+
+	t.Run("truncated", func(t *testing.T) {
+		data := tc.write(t, tc.blocks).Bytes()
+		_, _, err := readAll(bytes.NewReader(data[:len(data)/2]))
+		require.Error(t, err)
+	})
+
+	t.Run("broken blocks chain", func(t *testing.T) {
+		blocks := make([]*block.Block, len(tc.blocks))
+		copy(blocks, tc.blocks)
+		b := block.NewBlock("", 7)
+		b.PrevHash = encryption.Hash("other block")
+		b.HashBlock()
+		blocks[2] = b
+
+		_, _, err := readAll(tc.write(t, blocks))
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("tampered block", func(t *testing.T) {
+		blocks := make([]*block.Block, len(tc.blocks))
+		copy(blocks, tc.blocks)
+		b := tc.blocks[1].Clone()
+		b.RoundRandomSeed++
+		blocks[1] = b
+
+		_, _, err := readAll(tc.write(t, blocks))
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("wrong state root", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		aw, err := NewWriter(buf, Header{
+			ChainID:    "chain",
+			FromRound:  5,
+			ToRound:    9,
+			StateRound: 7,
+			StateRoot:  util.ToHex(tc.root),
+		})
+		require.NoError(t, err)
+		require.NoError(t, aw.WriteMagicBlock(tc.mb))
+		for _, b := range tc.blocks {
+			require.NoError(t, aw.WriteBlock(b))
+		}
+		require.NoError(t, aw.Close())
+
+		_, _, err = readAll(buf)
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("missing blocks", func(t *testing.T) {
+		aw, err := NewWriter(io.Discard, Header{
+			ChainID:    "chain",
+			FromRound:  5,
+			ToRound:    9,
+			StateRound: 8,
+			StateRoot:  util.ToHex(tc.root),
+		})
+		require.NoError(t, err)
+		require.NoError(t, aw.WriteBlock(tc.blocks[0]))
+		require.Error(t, aw.Close())
+	})
+}
@@ -0,0 +1,252 @@
+package sharder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// eventsStreamKeepAlive is the interval of the keep alive comments sent to idle subscribers
+const eventsStreamKeepAlive = 30 * time.Second
+
+// EventsStreamHandler streams the events committed to the events database for each block
+// as server-sent events. The events could be filtered by tags, types, index and tx_hash
+// query parameters, tags and types are comma separated lists of names or numbers. The
+// from_round parameter resumes the stream from the given round, the rounds no longer kept
+// in memory are read from the events table and sent as "stored_events", without the data
+// of the events as it is not kept in the table. Each message has the events of one round.
+func EventsStreamHandler(w http.ResponseWriter, r *http.Request) {
+	edb := GetSharderChain().GetEventDb()
+	if edb == nil {
+		http.Error(w, "events database is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	filter, fromRound, err := parseEventsStreamParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub, recent, historyStart, err := edb.Subscribe(filter, fromRound)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer sub.Cancel()
+
+	// the stream outlives the server write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		logging.Logger.Warn("events stream - could not reset write deadline", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	// the rounds before the kept history are read from the events table, the events
+	// committed meanwhile are taken from the subscription so that it doesn't lag behind
+	var (
+		lastDBRound = int64(-1)
+		live        []event.CommittedEvents
+	)
+	if fromRound > 0 && (historyStart < 0 || fromRound < historyStart) {
+		toRound := int64(math.MaxInt64)
+		if historyStart >= 0 {
+			toRound = historyStart - 1
+		}
+		lastDBRound, err = writeStoredEvents(w, r, edb, filter, fromRound, toRound, func() {
+			live = drainSubscription(sub, live)
+		})
+		if err != nil {
+			logging.Logger.Error("events stream - read stored events", zap.Error(err))
+			writeEventsStreamError(w, err)
+			return
+		}
+	}
+
+	for _, ce := range recent {
+		if err := writeCommittedEvents(w, "events", ce); err != nil {
+			return
+		}
+	}
+	for _, ce := range live {
+		// already sent from the events table
+		if historyStart < 0 && ce.Round <= lastDBRound {
+			continue
+		}
+		if err := writeCommittedEvents(w, "events", ce); err != nil {
+			return
+		}
+	}
+	rc.Flush()
+
+	keepAlive := time.NewTicker(eventsStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			rc.Flush()
+		case ce, ok := <-sub.Events():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					writeEventsStreamError(w, err)
+					rc.Flush()
+				}
+				return
+			}
+			// already sent from the events table
+			if historyStart < 0 && ce.Round <= lastDBRound {
+				continue
+			}
+			if err := writeCommittedEvents(w, "events", ce); err != nil {
+				return
+			}
+			rc.Flush()
+		}
+	}
+}
+
+func parseEventsStreamParams(r *http.Request) (event.EventFilter, int64, error) {
+	var (
+		query  = r.URL.Query()
+		filter = event.EventFilter{
+			Index:  query.Get("index"),
+			TxHash: query.Get("tx_hash"),
+		}
+		fromRound int64
+	)
+
+	if tags := query.Get("tags"); tags != "" {
+		for _, s := range strings.Split(tags, ",") {
+			tag, err := event.ParseEventTag(strings.TrimSpace(s))
+			if err != nil {
+				return filter, 0, err
+			}
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	if types := query.Get("types"); types != "" {
+		for _, s := range strings.Split(types, ",") {
+			typ, err := event.ParseEventType(strings.TrimSpace(s))
+			if err != nil {
+				return filter, 0, err
+			}
+			filter.Types = append(filter.Types, typ)
+		}
+	}
+
+	if from := query.Get("from_round"); from != "" {
+		var err error
+		fromRound, err = strconv.ParseInt(from, 10, 64)
+		if err != nil || fromRound < 0 {
+			return filter, 0, fmt.Errorf("invalid from_round: %v", from)
+		}
+	}
+
+	return filter, fromRound, nil
+}
+
+// writeStoredEvents writes the events of the rounds from the events table, a round at a
+// time, and returns the last round written. The pages of the table could end in the middle
+// of a round, so a round is written once the next one is read. The onPage callback is
+// called after each page.
+func writeStoredEvents(w http.ResponseWriter, r *http.Request, edb *event.EventDb,
+	filter event.EventFilter, fromRound, toRound int64, onPage func()) (int64, error) {
+	var (
+		lastRound = int64(-1)
+		limit     = int(edb.PageLimit())
+		offset    int
+		cur       *event.CommittedEvents
+	)
+	if limit <= 0 {
+		limit = common.DefaultQueryLimit
+	}
+
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		if err := writeCommittedEvents(w, "stored_events", *cur); err != nil {
+			return err
+		}
+		lastRound = cur.Round
+		cur = nil
+		return nil
+	}
+
+	for {
+		ces, err := edb.FindCommittedEvents(r.Context(), filter, fromRound, toRound,
+			common.Pagination{Offset: offset, Limit: limit})
+		if err != nil {
+			return lastRound, err
+		}
+
+		var n int
+		for i := range ces {
+			n += len(ces[i].Events)
+			if cur != nil && cur.Round == ces[i].Round {
+				cur.Events = append(cur.Events, ces[i].Events...)
+				continue
+			}
+			if err := flush(); err != nil {
+				return lastRound, err
+			}
+			cur = &ces[i]
+		}
+		onPage()
+
+		if n < limit {
+			err := flush()
+			return lastRound, err
+		}
+		offset += n
+	}
+}
+
+// drainSubscription appends the committed events received by the subscription so far
+func drainSubscription(sub *event.Subscription, ces []event.CommittedEvents) []event.CommittedEvents {
+	for {
+		select {
+		case ce, ok := <-sub.Events():
+			if !ok {
+				return ces
+			}
+			ces = append(ces, ce)
+		default:
+			return ces
+		}
+	}
+}
+
+func writeCommittedEvents(w http.ResponseWriter, name string, ce event.CommittedEvents) error {
+	data, err := json.Marshal(ce)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ce.Round, name, data)
+	return err
+}
+
+func writeEventsStreamError(w http.ResponseWriter, err error) {
+	fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+}
@@ -29,7 +29,9 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 		"/v1/sharder/get/stats":            common.ToJSONResponse(SharderStatsHandler),
 		"/v1/state/nodes":                  common.ToJSONResponse(chain.StateNodesHandler),
 		"/v1/block/state_change":           common.ToJSONResponse(BlockStateChangeHandler),
+		"/v1/state/proof":                  common.ToJSONResponse(StateProofHandler),
 		"/_transaction_errors":             TransactionErrorWriter,
+		"/v1/events/stream":                EventsStreamHandler,
 	}
 
 	handlers := make(map[string]func(http.ResponseWriter, *http.Request))
@@ -44,6 +46,34 @@ func BlockStateChangeHandler(ctx context.Context, r *http.Request) (interface{},
 	return c.BlockStateChangeHandler(ctx, r)
 }
 
+/*StateProofHandler - a handler to respond with a client state inclusion or exclusion proof */
+func StateProofHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	roundNumber, err := strconv.ParseInt(r.FormValue("round"), 10, 64)
+	if err != nil {
+		return nil, common.InvalidRequest("invalid round number")
+	}
+	path, err := chain.StateProofPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := GetSharderChain()
+	if roundNumber <= 0 || roundNumber > sc.GetLatestFinalizedBlock().Round {
+		return nil, common.InvalidRequest("round is not finalized")
+	}
+	hash, err := sc.GetBlockHash(ctx, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	b, err := sc.GetBlock(ctx, hash)
+	if err != nil {
+		if b, err = sc.GetBlockFromStore(hash, roundNumber); err != nil {
+			return nil, err
+		}
+	}
+	return sc.GetStateProof(b, path)
+}
+
 type ChainInfo struct {
 	LatestFinalizedBlock *block.BlockSummary `json:"latest_finalized_block"`
 }
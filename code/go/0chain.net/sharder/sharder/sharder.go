@@ -51,6 +51,11 @@ func main() {
 	flag.String("nodes_file", "", "nodes_file (deprecated)")
 	workdir := ""
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
+	exportArchiveFile := flag.String("export_archive", "", "export the blocks range and the state to the archive file and exit")
+	exportFromRound := flag.Int64("export_from_round", 0, "first round of the exported blocks")
+	exportToRound := flag.Int64("export_to_round", 0, "last round of the exported blocks")
+	exportStateRound := flag.Int64("export_state_round", -1, "round of the exported state, defaults to export_to_round")
+	importArchiveFile := flag.String("import_archive", "", "import the archive file before starting")
 
 	flag.Parse()
 	config.Configuration().DeploymentMode = byte(*deploymentMode)
@@ -173,6 +178,23 @@ func main() {
 
 	sc.SetupGenesisBlock(viper.GetString("server_chain.genesis_block.id"), magicBlock, initStates)
 
+	if *exportArchiveFile != "" {
+		if *exportStateRound < 0 {
+			*exportStateRound = *exportToRound
+		}
+		if err := exportArchive(ctx, sc, *exportArchiveFile, *exportFromRound, *exportToRound, *exportStateRound); err != nil {
+			Logger.Fatal("export archive failed", zap.Error(err))
+		}
+		chain.CloseStateDB()
+		return
+	}
+
+	if *importArchiveFile != "" {
+		if err := importArchive(ctx, sc, *importArchiveFile); err != nil {
+			Logger.Fatal("import archive failed", zap.Error(err))
+		}
+	}
+
 	Logger.Info("sharder node", zap.Any("node", node.Self))
 
 	var selfNode = node.Self.Underlying()
@@ -244,11 +266,20 @@ func main() {
 	initN2NHandlers(sc)
 	initWorkers(ctx)
 
+	// sync the state of a recent round from the other sharders before the blocks
+	// processing, the synced block becomes the LFB stored
+	if fsConf := chain.ReadFastSyncConfig(workdir); fsConf.Enabled {
+		if err := sc.FastSync(ctx, fsConf); err != nil {
+			Logger.Fatal("fast state sync failed", zap.Error(err))
+		}
+	}
+
 	// start sharding from the LFB stored
 	if err = sc.LoadLatestBlocksFromStore(common.GetRootContext()); err != nil {
 		Logger.Error("load latest blocks from store: " + err.Error())
 		return
 	}
+	sc.WarnMissingEvents()
 
 	sharder.SetupWorkers(ctx)
 
@@ -318,6 +349,40 @@ func startBlocksInfoLogs(sc *sharder.Chain) {
 		zap.String("hash", lfmb.MagicBlockHash)) // hash of block with the magic block
 }
 
+// exportArchive writes the archive to a temporary file first, so that an incomplete
+// archive is never left under the given name
+func exportArchive(ctx context.Context, sc *sharder.Chain, file string, fromRound, toRound, stateRound int64) error {
+	tmp := file + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	w := bufio.NewWriter(f)
+	if err := sc.ExportArchive(ctx, w, fromRound, toRound, stateRound); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+func importArchive(ctx context.Context, sc *sharder.Chain, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return sc.ImportArchive(ctx, bufio.NewReader(f))
+}
+
 func initServer() {
 	// TODO; when a new server is brought up, it needs to first download all the state before it can start accepting requests
 }
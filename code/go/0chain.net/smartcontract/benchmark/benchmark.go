@@ -212,6 +212,10 @@ const (
 	StorageBlockRewardZetaK           = SmartContract + StorageSc + BlockReward + "zeta.k"
 	StorageBlockRewardZetaMu          = SmartContract + StorageSc + BlockReward + "zeta.mu"
 
+	StorageAutoRenewTriggerPeriod = SmartContract + StorageSc + "auto_renew.trigger_period"
+	StorageAutoRenewWindow        = SmartContract + StorageSc + "auto_renew.window"
+	StorageClasses                = SmartContract + StorageSc + "storage_classes."
+
 	VestingPoolOwner            = SmartContract + VestingSc + "owner_id"
 	VestingMinLock              = SmartContract + VestingSc + "min_lock"
 	VestingMaxDestinations      = SmartContract + VestingSc + "max_destinations"
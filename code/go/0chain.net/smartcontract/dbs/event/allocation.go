@@ -28,6 +28,7 @@ type Allocation struct {
 	ReadPriceMax             currency.Coin `json:"read_price_max"`
 	WritePriceMin            currency.Coin `json:"write_price_min"`
 	WritePriceMax            currency.Coin `json:"write_price_max"`
+	StorageClass             string        `json:"storage_class"`
 	StartTime                int64         `json:"start_time"`
 	Finalized                bool          `json:"finalized"`
 	Cancelled                bool          `json:"cancelled"`
@@ -47,12 +48,28 @@ type Allocation struct {
 	ThirdPartyExtendable     bool          `json:"third_party_extendable"`
 	FileOptions              uint16        `json:"file_options"`
 	MinLockDemand            float64       `json:"min_lock_demand"`
+	AutoRepair               bool          `json:"auto_repair"`
+	Degraded                 bool          `json:"degraded" gorm:"index:idx_adegraded"`
+	AutoRenew                bool          `json:"auto_renew"`
+	RenewalSource            string        `json:"renewal_source"`
+	RenewalPool              currency.Coin `json:"renewal_pool"`
 
 	//ref
 	User  User                    `gorm:"foreignKey:Owner;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Terms []AllocationBlobberTerm `json:"terms" gorm:"foreignKey:AllocationID;references:ID"`
 }
 
+// AllocationRenewal is the data of the TagAllocationRenewed and the
+// TagAllocationRenewalFailed events.
+type AllocationRenewal struct {
+	AllocationID string        `json:"allocation_id"`
+	Owner        string        `json:"owner"`
+	Source       string        `json:"source"`
+	Price        currency.Coin `json:"price"`
+	Expiration   int64         `json:"expiration"`
+	Reason       string        `json:"reason,omitempty"`
+}
+
 func (edb *EventDb) GetAllocation(id string) (*Allocation, error) {
 	var alloc Allocation
 	err := edb.Store.Get().Preload("Terms").Model(&Allocation{}).Where("allocation_id = ?", id).First(&alloc).Error
@@ -96,6 +113,53 @@ func (edb *EventDb) GetClientsAllocation(clientID string, limit common.Paginatio
 	return allocs, nil
 }
 
+// GetDegradedAllocations returns the active allocations using killed or shut down
+// blobbers, of all the clients if the client is empty. The allocations are marked
+// as degraded in the state lazily, so the allocations terms are joined with the
+// killed and shut down blobbers as well.
+func (edb *EventDb) GetDegradedAllocations(clientID string, limit common.Pagination) ([]Allocation, error) {
+	allocs := make([]Allocation, 0)
+
+	degradedTerms := edb.Store.Get().
+		Model(&AllocationBlobberTerm{}).
+		Select("allocation_blobber_terms.alloc_id").
+		Joins("JOIN blobbers ON blobbers.id = allocation_blobber_terms.blobber_id").
+		Where("blobbers.is_killed = ? OR blobbers.is_shutdown = ?", true, true)
+
+	query := edb.Store.Get().
+		Preload("Terms").
+		Model(&Allocation{}).
+		Where("finalized = ? AND cancelled = ?", false, false).
+		Where("degraded = ? OR id IN (?)", true, degradedTerms)
+	if clientID != "" {
+		query = query.Where("owner = ?", clientID)
+	}
+
+	err := query.
+		Limit(limit.Limit).
+		Offset(limit.Offset).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "start_time"},
+			Desc:   limit.IsDescending,
+		}).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "allocation_id"},
+			Desc:   limit.IsDescending,
+		}).
+		Find(&allocs).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving degraded allocations, error: %v", err)
+	}
+
+	for i := range allocs {
+		allocs[i].Degraded = true
+		if len(allocs[i].Terms) > 0 {
+			sort.Sort(ByIndex(allocs[i].Terms))
+		}
+	}
+	return allocs, nil
+}
+
 func (edb *EventDb) GetActiveAllocationsCount() (int64, error) {
 	var count int64
 	result := edb.Store.Get().Model(&Allocation{}).Where("finalized = ? AND cancelled = ?", false, false).Count(&count)
@@ -143,6 +207,11 @@ func (edb *EventDb) updateAllocations(allocs []Allocation) error {
 		"third_party_extendable",
 		"file_options",
 		"min_lock_demand",
+		"auto_repair",
+		"degraded",
+		"auto_renew",
+		"renewal_source",
+		"renewal_pool",
 	}
 
 	columns, err := Columnize(allocs)
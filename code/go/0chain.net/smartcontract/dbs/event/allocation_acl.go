@@ -0,0 +1,46 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+)
+
+// swagger:model allocationACL
+type AllocationACL struct {
+	model.UpdatableModel
+	AllocationID string `json:"allocation_id" gorm:"uniqueIndex:idx_allocation_acl,priority:1; not null"`
+	ClientID     string `json:"client_id" gorm:"uniqueIndex:idx_allocation_acl,priority:2; not null"`
+	Role         string `json:"role"`
+	Expiration   int64  `json:"expiration"`
+}
+
+// GetAllocationACL returns the collaborators of the allocation, or the given
+// collaborator only if the clientID is not empty
+func (edb *EventDb) GetAllocationACL(allocationID, clientID string, limit common2.Pagination) ([]AllocationACL, error) {
+	var acl []AllocationACL
+	db := edb.Store.Get().Model(&AllocationACL{}).Where("allocation_id = ?", allocationID)
+	if clientID != "" {
+		db = db.Where("client_id = ?", clientID)
+	}
+	err := db.Offset(limit.Offset).
+		Limit(limit.Limit).
+		Order("client_id").
+		Find(&acl).Error
+	return acl, err
+}
+
+// replaceAllocationACL overwrites the collaborators of the allocation with the
+// given ones, the storage SC always emits the whole access list
+func (edb *EventDb) replaceAllocationACL(allocationID string, acl []AllocationACL) error {
+	db := edb.Store.Get()
+	if err := db.Where("allocation_id = ?", allocationID).Delete(&AllocationACL{}).Error; err != nil {
+		return err
+	}
+	if len(acl) == 0 {
+		return nil
+	}
+	for i := range acl {
+		acl[i].AllocationID = allocationID
+	}
+	return db.Create(&acl).Error
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"testing"
+
+	common2 "0chain.net/smartcontract/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationACL(t *testing.T) {
+	edb, clean := GetTestEventDB(t)
+	defer clean()
+
+	limit := common2.Pagination{Limit: 10}
+	updateACL := func(allocID string, acl []AllocationACL) {
+		events := []Event{
+			{
+				BlockNumber: 3,
+				TxHash:      "tx",
+				Type:        TypeStats,
+				Tag:         TagUpdateAllocationACL,
+				Index:       allocID,
+				Data:        acl,
+			},
+		}
+		merged, err := mergeEvents(3, "three", events)
+		require.NoError(t, err)
+		require.Len(t, merged, 1)
+		require.NoError(t, edb.addStat(merged[0]))
+	}
+
+	updateACL("alloc1", []AllocationACL{
+		{ClientID: "client1", Role: "reader"},
+		{ClientID: "client2", Role: "writer", Expiration: 100},
+	})
+	updateACL("alloc2", []AllocationACL{
+		{ClientID: "client1", Role: "admin"},
+	})
+
+	acl, err := edb.GetAllocationACL("alloc1", "", limit)
+	require.NoError(t, err)
+	require.Len(t, acl, 2)
+	require.Equal(t, "alloc1", acl[0].AllocationID)
+	require.Equal(t, "client1", acl[0].ClientID)
+	require.Equal(t, "reader", acl[0].Role)
+	require.EqualValues(t, 100, acl[1].Expiration)
+
+	acl, err = edb.GetAllocationACL("alloc2", "client1", limit)
+	require.NoError(t, err)
+	require.Len(t, acl, 1)
+	require.Equal(t, "admin", acl[0].Role)
+
+	// the whole access list is overwritten
+	updateACL("alloc1", []AllocationACL{
+		{ClientID: "client2", Role: "admin"},
+	})
+	acl, err = edb.GetAllocationACL("alloc1", "", limit)
+	require.NoError(t, err)
+	require.Len(t, acl, 1)
+	require.Equal(t, "client2", acl[0].ClientID)
+	require.Equal(t, "admin", acl[0].Role)
+
+	updateACL("alloc1", []AllocationACL{})
+	acl, err = edb.GetAllocationACL("alloc1", "", limit)
+	require.NoError(t, err)
+	require.Empty(t, acl)
+}
@@ -224,3 +224,7 @@ func mergeAuthorizerBurnEvents() *eventsMergerImpl[state.Burn] {
 func mergeAddBridgeMintEvents() *eventsMergerImpl[BridgeMint] {
 	return newEventsMerger[BridgeMint](TagAddBridgeMint, withUniqueEventOverwrite())
 }
+
+func mergeCancelBridgeMintEvents() *eventsMergerImpl[BridgeMint] {
+	return newEventsMerger[BridgeMint](TagCancelBridgeMint, withUniqueEventOverwrite())
+}
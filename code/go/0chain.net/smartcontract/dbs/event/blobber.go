@@ -30,6 +30,8 @@ type Blobber struct {
 	ReadPrice  currency.Coin `json:"read_price"`
 	WritePrice currency.Coin `json:"write_price"`
 
+	StorageClass string `json:"storage_class"`
+
 	Capacity     int64 `json:"capacity"`   // total blobber capacity
 	Allocated    int64 `json:"allocated"`  // allocated capacity
 	Used         int64 `json:"used"`       // total of files saved on blobber
@@ -238,6 +240,7 @@ type AllocationQuery struct {
 	AllocationSize     int64
 	AllocationSizeInGB float64
 	NumberOfDataShards int
+	StorageClass       string
 }
 
 func (edb *EventDb) GetBlobberIdsFromUrls(urls []string, data common2.Pagination) ([]string, error) {
@@ -263,6 +266,7 @@ func (edb *EventDb) GetBlobbersFromParams(allocation AllocationQuery, limit comm
 	dbStore = dbStore.Where("is_killed = false")
 	dbStore = dbStore.Where("is_shutdown = false")
 	dbStore = dbStore.Where("not_available = false")
+	dbStore = dbStore.Where("storage_class = ?", allocation.StorageClass)
 	dbStore = dbStore.Limit(limit.Limit).
 		Offset(limit.Offset).
 		Order(clause.OrderByColumn{
@@ -290,6 +294,7 @@ func (edb *EventDb) updateBlobber(blobbers []Blobber) error {
 		"longitude",
 		"read_price",
 		"write_price",
+		"storage_class",
 		"min_lock_demand",
 		"max_offer_duration",
 		"capacity",
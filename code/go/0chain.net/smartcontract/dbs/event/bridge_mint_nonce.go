@@ -0,0 +1,94 @@
+package event
+
+import (
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BridgeMintNonce is the latest mint nonce of a user from a chain other than
+// the default chain of the bridge, the nonces of the default chain are kept
+// by the users
+type BridgeMintNonce struct {
+	model.UpdatableModel
+	UserID    string `json:"user_id" gorm:"uniqueIndex:idx_bridge_mint_nonce,priority:1; not null"`
+	ChainID   string `json:"chain_id" gorm:"uniqueIndex:idx_bridge_mint_nonce,priority:2; not null"`
+	MintNonce int64  `json:"mint_nonce"`
+}
+
+func (edb *EventDb) GetBridgeMintNonce(userID, chainID string) (*BridgeMintNonce, error) {
+	var nonce BridgeMintNonce
+	err := edb.Store.Get().Model(&BridgeMintNonce{}).
+		Where("user_id = ? AND chain_id = ?", userID, chainID).
+		First(&nonce).Error
+
+	if err != nil && err == gorm.ErrRecordNotFound {
+		return nil, util.ErrValueNotPresent
+	}
+
+	return &nonce, err
+}
+
+// update or create mint nonces, a nonce never goes back since a delayed mint
+// is released after the mints of later nonces
+func (edb *EventDb) updateBridgeMintNonces(nonces []BridgeMintNonce) error {
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "chain_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"mint_nonce": gorm.Expr("GREATEST(bridge_mint_nonces.mint_nonce, excluded.mint_nonce)"),
+		}),
+	}).Create(&nonces).Error
+}
+
+// updateMintNonces records the nonces of the mints, the nonces of the default
+// chain are kept by the users
+func (edb *EventDb) updateMintNonces(bms []BridgeMint) error {
+	var (
+		users  []User
+		nonces []BridgeMintNonce
+		// index of the nonce of the user and chain, a single upsert can't
+		// update a row twice
+		seen = make(map[[2]string]int, len(bms))
+	)
+	for _, bm := range bms {
+		key := [2]string{bm.UserID, bm.ChainID}
+		if i, ok := seen[key]; ok {
+			if bm.ChainID == "" && users[i].MintNonce < bm.MintNonce {
+				users[i].MintNonce = bm.MintNonce
+			}
+			if bm.ChainID != "" && nonces[i].MintNonce < bm.MintNonce {
+				nonces[i].MintNonce = bm.MintNonce
+			}
+			continue
+		}
+
+		if bm.ChainID == "" {
+			seen[key] = len(users)
+			users = append(users, User{
+				UserID:    bm.UserID,
+				MintNonce: bm.MintNonce,
+			})
+		} else {
+			seen[key] = len(nonces)
+			nonces = append(nonces, BridgeMintNonce{
+				UserID:    bm.UserID,
+				ChainID:   bm.ChainID,
+				MintNonce: bm.MintNonce,
+			})
+		}
+	}
+
+	if len(users) > 0 {
+		if err := edb.updateUserMintNonce(users); err != nil {
+			return err
+		}
+	}
+
+	if len(nonces) > 0 {
+		if err := edb.updateBridgeMintNonces(nonces); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,15 +15,19 @@ import (
 type BurnTicket struct {
 	model.UpdatableModel
 	EthereumAddress string        `json:"ethereum_address" gorm:"not null"`
+	ChainID         string        `json:"chain_id" gorm:"not null;default:''"`
 	Hash            string        `json:"hash" gorm:"unique"`
 	Amount          currency.Coin `json:"amount" gorm:"not null"`
 	Nonce           int64         `json:"nonce" gorm:"not null"`
 }
 
-func (edb *EventDb) GetBurnTickets(ethereumAddress string) ([]BurnTicket, error) {
+// GetBurnTickets of the ethereum address to the chain, the empty chain id is
+// the default chain of the bridge
+func (edb *EventDb) GetBurnTickets(ethereumAddress, chainID string) ([]BurnTicket, error) {
 	var burnTickets []BurnTicket
 	err := edb.Store.Get().Model(&BurnTicket{}).
-		Where(&BurnTicket{EthereumAddress: ethereumAddress}).Find(&burnTickets).Error
+		Where("ethereum_address = ? AND chain_id = ?", ethereumAddress, chainID).
+		Find(&burnTickets).Error
 
 	if err != nil && err == gorm.ErrRecordNotFound {
 		return nil, util.ErrValueNotPresent
@@ -41,6 +45,8 @@ func (edb *EventDb) addBurnTicket(burnTicket BurnTicket) error {
 	result := edb.Store.Get().Model(&BurnTicket{}).
 		Where("ethereum_address = ?",
 			burnTicket.EthereumAddress).
+		Where("chain_id = ?",
+			burnTicket.ChainID).
 		Where("nonce = ?",
 			burnTicket.Nonce).
 		FirstOrCreate(&burnTicket)
@@ -50,7 +56,7 @@ func (edb *EventDb) addBurnTicket(burnTicket BurnTicket) error {
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("burn ticket with the given ethereum address and nonce already exists")
+		return errors.New("burn ticket with the given ethereum address, chain and nonce already exists")
 	}
 	return nil
 }
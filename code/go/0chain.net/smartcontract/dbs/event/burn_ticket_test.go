@@ -53,7 +53,7 @@ func TestBurnTicketEvent(t *testing.T) {
 	eventDb.Get().Table("burn_tickets").Count(&count)
 	require.Equal(t, int64(1), count, "BurnTicket not getting inserted")
 
-	burnTickets, err := eventDb.GetBurnTickets(ethereumAddress)
+	burnTickets, err := eventDb.GetBurnTickets(ethereumAddress, "")
 	require.NoError(t, err, "Error while fetching burn tickets by ethereumAddress")
 	require.Len(t, burnTickets, 1)
 
@@ -86,7 +86,7 @@ func TestBurnTicketEvent(t *testing.T) {
 	eventDb.Get().Table("burn_tickets").Count(&count)
 	require.Equal(t, int64(2), count, "BurnTicket not getting inserted")
 
-	burnTickets, err = eventDb.GetBurnTickets(ethereumAddress)
+	burnTickets, err = eventDb.GetBurnTickets(ethereumAddress, "")
 	require.NoError(t, err, "Error while fetching burn tickets by ethereumAddress")
 	require.Len(t, burnTickets, 2)
 
@@ -106,4 +106,19 @@ func TestBurnTicketEvent(t *testing.T) {
 
 	eventDb.Get().Table("burn_tickets").Count(&count)
 	require.Equal(t, int64(2), count, "BurnTicket gets inserted")
+
+	burnTicket5 := BurnTicket{
+		EthereumAddress: ethereumAddress,
+		ChainID:         "137",
+		Hash:            hash + hash + hash,
+		Nonce:           nonce + 1,
+	}
+
+	err = eventDb.addBurnTicket(burnTicket5)
+	require.NoError(t, err, "Error while inserting BurnTicket of another chain to event Database")
+
+	burnTickets, err = eventDb.GetBurnTickets(ethereumAddress, "137")
+	require.NoError(t, err, "Error while fetching burn tickets by ethereumAddress and chain")
+	require.Len(t, burnTickets, 1)
+	require.Equal(t, "137", burnTickets[0].ChainID, "Fetched invalid BurnTicket")
 }
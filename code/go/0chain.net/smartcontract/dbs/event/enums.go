@@ -1,5 +1,10 @@
 package event
 
+import (
+	"fmt"
+	"strconv"
+)
+
 type (
 	EventType int
 	EventTag  int
@@ -111,6 +116,10 @@ const (
 	TagShutdownProvider
 	TagInsertReadpool
 	TagUpdateReadpool
+	TagAllocationRenewed
+	TagAllocationRenewalFailed
+	TagUpdateAllocationACL
+	TagCancelBridgeMint
 	NumberOfTags
 )
 
@@ -205,6 +214,10 @@ func initTagString() {
 	TagString[TagShutdownProvider] = "TagShutdownProvider"
 	TagString[TagInsertReadpool] = "TagInsertReadpool"
 	TagString[TagUpdateReadpool] = "TagUpdateReadpool"
+	TagString[TagAllocationRenewed] = "TagAllocationRenewed"
+	TagString[TagAllocationRenewalFailed] = "TagAllocationRenewalFailed"
+	TagString[TagUpdateAllocationACL] = "TagUpdateAllocationACL"
+	TagString[TagCancelBridgeMint] = "TagCancelBridgeMint"
 	TagString[NumberOfTags] = "invalid"
 }
 
@@ -218,3 +231,35 @@ func (tag EventTag) String() string {
 func (tag EventTag) Int() int {
 	return int(tag)
 }
+
+// ParseEventType parses the event type from its name or number
+func ParseEventType(s string) (EventType, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n >= NumberOfTypes.Int() {
+			return TypeNone, fmt.Errorf("unknown event type: %s", s)
+		}
+		return EventType(n), nil
+	}
+	for i, name := range TypeString[:NumberOfTypes] {
+		if name == s {
+			return EventType(i), nil
+		}
+	}
+	return TypeNone, fmt.Errorf("unknown event type: %s", s)
+}
+
+// ParseEventTag parses the event tag from its name or number
+func ParseEventTag(s string) (EventTag, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || n >= NumberOfTags.Int() {
+			return TagNone, fmt.Errorf("unknown event tag: %s", s)
+		}
+		return EventTag(n), nil
+	}
+	for i, name := range TagString[:NumberOfTags] {
+		if name == s {
+			return EventTag(i), nil
+		}
+	}
+	return TagNone, fmt.Errorf("unknown event tag: %s", s)
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,3 +16,29 @@ func TestEventTags(t *testing.T) {
 	initTagString()
 	require.Len(t, TagString, NumberOfTags.Int()+1)
 }
+
+func TestParseEventTag(t *testing.T) {
+	initTagString()
+	tag, err := ParseEventTag("TagAddBlobber")
+	require.NoError(t, err)
+	require.Equal(t, TagAddBlobber, tag)
+
+	tag, err = ParseEventTag(strconv.Itoa(TagAddAllocation.Int()))
+	require.NoError(t, err)
+	require.Equal(t, TagAddAllocation, tag)
+
+	_, err = ParseEventTag("TagUnknown")
+	require.Error(t, err)
+	_, err = ParseEventTag(strconv.Itoa(NumberOfTags.Int()))
+	require.Error(t, err)
+}
+
+func TestParseEventType(t *testing.T) {
+	initTypeString()
+	typ, err := ParseEventType("stats")
+	require.NoError(t, err)
+	require.Equal(t, TypeStats, typ)
+
+	_, err = ParseEventType("-1")
+	require.Error(t, err)
+}
@@ -40,6 +40,7 @@ func NewEventDbWithoutWorker(config config.DbAccess, settings config.DbSettings)
 		dbConfig:      config,
 		eventsChannel: make(chan BlockEvents, 1),
 		settings:      settings,
+		broker:        newEventsBroker(),
 	}
 
 	return eventDb, nil
@@ -55,6 +56,7 @@ func NewInMemoryEventDb(config config.DbAccess, settings config.DbSettings) (*Ev
 		dbConfig:      config,
 		eventsChannel: make(chan BlockEvents, 1),
 		settings:      settings,
+		broker:        newEventsBroker(),
 	}
 	go eventDb.addEventsWorker(common.GetRootContext())
 	if err := eventDb.AutoMigrate(); err != nil {
@@ -68,6 +70,8 @@ type EventDb struct {
 	dbConfig      config.DbAccess   // depends on the sharder, change on restart
 	settings      config.DbSettings // the same across all sharders, needs to mirror blockchain
 	eventsChannel chan BlockEvents
+	broker        *eventsBroker    // shared by the transactions to notify the events subscribers
+	committed     *CommittedEvents // events to notify the subscribers about once the transaction is committed
 }
 
 func (edb *EventDb) Begin(ctx context.Context) (*EventDb, error) {
@@ -83,6 +87,7 @@ func (edb *EventDb) Begin(ctx context.Context) (*EventDb, error) {
 		},
 		dbConfig: edb.dbConfig,
 		settings: edb.settings,
+		broker:   edb.broker,
 	}
 	return &edbTx, nil
 }
@@ -91,7 +96,11 @@ func (edb *EventDb) Commit() error {
 	if edb.Store.Get() == nil {
 		return errors.New("committing nil transaction")
 	}
-	return edb.Store.Get().Commit().Error
+	if err := edb.Store.Get().Commit().Error; err != nil {
+		return err
+	}
+	edb.publishCommitted()
+	return nil
 }
 
 func (edb *EventDb) Rollback() error {
@@ -196,6 +205,9 @@ func (edb *EventDb) AutoMigrate() error {
 		&RewardDelegate{},
 		&RewardProvider{},
 		&ReadPool{},
+		&AllocationACL{},
+		&BridgeMintNonce{},
+		&MissingEvents{},
 	); err != nil {
 		return err
 	}
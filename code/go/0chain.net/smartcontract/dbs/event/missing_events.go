@@ -0,0 +1,31 @@
+package event
+
+import "0chain.net/smartcontract/dbs/model"
+
+// MissingEvents is a range of rounds which smart contract events are not in the
+// event DB. A sharder restoring the blocks and the state of these rounds from the
+// other sharders or from an archive doesn't get their events, so the tables built
+// from the events miss their data until the event DB is rebuilt, for example
+// restored from a dump of the event DB of another sharder.
+type MissingEvents struct {
+	model.UpdatableModel
+	FromRound int64  `json:"from_round"`
+	ToRound   int64  `json:"to_round"`
+	Source    string `json:"source"`
+}
+
+// AddMissingEvents records that the events of the rounds are missing
+func (edb *EventDb) AddMissingEvents(fromRound, toRound int64, source string) error {
+	return edb.Store.Get().Create(&MissingEvents{
+		FromRound: fromRound,
+		ToRound:   toRound,
+		Source:    source,
+	}).Error
+}
+
+// GetMissingEvents returns the ranges of the rounds which events are missing
+func (edb *EventDb) GetMissingEvents() ([]MissingEvents, error) {
+	var missing []MissingEvents
+	err := edb.Store.Get().Model(&MissingEvents{}).Order("from_round").Find(&missing).Error
+	return missing, err
+}
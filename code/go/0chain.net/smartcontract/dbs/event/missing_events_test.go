@@ -0,0 +1,29 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingEvents(t *testing.T) {
+	edb, clean := GetTestEventDB(t)
+	defer clean()
+
+	missing, err := edb.GetMissingEvents()
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	require.NoError(t, edb.AddMissingEvents(101, 200, "fast_sync"))
+	require.NoError(t, edb.AddMissingEvents(1, 100, "archive"))
+
+	missing, err = edb.GetMissingEvents()
+	require.NoError(t, err)
+	require.Len(t, missing, 2)
+	require.Equal(t, int64(1), missing[0].FromRound)
+	require.Equal(t, int64(100), missing[0].ToRound)
+	require.Equal(t, "archive", missing[0].Source)
+	require.Equal(t, int64(101), missing[1].FromRound)
+	require.Equal(t, int64(200), missing[1].ToRound)
+	require.Equal(t, "fast_sync", missing[1].Source)
+}
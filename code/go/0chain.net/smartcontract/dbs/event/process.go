@@ -106,6 +106,8 @@ func (edb *EventDb) ProcessEvents(
 			return nil, err
 		}
 
+		tx.committed = &CommittedEvents{Round: round, Block: block, Events: es}
+
 		var opt ProcessEventsOptions
 		for _, f := range opts {
 			f(&opt)
@@ -225,6 +227,7 @@ func mergeEvents(round int64, block string, events []Event) ([]Event, error) {
 			mergeUpdateUserPayedFeesEvents(),
 			mergeAuthorizerBurnEvents(),
 			mergeAddBridgeMintEvents(),
+			mergeCancelBridgeMintEvents(),
 		}
 
 		others = make([]Event, 0, len(events))
@@ -923,6 +926,12 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			return ErrInvalidEventData
 		}
 		return edb.updateReadPool(*rps)
+	case TagUpdateAllocationACL:
+		acl, ok := fromEvent[[]AllocationACL](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.replaceAllocationACL(event.Index, *acl)
 	case TagCollectProviderReward:
 		return edb.collectRewards(event.Index)
 	case TagMinerHealthCheck:
@@ -977,14 +986,8 @@ func (edb *EventDb) addStat(event Event) (err error) {
 		if !ok {
 			return ErrInvalidEventData
 		}
-		users := make([]User, 0, len(*bms))
 		authMint := make(map[string]currency.Coin)
 		for _, bm := range *bms {
-			users = append(users, User{
-				UserID:    bm.UserID,
-				MintNonce: bm.MintNonce,
-			})
-
 			for _, sig := range bm.Signers {
 				mv, ok := authMint[sig]
 				if !ok {
@@ -1002,16 +1005,22 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			})
 		}
 
-		err := edb.updateUserMintNonce(users)
-		if err != nil {
+		if err := edb.updateMintNonces(*bms); err != nil {
 			return err
 		}
 
-		err = edb.updateAuthorizersTotalMint(mints)
+		err := edb.updateAuthorizersTotalMint(mints)
 		if err != nil {
 			return err
 		}
 		return nil
+	case TagCancelBridgeMint:
+		// the nonce of a cancelled mint stays used
+		bms, ok := fromEvent[[]BridgeMint](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.updateMintNonces(*bms)
 
 	case TagShutdownProvider:
 		u, ok := fromEvent[[]dbs.ProviderID](event.Data)
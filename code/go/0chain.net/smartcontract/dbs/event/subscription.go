@@ -0,0 +1,258 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"0chain.net/smartcontract/common"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// subscriptionBuffer is the number of blocks a subscriber could fall behind
+	// before the subscription is dropped
+	subscriptionBuffer = 64
+	// streamHistorySize is the number of recently committed blocks kept in memory,
+	// so that subscribers could resume without querying the events table
+	streamHistorySize = 256
+)
+
+var (
+	ErrSubscriptionsDisabled = errors.New("events subscriptions are not enabled")
+	ErrSubscriptionLagged    = errors.New("subscription dropped, subscriber is too slow")
+)
+
+// EventFilter selects the events delivered to a subscription,
+// empty fields match any event.
+type EventFilter struct {
+	Tags   []EventTag
+	Types  []EventType
+	Index  string
+	TxHash string
+}
+
+// Match checks whether the event satisfies the filter
+func (f EventFilter) Match(e Event) bool {
+	if len(f.Tags) > 0 && !containsTag(f.Tags, e.Tag) {
+		return false
+	}
+	if len(f.Types) > 0 && !containsType(f.Types, e.Type) {
+		return false
+	}
+	if f.Index != "" && f.Index != e.Index {
+		return false
+	}
+	if f.TxHash != "" && f.TxHash != e.TxHash {
+		return false
+	}
+	return true
+}
+
+func (f EventFilter) filter(events []Event) []Event {
+	matched := make([]Event, 0, len(events))
+	for _, e := range events {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+func containsTag(tags []EventTag, tag EventTag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(types []EventType, typ EventType) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// CommittedEvents is the list of events committed to the events database for a block
+type CommittedEvents struct {
+	Round  int64   `json:"round"`
+	Block  string  `json:"block"`
+	Events []Event `json:"events"`
+}
+
+// Subscription receives the events committed for each block that match its filter
+type Subscription struct {
+	id     uint64
+	filter EventFilter
+	c      chan CommittedEvents
+	err    error
+	broker *eventsBroker
+}
+
+// Events returns the channel of the committed events, it's closed once the
+// subscription is cancelled or dropped.
+func (s *Subscription) Events() <-chan CommittedEvents {
+	return s.c
+}
+
+// Err returns the reason the subscription was dropped, if any
+func (s *Subscription) Err() error {
+	s.broker.mutex.RLock()
+	defer s.broker.mutex.RUnlock()
+	return s.err
+}
+
+// Cancel stops the subscription
+func (s *Subscription) Cancel() {
+	s.broker.unsubscribe(s.id, nil)
+}
+
+type eventsBroker struct {
+	mutex   sync.RWMutex
+	nextID  uint64
+	subs    map[uint64]*Subscription
+	history []CommittedEvents
+}
+
+func newEventsBroker() *eventsBroker {
+	return &eventsBroker{
+		subs: make(map[uint64]*Subscription),
+	}
+}
+
+func (b *eventsBroker) unsubscribe(id uint64, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.unsubscribeLocked(id, err)
+}
+
+func (b *eventsBroker) unsubscribeLocked(id uint64, err error) {
+	s, ok := b.subs[id]
+	if !ok {
+		return
+	}
+	delete(b.subs, id)
+	s.err = err
+	close(s.c)
+}
+
+func (b *eventsBroker) publish(ce CommittedEvents) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.history = append(b.history, ce)
+	if len(b.history) > streamHistorySize {
+		b.history = b.history[len(b.history)-streamHistorySize:]
+	}
+
+	for id, s := range b.subs {
+		events := s.filter.filter(ce.Events)
+		if len(events) == 0 {
+			continue
+		}
+
+		select {
+		case s.c <- CommittedEvents{Round: ce.Round, Block: ce.Block, Events: events}:
+		default:
+			// do not block the events processing for slow subscribers, they
+			// could resume from the last received round
+			b.unsubscribeLocked(id, ErrSubscriptionLagged)
+		}
+	}
+}
+
+// subscribeFrom subscribes to the committed events and returns the matched events of the kept
+// blocks from the given round on, and the first round kept, or -1 if none is kept yet.
+// Both are done under the same lock, so that no block is missed or repeated in between.
+func (b *eventsBroker) subscribeFrom(filter EventFilter, fromRound int64) (*Subscription, []CommittedEvents, int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.nextID++
+	s := &Subscription{
+		id:     b.nextID,
+		filter: filter,
+		c:      make(chan CommittedEvents, subscriptionBuffer),
+		broker: b,
+	}
+	b.subs[s.id] = s
+
+	if len(b.history) == 0 {
+		return s, nil, -1
+	}
+
+	var recent []CommittedEvents
+	for _, ce := range b.history {
+		if ce.Round < fromRound {
+			continue
+		}
+		if events := filter.filter(ce.Events); len(events) > 0 {
+			recent = append(recent, CommittedEvents{Round: ce.Round, Block: ce.Block, Events: events})
+		}
+	}
+	return s, recent, b.history[0].Round
+}
+
+// Subscribe subscribes to the events matching the filter that are committed from now on.
+// The matched events of the recently committed blocks from the fromRound on are returned
+// along with the first round kept in memory (-1 if none), the events of the prior rounds
+// could be read from the events table using FindCommittedEvents.
+func (edb *EventDb) Subscribe(filter EventFilter, fromRound int64) (*Subscription, []CommittedEvents, int64, error) {
+	if edb.broker == nil {
+		return nil, nil, 0, ErrSubscriptionsDisabled
+	}
+	sub, recent, historyStart := edb.broker.subscribeFrom(filter, fromRound)
+	return sub, recent, historyStart, nil
+}
+
+// FindCommittedEvents returns the events matching the filter in the given rounds range
+// from the events table grouped by rounds. The events data is not kept in the table.
+func (edb *EventDb) FindCommittedEvents(ctx context.Context, filter EventFilter,
+	fromRound, toRound int64, p common.Pagination) ([]CommittedEvents, error) {
+	db := edb.Store.Get().WithContext(ctx).
+		Where("block_number >= ? AND block_number <= ?", fromRound, toRound)
+	if len(filter.Tags) > 0 {
+		db = db.Where("tag IN ?", filter.Tags)
+	}
+	if len(filter.Types) > 0 {
+		db = db.Where("type IN ?", filter.Types)
+	}
+	if filter.Index != "" {
+		db = db.Where("index = ?", filter.Index)
+	}
+	if filter.TxHash != "" {
+		db = db.Where("tx_hash = ?", filter.TxHash)
+	}
+
+	var events []Event
+	err := db.Order(clause.OrderByColumn{Column: clause.Column{Name: "block_number"}}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}}).
+		Offset(p.Offset).
+		Limit(p.Limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var ces []CommittedEvents
+	for _, e := range events {
+		if len(ces) == 0 || ces[len(ces)-1].Round != e.BlockNumber {
+			ces = append(ces, CommittedEvents{Round: e.BlockNumber})
+		}
+		ces[len(ces)-1].Events = append(ces[len(ces)-1].Events, e)
+	}
+	return ces, nil
+}
+
+// publishCommitted notifies the subscribers about the events of the committed block
+func (edb *EventDb) publishCommitted() {
+	if edb.broker == nil || edb.committed == nil {
+		return
+	}
+	edb.broker.publish(*edb.committed)
+	edb.committed = nil
+}
@@ -0,0 +1,94 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFilterMatch(t *testing.T) {
+	e := Event{Type: TypeStats, Tag: TagAddAllocation, Index: "alloc", TxHash: "txn"}
+
+	require.True(t, EventFilter{}.Match(e))
+	require.True(t, EventFilter{Tags: []EventTag{TagAddBlobber, TagAddAllocation}}.Match(e))
+	require.True(t, EventFilter{Types: []EventType{TypeStats}, Index: "alloc", TxHash: "txn"}.Match(e))
+	require.False(t, EventFilter{Tags: []EventTag{TagAddBlobber}}.Match(e))
+	require.False(t, EventFilter{Types: []EventType{TypeError}}.Match(e))
+	require.False(t, EventFilter{Index: "other"}.Match(e))
+	require.False(t, EventFilter{TxHash: "other"}.Match(e))
+}
+
+func TestEventsBrokerPublish(t *testing.T) {
+	b := newEventsBroker()
+	edb := &EventDb{broker: b}
+
+	sub, recent, start, err := edb.Subscribe(EventFilter{Tags: []EventTag{TagAddAllocation}}, 0)
+	require.NoError(t, err)
+	require.Empty(t, recent)
+	require.Equal(t, int64(-1), start)
+
+	b.publish(CommittedEvents{Round: 1, Block: "b1", Events: []Event{
+		{BlockNumber: 1, Tag: TagAddBlobber},
+		{BlockNumber: 1, Tag: TagAddAllocation, Index: "a1"},
+	}})
+	b.publish(CommittedEvents{Round: 2, Block: "b2", Events: []Event{{BlockNumber: 2, Tag: TagAddBlobber}}})
+	b.publish(CommittedEvents{Round: 3, Block: "b3", Events: []Event{{BlockNumber: 3, Tag: TagAddAllocation, Index: "a3"}}})
+
+	ce := <-sub.Events()
+	require.Equal(t, int64(1), ce.Round)
+	require.Equal(t, "b1", ce.Block)
+	require.Len(t, ce.Events, 1)
+	require.Equal(t, "a1", ce.Events[0].Index)
+
+	ce = <-sub.Events()
+	require.Equal(t, int64(3), ce.Round)
+
+	sub.Cancel()
+	_, ok := <-sub.Events()
+	require.False(t, ok)
+	require.NoError(t, sub.Err())
+
+	// resume from the kept history
+	sub, recent, start, err = edb.Subscribe(EventFilter{Tags: []EventTag{TagAddAllocation}}, 2)
+	require.NoError(t, err)
+	defer sub.Cancel()
+	require.Equal(t, int64(1), start)
+	require.Len(t, recent, 1)
+	require.Equal(t, int64(3), recent[0].Round)
+}
+
+func TestEventsBrokerDropsLaggedSubscriber(t *testing.T) {
+	b := newEventsBroker()
+	edb := &EventDb{broker: b}
+
+	sub, _, _, err := edb.Subscribe(EventFilter{}, 0)
+	require.NoError(t, err)
+
+	for i := 0; i <= subscriptionBuffer; i++ {
+		b.publish(CommittedEvents{Round: int64(i), Events: []Event{{BlockNumber: int64(i)}}})
+	}
+
+	var n int
+	for range sub.Events() {
+		n++
+	}
+	require.Equal(t, subscriptionBuffer, n)
+	require.ErrorIs(t, sub.Err(), ErrSubscriptionLagged)
+}
+
+func TestEventsBrokerHistorySize(t *testing.T) {
+	b := newEventsBroker()
+	for i := 0; i < streamHistorySize+10; i++ {
+		b.publish(CommittedEvents{Round: int64(i), Events: []Event{{BlockNumber: int64(i)}}})
+	}
+
+	sub, recent, start, _ := (&EventDb{broker: b}).Subscribe(EventFilter{}, 0)
+	defer sub.Cancel()
+	require.Equal(t, int64(10), start)
+	require.Len(t, recent, streamHistorySize)
+}
+
+func TestSubscribeWithoutBroker(t *testing.T) {
+	_, _, _, err := (&EventDb{}).Subscribe(EventFilter{}, 0)
+	require.ErrorIs(t, err, ErrSubscriptionsDisabled)
+}
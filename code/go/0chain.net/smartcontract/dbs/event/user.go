@@ -40,11 +40,13 @@ func (edb *EventDb) addOrUpdateUsers(users []User) error {
 	}).Create(&users).Error
 }
 
-// update or create users
+// update or create users, the mint nonce never goes back
 func (edb *EventDb) updateUserMintNonce(users []User) error {
 	return edb.Store.Get().Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "user_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"mint_nonce"}),
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"mint_nonce": gorm.Expr("GREATEST(users.mint_nonce, excluded.mint_nonce)"),
+		}),
 	}).Create(&users).Error
 }
 
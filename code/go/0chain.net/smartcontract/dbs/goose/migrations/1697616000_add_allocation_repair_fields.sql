@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS auto_repair boolean NOT NULL DEFAULT false;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS degraded boolean NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_adegraded ON allocations USING btree (degraded);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_adegraded;
+ALTER TABLE allocations DROP COLUMN auto_repair;
+ALTER TABLE allocations DROP COLUMN degraded;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS auto_renew boolean NOT NULL DEFAULT false;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS renewal_source text;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS renewal_pool bigint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE allocations DROP COLUMN auto_renew;
+ALTER TABLE allocations DROP COLUMN renewal_source;
+ALTER TABLE allocations DROP COLUMN renewal_pool;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS allocation_acls (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+
+    allocation_id text NOT NULL,
+    client_id text NOT NULL,
+    role text,
+    expiration bigint
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_allocation_acl ON allocation_acls USING btree (allocation_id, client_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE allocation_acls;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE blobbers ADD COLUMN IF NOT EXISTS storage_class text NOT NULL DEFAULT 'hot';
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS storage_class text NOT NULL DEFAULT 'hot';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE blobbers DROP COLUMN storage_class;
+ALTER TABLE allocations DROP COLUMN storage_class;
+-- +goose StatementEnd
@@ -15,6 +15,7 @@ type StorageDtoNode struct {
 	BaseURL                 *string                 `json:"url,omitempty"`
 	Geolocation             *StorageNodeGeolocation `json:"geolocation,omitempty"`
 	Terms                   *Terms                  `json:"terms,omitempty"`
+	StorageClass            *string                 `json:"storage_class,omitempty"`
 	Capacity                *int64                  `json:"capacity,omitempty"`
 	Allocated               *int64                  `json:"allocated,omitempty"`
 	SavedData               *int64                  `json:"saved_data,omitempty"`
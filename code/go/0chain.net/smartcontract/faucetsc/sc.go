@@ -16,6 +16,7 @@ import (
 	"0chain.net/chaincore/state"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
+	"0chain.net/smartcontract/governancesc"
 	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/util"
 	metrics "github.com/rcrowley/go-metrics"
@@ -125,7 +126,7 @@ func (fc *FaucetSmartContract) updateSettings(
 	gn *GlobalNode,
 ) (string, error) {
 	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
-		return gn.FaucetConfig.OwnerId == t.ClientID
+		return gn.FaucetConfig.OwnerId == t.ClientID || t.ClientID == governancesc.ADDRESS
 	}); err != nil {
 		return "", err
 	}
@@ -0,0 +1,382 @@
+package governancesc
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// voting modes
+const (
+	VotingCouncil = "council" // one vote per council member
+	VotingStake   = "stake"   // votes weighted by the stake locked by delegates
+)
+
+type Setting int
+
+const (
+	OwnerId Setting = iota
+	VotingMode
+	Council
+	MinLock
+	MinProposalStake
+	VotingPeriod
+	Timelock
+	ExecutionWindow
+	Quorum
+	Threshold
+	MaxDescriptionLength
+	Cost
+)
+
+var (
+	Settings = []string{
+		"owner_id",
+		"voting_mode",
+		"council",
+		"min_lock",
+		"min_proposal_stake",
+		"voting_period",
+		"timelock",
+		"execution_window",
+		"quorum",
+		"threshold",
+		"max_description_length",
+		"cost",
+	}
+
+	costFunctions = []string{
+		"create_proposal",
+		"vote",
+		"execute_proposal",
+		"cancel_proposal",
+		"lock",
+		"unlock",
+		"update_settings",
+	}
+)
+
+func scConfigKey(scKey string) datastore.Key {
+	return scKey + encryption.Hash("governancesc_config")
+}
+
+// config represents SC configurations ('governancesc:' from sc.yaml)
+type config struct {
+	// OwnerId can update the settings until the governance takes over by
+	// setting an empty owner through a proposal
+	OwnerId    string   `json:"owner_id"`
+	VotingMode string   `json:"voting_mode"`
+	Council    []string `json:"council"`
+	// MinLock is the min stake locked by a delegate at once
+	MinLock currency.Coin `json:"min_lock"`
+	// MinProposalStake is the min stake of a delegate creating a proposal
+	MinProposalStake currency.Coin `json:"min_proposal_stake"`
+	// VotingPeriod is the time a proposal is open for the votes
+	VotingPeriod time.Duration `json:"voting_period"`
+	// Timelock is the delay between the end of the voting and the execution
+	Timelock time.Duration `json:"timelock"`
+	// ExecutionWindow is the time an accepted proposal can be executed for
+	ExecutionWindow time.Duration `json:"execution_window"`
+	// Quorum is the min fraction of the total weight voting on a proposal
+	Quorum float64 `json:"quorum"`
+	// Threshold is the min fraction of the cast weight approving a proposal
+	Threshold            float64        `json:"threshold"`
+	MaxDescriptionLength int            `json:"max_description_length"`
+	Cost                 map[string]int `json:"cost"`
+}
+
+func (c *config) validate() (err error) {
+	switch {
+	case c.VotingMode != VotingCouncil && c.VotingMode != VotingStake:
+		return fmt.Errorf("invalid voting_mode %q", c.VotingMode)
+	case c.VotingMode == VotingCouncil && len(c.Council) == 0:
+		return errors.New("empty council")
+	case toSeconds(c.VotingPeriod) < 1:
+		return errors.New("invalid voting_period (< 1s)")
+	case c.Timelock < 0:
+		return errors.New("negative timelock")
+	case toSeconds(c.ExecutionWindow) < 1:
+		return errors.New("invalid execution_window (< 1s)")
+	case c.Quorum <= 0 || c.Quorum > 1:
+		return errors.New("quorum not in (0; 1] range")
+	case c.Threshold < 0.5 || c.Threshold > 1:
+		return errors.New("threshold not in [0.5; 1] range")
+	case c.MaxDescriptionLength < 1:
+		return errors.New("invalid max_description_length (< 1)")
+	}
+	return
+}
+
+func (c *config) isCouncilMember(clientID string) bool {
+	for _, id := range c.Council {
+		if id == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *config) update(changes *config2.StringMap) error {
+	for key, value := range changes.Fields {
+		switch key {
+		case Settings[OwnerId]:
+			if _, err := hex.DecodeString(value); err != nil {
+				return fmt.Errorf("value %v cannot be converted to int with 16 base, "+
+					"failing to set config key %s", value, key)
+			}
+			c.OwnerId = value
+		case Settings[VotingMode]:
+			c.VotingMode = value
+		case Settings[Council]:
+			c.Council = nil
+			for _, id := range strings.Split(value, ",") {
+				if id = strings.TrimSpace(id); id == "" {
+					continue
+				}
+				if _, err := hex.DecodeString(id); err != nil {
+					return fmt.Errorf("invalid council member %v, "+
+						"failing to set config key %s", id, key)
+				}
+				c.Council = append(c.Council, id)
+			}
+		case Settings[MinLock], Settings[MinProposalStake]:
+			fValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to currency.Coin, "+
+					"failing to set config key %s", value, key)
+			}
+			coin, err := currency.ParseZCN(fValue)
+			if err != nil {
+				return err
+			}
+			if key == Settings[MinLock] {
+				c.MinLock = coin
+			} else {
+				c.MinProposalStake = coin
+			}
+		case Settings[VotingPeriod], Settings[Timelock], Settings[ExecutionWindow]:
+			dValue, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to time.Duration, "+
+					"failing to set config key %s", value, key)
+			}
+			switch key {
+			case Settings[VotingPeriod]:
+				c.VotingPeriod = dValue
+			case Settings[Timelock]:
+				c.Timelock = dValue
+			default:
+				c.ExecutionWindow = dValue
+			}
+		case Settings[Quorum], Settings[Threshold]:
+			fValue, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to float64, "+
+					"failing to set config key %s", value, key)
+			}
+			if key == Settings[Quorum] {
+				c.Quorum = fValue
+			} else {
+				c.Threshold = fValue
+			}
+		case Settings[MaxDescriptionLength]:
+			iValue, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("value %v cannot be converted to int, "+
+					"failing to set config key %s", value, key)
+			}
+			c.MaxDescriptionLength = iValue
+		default:
+			if err := c.setCostValue(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return c.validate()
+}
+
+func (c *config) setCostValue(key, value string) error {
+	if !strings.HasPrefix(key, Settings[Cost]) {
+		return fmt.Errorf("config setting %s not found", key)
+	}
+
+	costKey := strings.ToLower(strings.TrimPrefix(key, Settings[Cost]+"."))
+	for _, costFunction := range costFunctions {
+		if costKey != strings.ToLower(costFunction) {
+			continue
+		}
+		costValue, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("key %s, unable to convert %v to integer", key, value)
+		}
+
+		if costValue < 0 {
+			return fmt.Errorf("cost.%s contains invalid value %s", key, value)
+		}
+
+		if c.Cost == nil {
+			c.Cost = make(map[string]int)
+		}
+		c.Cost[costKey] = costValue
+
+		return nil
+	}
+
+	return fmt.Errorf("cost config setting %s not found", costKey)
+}
+
+func (c *config) getConfigMap() config2.StringMap {
+	fields := map[string]string{
+		Settings[OwnerId]:              c.OwnerId,
+		Settings[VotingMode]:           c.VotingMode,
+		Settings[Council]:              strings.Join(c.Council, ","),
+		Settings[MinLock]:              fmt.Sprintf("%v", float64(c.MinLock)/1e10),
+		Settings[MinProposalStake]:     fmt.Sprintf("%v", float64(c.MinProposalStake)/1e10),
+		Settings[VotingPeriod]:         fmt.Sprintf("%v", c.VotingPeriod),
+		Settings[Timelock]:             fmt.Sprintf("%v", c.Timelock),
+		Settings[ExecutionWindow]:      fmt.Sprintf("%v", c.ExecutionWindow),
+		Settings[Quorum]:               fmt.Sprintf("%v", c.Quorum),
+		Settings[Threshold]:            fmt.Sprintf("%v", c.Threshold),
+		Settings[MaxDescriptionLength]: fmt.Sprintf("%v", c.MaxDescriptionLength),
+	}
+
+	for _, key := range costFunctions {
+		fields[fmt.Sprintf("cost.%s", key)] = fmt.Sprintf("%0v", c.Cost[strings.ToLower(key)])
+	}
+
+	return config2.StringMap{
+		Fields: fields,
+	}
+}
+
+// updateConfig is called by the owner, or by the governance itself executing
+// an accepted proposal
+func (gsc *GovernanceSmartContract) updateConfig(
+	txn *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	var conf *config
+	if conf, err = gsc.getConfig(balances); err != nil {
+		return "", common.NewError("update_settings",
+			"can't get config: "+err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
+		return (conf.OwnerId != "" && conf.OwnerId == txn.ClientID) || txn.ClientID == ADDRESS
+	}); err != nil {
+		return "", err
+	}
+
+	update := &config2.StringMap{}
+	if err = update.Decode(input); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	if err := conf.update(update); err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	if err != nil {
+		return "", common.NewError("update_settings", err.Error())
+	}
+
+	return "", nil
+}
+
+//
+// helpers
+//
+
+func toSeconds(dur time.Duration) common.Timestamp {
+	return common.Timestamp(dur / time.Second)
+}
+
+// configurations from sc.yaml
+func getConfiguredConfig() (conf *config, err error) {
+	const prefix = "smart_contracts.governancesc."
+
+	conf = new(config)
+
+	// short hand
+	var scconf = config2.SmartContractConfig
+	conf.OwnerId = scconf.GetString(prefix + "owner_id")
+	conf.VotingMode = scconf.GetString(prefix + "voting_mode")
+	conf.Council = scconf.GetStringSlice(prefix + "council")
+	conf.MinLock, err = currency.ParseZCN(scconf.GetFloat64(prefix + "min_lock"))
+	if err != nil {
+		return nil, err
+	}
+	conf.MinProposalStake, err = currency.ParseZCN(scconf.GetFloat64(prefix + "min_proposal_stake"))
+	if err != nil {
+		return nil, err
+	}
+	conf.VotingPeriod = scconf.GetDuration(prefix + "voting_period")
+	conf.Timelock = scconf.GetDuration(prefix + "timelock")
+	conf.ExecutionWindow = scconf.GetDuration(prefix + "execution_window")
+	conf.Quorum = scconf.GetFloat64(prefix + "quorum")
+	conf.Threshold = scconf.GetFloat64(prefix + "threshold")
+	conf.MaxDescriptionLength = scconf.GetInt(prefix + "max_description_length")
+	conf.Cost = scconf.GetStringMapInt(prefix + "cost")
+
+	err = conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func getConfigReadOnly(
+	balances chainstate.CommonStateContextI,
+) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(ADDRESS), conf)
+	switch err {
+	case nil:
+		return conf, nil
+	case util.ErrValueNotPresent:
+		return getConfiguredConfig()
+	default:
+		return nil, err
+	}
+}
+
+func (gsc *GovernanceSmartContract) getConfig(
+	balances chainstate.StateContextI,
+) (conf *config, err error) {
+	conf = new(config)
+	err = balances.GetTrieNode(scConfigKey(ADDRESS), conf)
+	if err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func InitConfig(balances chainstate.StateContextI) error {
+	err := balances.GetTrieNode(scConfigKey(ADDRESS), &config{})
+	if err == util.ErrValueNotPresent {
+		conf, err := getConfiguredConfig()
+		if err != nil {
+			return err
+		}
+		_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+		return err
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+package governancesc
+
+import (
+	"encoding/json"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+func delegateKey(clientID datastore.Key) datastore.Key {
+	return ADDRESS + ":delegate:" + clientID
+}
+
+func globalNodeKey() datastore.Key {
+	return ADDRESS + ":globalnode"
+}
+
+// delegate is the stake a client locks in the governance to vote on the
+// proposals with the stake voting
+type delegate struct {
+	ClientID string        `json:"client_id"`
+	Stake    currency.Coin `json:"stake"`
+	// LockedUntil is the end of the voting of the last proposal the delegate
+	// voted on, the stake can't be moved to vote again meanwhile
+	LockedUntil common.Timestamp `json:"locked_until"`
+}
+
+func (d *delegate) save(balances chainstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(delegateKey(d.ClientID), d)
+	return err
+}
+
+func getDelegate(clientID datastore.Key,
+	balances chainstate.CommonStateContextI) (*delegate, error) {
+
+	d := &delegate{ClientID: clientID}
+	if err := balances.GetTrieNode(delegateKey(clientID), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func getOrCreateDelegate(clientID datastore.Key,
+	balances chainstate.CommonStateContextI) (*delegate, error) {
+
+	d, err := getDelegate(clientID, balances)
+	if err == util.ErrValueNotPresent {
+		return &delegate{ClientID: clientID}, nil
+	}
+	return d, err
+}
+
+// globalNode keeps the total stake locked by the delegates
+type globalNode struct {
+	TotalStake currency.Coin `json:"total_stake"`
+}
+
+func (gn *globalNode) save(balances chainstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(globalNodeKey(), gn)
+	return err
+}
+
+func getGlobalNode(balances chainstate.CommonStateContextI) (*globalNode, error) {
+	gn := new(globalNode)
+	err := balances.GetTrieNode(globalNodeKey(), gn)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return gn, nil
+}
+
+// lock moves the transaction value to the stake of the client
+func (gsc *GovernanceSmartContract) lock(t *transaction.Transaction,
+	_ []byte, balances chainstate.StateContextI) (string, error) {
+
+	conf, err := gsc.getConfig(balances)
+	if err != nil {
+		return "", common.NewError("governance_lock_failed",
+			"can't get config: "+err.Error())
+	}
+
+	if t.Value < conf.MinLock || t.Value == 0 {
+		return "", common.NewError("governance_lock_failed",
+			"insufficient amount to lock")
+	}
+
+	balance, err := balances.GetClientBalance(t.ClientID)
+	if err != nil && err != util.ErrValueNotPresent {
+		return "", common.NewError("governance_lock_failed",
+			"can't get client balance: "+err.Error())
+	}
+	if t.Value > balance {
+		return "", common.NewError("governance_lock_failed",
+			"lock amount is greater than balance")
+	}
+
+	d, err := getOrCreateDelegate(t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+	gn, err := getGlobalNode(balances)
+	if err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+
+	if err := balances.AddTransfer(state.NewTransfer(t.ClientID, ADDRESS, t.Value)); err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+	if d.Stake, err = currency.AddCoin(d.Stake, t.Value); err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+	if gn.TotalStake, err = currency.AddCoin(gn.TotalStake, t.Value); err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+
+	if err := d.save(balances); err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+	if err := gn.save(balances); err != nil {
+		return "", common.NewError("governance_lock_failed", err.Error())
+	}
+
+	return string(mustEncode(d)), nil
+}
+
+// unlock returns the whole stake of the client, once the proposals it voted
+// on are no longer open
+func (gsc *GovernanceSmartContract) unlock(t *transaction.Transaction,
+	_ []byte, balances chainstate.StateContextI) (string, error) {
+
+	d, err := getDelegate(t.ClientID, balances)
+	if err != nil {
+		return "", common.NewError("governance_unlock_failed",
+			"can't get stake: "+err.Error())
+	}
+	if t.CreationDate < d.LockedUntil {
+		return "", common.NewErrorf("governance_unlock_failed",
+			"stake is locked until %d by the open votes", d.LockedUntil)
+	}
+
+	gn, err := getGlobalNode(balances)
+	if err != nil {
+		return "", common.NewError("governance_unlock_failed", err.Error())
+	}
+	if gn.TotalStake, err = currency.MinusCoin(gn.TotalStake, d.Stake); err != nil {
+		return "", common.NewError("governance_unlock_failed", err.Error())
+	}
+
+	if err := balances.AddTransfer(state.NewTransfer(ADDRESS, t.ClientID, d.Stake)); err != nil {
+		return "", common.NewError("governance_unlock_failed", err.Error())
+	}
+	if _, err := balances.DeleteTrieNode(delegateKey(t.ClientID)); err != nil {
+		return "", common.NewError("governance_unlock_failed", err.Error())
+	}
+	if err := gn.save(balances); err != nil {
+		return "", common.NewError("governance_unlock_failed", err.Error())
+	}
+
+	return string(mustEncode(d)), nil
+}
+
+func mustEncode(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err) // must not happen
+	}
+	return b
+}
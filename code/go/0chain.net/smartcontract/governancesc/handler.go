@@ -0,0 +1,107 @@
+package governancesc
+
+import (
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract"
+	"0chain.net/smartcontract/rest"
+)
+
+type GovernanceRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewGovernanceRestHandler(rh rest.RestHandlerI) *GovernanceRestHandler {
+	return &GovernanceRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	grh := NewGovernanceRestHandler(rh)
+	governance := "/v1/screst/" + ADDRESS
+	return []rest.Endpoint{
+		rest.MakeEndpoint(governance+"/proposal", common.UserRateLimit(grh.getProposal)),
+		rest.MakeEndpoint(governance+"/delegate", common.UserRateLimit(grh.getDelegate)),
+		rest.MakeEndpoint(governance+"/governance-config", common.UserRateLimit(grh.getConfig)),
+	}
+}
+
+// swagger:route GET /v1/screst/f5486a3f1cc02f8613b33469bef0bac9846e9a1c8b0219f32e0a59e93e19731e/proposal proposal
+// get a governance proposal with its status
+//
+// parameters:
+//
+//	+name: id
+//	 description: proposal id
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: proposalInfo
+//	400:
+//	500:
+func (grh *GovernanceRestHandler) getProposal(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing proposal id"))
+		return
+	}
+
+	p, err := getProposal(id, grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get proposal"))
+		return
+	}
+
+	common.Respond(w, r, proposalInfo{proposal: p, Status: p.status(common.Now())}, nil)
+}
+
+// swagger:route GET /v1/screst/f5486a3f1cc02f8613b33469bef0bac9846e9a1c8b0219f32e0a59e93e19731e/delegate delegate
+// get the governance stake of a client
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: delegate
+//	500:
+func (grh *GovernanceRestHandler) getDelegate(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+
+	// just return empty stake if not found
+	d, err := getOrCreateDelegate(clientID, grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get delegate"))
+		return
+	}
+
+	common.Respond(w, r, d, nil)
+}
+
+// swagger:route GET /v1/screst/f5486a3f1cc02f8613b33469bef0bac9846e9a1c8b0219f32e0a59e93e19731e/governance-config governance-config
+// get governance configuration settings
+//
+// responses:
+//
+//	200: StringMap
+//	500:
+func (grh *GovernanceRestHandler) getConfig(w http.ResponseWriter, r *http.Request) {
+	conf, err := getConfigReadOnly(grh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get config", err.Error()))
+		return
+	}
+	common.Respond(w, r, conf.getConfigMap(), nil)
+}
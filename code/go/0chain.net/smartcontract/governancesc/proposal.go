@@ -0,0 +1,406 @@
+package governancesc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+//msgp:ignore proposalRequest voteRequest proposalIDRequest proposalInfo
+
+// proposal statuses
+const (
+	ProposalVoting     = "voting"
+	ProposalRejected   = "rejected"
+	ProposalQueued     = "queued"
+	ProposalExecutable = "executable"
+	ProposalExpired    = "expired"
+	ProposalExecuted   = "executed"
+	ProposalCancelled  = "cancelled"
+)
+
+// governedFunctions are the administrative functions a proposal can call,
+// by the name of the smart contract
+var governedFunctions = map[string][]string{
+	"miner":   {"update_settings", "update_globals", "kill_miner", "kill_sharder"},
+	"storage": {"update_settings", "kill_blobber", "kill_validator"},
+	"faucet":  {"update-settings"},
+	"vesting": {"vestingsc-update-settings"},
+	"zcnsc":   {"update-global-config"},
+	name:      {"update_settings"},
+}
+
+func isGoverned(scName, function string) bool {
+	for _, f := range governedFunctions[scName] {
+		if f == function {
+			return true
+		}
+	}
+	return false
+}
+
+func proposalKey(id datastore.Key) datastore.Key {
+	return ADDRESS + ":proposal:" + id
+}
+
+type proposalRequest struct {
+	Target      string          `json:"target"`
+	Function    string          `json:"function"`
+	Input       json.RawMessage `json:"input"`
+	Description string          `json:"description"`
+}
+
+func (pr *proposalRequest) decode(b []byte) error {
+	return json.Unmarshal(b, pr)
+}
+
+type voteRequest struct {
+	ProposalID string `json:"proposal_id"`
+	Approve    bool   `json:"approve"`
+}
+
+func (vr *voteRequest) decode(b []byte) error {
+	return json.Unmarshal(b, vr)
+}
+
+type proposalIDRequest struct {
+	ProposalID string `json:"proposal_id"`
+}
+
+func (pr *proposalIDRequest) decode(b []byte) error {
+	return json.Unmarshal(b, pr)
+}
+
+// proposal to call an administrative function of a smart contract, the
+// voting mode, the quorum and the threshold are fixed at the creation, the
+// total weight is updated on each vote, as the votes are weighted by the
+// stakes at the time of the vote
+type proposal struct {
+	ID          string `json:"id"`
+	Proposer    string `json:"proposer"`
+	Target      string `json:"target"`
+	Function    string `json:"function"`
+	Input       string `json:"input"`
+	Description string `json:"description"`
+
+	VotingMode  string  `json:"voting_mode"`
+	Quorum      float64 `json:"quorum"`
+	Threshold   float64 `json:"threshold"`
+	TotalWeight uint64  `json:"total_weight"`
+	Yes         uint64  `json:"yes"`
+	No          uint64  `json:"no"`
+	// Votes of the voters, true is approving
+	Votes map[string]bool `json:"votes"`
+
+	CreatedAt    common.Timestamp `json:"created_at"`
+	VotingEnds   common.Timestamp `json:"voting_ends"`
+	ExecutableAt common.Timestamp `json:"executable_at"`
+	ExpiresAt    common.Timestamp `json:"expires_at"`
+	Executed     bool             `json:"executed"`
+	Cancelled    bool             `json:"cancelled"`
+}
+
+func (p *proposal) passed() bool {
+	cast := p.Yes + p.No
+	return cast > 0 &&
+		float64(cast) >= p.Quorum*float64(p.TotalWeight) &&
+		float64(p.Yes) >= p.Threshold*float64(cast)
+}
+
+func (p *proposal) status(now common.Timestamp) string {
+	switch {
+	case p.Executed:
+		return ProposalExecuted
+	case p.Cancelled:
+		return ProposalCancelled
+	case now < p.VotingEnds:
+		return ProposalVoting
+	case !p.passed():
+		return ProposalRejected
+	case now < p.ExecutableAt:
+		return ProposalQueued
+	case now < p.ExpiresAt:
+		return ProposalExecutable
+	default:
+		return ProposalExpired
+	}
+}
+
+func (p *proposal) save(balances chainstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(proposalKey(p.ID), p)
+	return err
+}
+
+func getProposal(id datastore.Key,
+	balances chainstate.CommonStateContextI) (*proposal, error) {
+
+	p := new(proposal)
+	if err := balances.GetTrieNode(proposalKey(id), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// proposalInfo is a proposal with its status
+type proposalInfo struct {
+	*proposal
+	Status string `json:"status"`
+}
+
+func (gsc *GovernanceSmartContract) createProposal(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	var req proposalRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"malformed request: "+err.Error())
+	}
+
+	conf, err := gsc.getConfig(balances)
+	if err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"can't get config: "+err.Error())
+	}
+
+	if len(req.Description) > conf.MaxDescriptionLength {
+		return "", common.NewError("create_proposal_failed",
+			"description too long")
+	}
+	target := smartcontract.GetSmartContract(req.Target)
+	if target == nil {
+		return "", common.NewError("create_proposal_failed",
+			"unknown target smart contract "+req.Target)
+	}
+	if !isGoverned(target.GetName(), req.Function) {
+		return "", common.NewErrorf("create_proposal_failed",
+			"function %s of smart contract %s is not governed",
+			req.Function, target.GetName())
+	}
+
+	p := &proposal{
+		ID:          t.Hash,
+		Proposer:    t.ClientID,
+		Target:      req.Target,
+		Function:    req.Function,
+		Input:       string(req.Input),
+		Description: req.Description,
+		VotingMode:  conf.VotingMode,
+		Quorum:      conf.Quorum,
+		Threshold:   conf.Threshold,
+		Votes:       make(map[string]bool),
+		CreatedAt:   t.CreationDate,
+	}
+	p.VotingEnds = p.CreatedAt + toSeconds(conf.VotingPeriod)
+	p.ExecutableAt = p.VotingEnds + toSeconds(conf.Timelock)
+	p.ExpiresAt = p.ExecutableAt + toSeconds(conf.ExecutionWindow)
+
+	switch conf.VotingMode {
+	case VotingCouncil:
+		if !conf.isCouncilMember(t.ClientID) {
+			return "", common.NewError("create_proposal_failed",
+				"only a council member can create a proposal")
+		}
+		p.TotalWeight = uint64(len(conf.Council))
+	default:
+		d, err := getOrCreateDelegate(t.ClientID, balances)
+		if err != nil {
+			return "", common.NewError("create_proposal_failed", err.Error())
+		}
+		if d.Stake == 0 || d.Stake < conf.MinProposalStake {
+			return "", common.NewError("create_proposal_failed",
+				"insufficient stake to create a proposal")
+		}
+		gn, err := getGlobalNode(balances)
+		if err != nil {
+			return "", common.NewError("create_proposal_failed", err.Error())
+		}
+		p.TotalWeight = uint64(gn.TotalStake)
+	}
+
+	if err := p.save(balances); err != nil {
+		return "", common.NewError("create_proposal_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	return string(mustEncode(p)), nil
+}
+
+func (gsc *GovernanceSmartContract) vote(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	var req voteRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("vote_failed",
+			"malformed request: "+err.Error())
+	}
+
+	p, err := getProposal(req.ProposalID, balances)
+	if err != nil {
+		return "", common.NewError("vote_failed",
+			"can't get proposal: "+err.Error())
+	}
+	if status := p.status(t.CreationDate); status != ProposalVoting {
+		return "", common.NewErrorf("vote_failed",
+			"proposal is %s", status)
+	}
+	if _, ok := p.Votes[t.ClientID]; ok {
+		return "", common.NewError("vote_failed", "already voted")
+	}
+
+	var weight uint64
+	switch p.VotingMode {
+	case VotingCouncil:
+		conf, err := gsc.getConfig(balances)
+		if err != nil {
+			return "", common.NewError("vote_failed",
+				"can't get config: "+err.Error())
+		}
+		if !conf.isCouncilMember(t.ClientID) {
+			return "", common.NewError("vote_failed",
+				"only a council member can vote")
+		}
+		weight = 1
+		p.TotalWeight = uint64(len(conf.Council))
+	default:
+		d, err := getDelegate(t.ClientID, balances)
+		if err != nil {
+			return "", common.NewError("vote_failed",
+				"only a delegate with stake can vote: "+err.Error())
+		}
+		weight = uint64(d.Stake)
+		if d.LockedUntil < p.VotingEnds {
+			d.LockedUntil = p.VotingEnds
+		}
+		if err := d.save(balances); err != nil {
+			return "", common.NewError("vote_failed", err.Error())
+		}
+		gn, err := getGlobalNode(balances)
+		if err != nil {
+			return "", common.NewError("vote_failed", err.Error())
+		}
+		p.TotalWeight = uint64(gn.TotalStake)
+	}
+
+	p.Votes[t.ClientID] = req.Approve
+	if req.Approve {
+		p.Yes += weight
+	} else {
+		p.No += weight
+	}
+
+	if err := p.save(balances); err != nil {
+		return "", common.NewError("vote_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	return string(mustEncode(p)), nil
+}
+
+// executeProposal calls the function of the accepted proposal on behalf of
+// the governance smart contract, any client can execute it once the proposal
+// is out of the timelock. The call runs in its own state context, with the
+// governance smart contract as the client of the call transaction.
+func (gsc *GovernanceSmartContract) executeProposal(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	var req proposalIDRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("execute_proposal_failed",
+			"malformed request: "+err.Error())
+	}
+
+	p, err := getProposal(req.ProposalID, balances)
+	if err != nil {
+		return "", common.NewError("execute_proposal_failed",
+			"can't get proposal: "+err.Error())
+	}
+	if status := p.status(t.CreationDate); status != ProposalExecutable {
+		return "", common.NewErrorf("execute_proposal_failed",
+			"proposal is %s", status)
+	}
+
+	target := smartcontract.GetSmartContract(p.Target)
+	if target == nil {
+		return "", common.NewError("execute_proposal_failed",
+			"unknown target smart contract "+p.Target)
+	}
+
+	callBalances, ok := balances.(chainstate.CallStateContextI)
+	if !ok {
+		return "", common.NewError("execute_proposal_failed",
+			"smart contract calls are not supported")
+	}
+
+	call := t.Clone()
+	call.ClientID = ADDRESS
+	call.ToClientID = p.Target
+	call.Value = 0
+	callCtx := callBalances.NewCallStateContext(call)
+	resp, err := target.Execute(call, p.Function, []byte(p.Input), callCtx)
+	if err != nil {
+		return "", common.NewErrorf("execute_proposal_failed",
+			"calling %s: %v", p.Function, err)
+	}
+	if err := callCtx.Validate(); err != nil {
+		return "", common.NewErrorf("execute_proposal_failed",
+			"calling %s: %v", p.Function, err)
+	}
+	callBalances.AddBatchCallResults(callCtx)
+
+	// the proposal is reloaded, as the call can update the governance itself
+	if p, err = getProposal(req.ProposalID, balances); err != nil {
+		return "", common.NewError("execute_proposal_failed",
+			"can't get proposal: "+err.Error())
+	}
+	p.Executed = true
+	if err := p.save(balances); err != nil {
+		return "", common.NewError("execute_proposal_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	return resp, nil
+}
+
+// cancelProposal withdraws a proposal not executed yet, by its proposer
+func (gsc *GovernanceSmartContract) cancelProposal(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (string, error) {
+
+	var req proposalIDRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"malformed request: "+err.Error())
+	}
+
+	p, err := getProposal(req.ProposalID, balances)
+	if err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"can't get proposal: "+err.Error())
+	}
+
+	if t.ClientID != p.Proposer {
+		return "", common.NewError("cancel_proposal_failed",
+			"only the proposer can cancel a proposal")
+	}
+
+	switch status := p.status(t.CreationDate); status {
+	case ProposalVoting, ProposalQueued, ProposalExecutable:
+	default:
+		return "", common.NewErrorf("cancel_proposal_failed",
+			"proposal is %s", status)
+	}
+
+	p.Cancelled = true
+	if err := p.save(balances); err != nil {
+		return "", common.NewError("cancel_proposal_failed",
+			"saving proposal: "+err.Error())
+	}
+
+	return fmt.Sprintf("proposal %s cancelled", p.ID), nil
+}
@@ -0,0 +1,213 @@
+package governancesc
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	config2 "0chain.net/core/config"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+type testGovernance struct {
+	t        *testing.T
+	gsc      *GovernanceSmartContract
+	balances *testBalances
+	txns     int
+}
+
+func newTestGovernance(t *testing.T, conf *config) *testGovernance {
+	gsc := NewGovernanceSmartContract().(*GovernanceSmartContract)
+	smartcontract.ContractMap[ADDRESS] = gsc
+	t.Cleanup(func() { delete(smartcontract.ContractMap, ADDRESS) })
+
+	balances := newTestBalances()
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+	return &testGovernance{t: t, gsc: gsc, balances: balances}
+}
+
+func (tg *testGovernance) call(clientID, function string, value currency.Coin,
+	now common.Timestamp, input interface{}) (string, error) {
+
+	b, err := json.Marshal(input)
+	require.NoError(tg.t, err)
+	tg.txns++
+	txn := &transaction.Transaction{
+		ClientID:     clientID,
+		ToClientID:   ADDRESS,
+		Value:        value,
+		CreationDate: now,
+	}
+	txn.Hash = "txn" + strconv.Itoa(tg.txns)
+	tg.balances.txn = txn
+	return tg.gsc.Execute(txn, function, b, tg.balances)
+}
+
+func (tg *testGovernance) config() *config {
+	conf, err := tg.gsc.getConfig(tg.balances)
+	require.NoError(tg.t, err)
+	return conf
+}
+
+func testConfig(mode string, council ...string) *config {
+	return &config{
+		VotingMode:           mode,
+		Council:              council,
+		MinLock:              1e10,
+		MinProposalStake:     10e10,
+		VotingPeriod:         100 * time.Second,
+		Timelock:             50 * time.Second,
+		ExecutionWindow:      100 * time.Second,
+		Quorum:               0.5,
+		Threshold:            0.66,
+		MaxDescriptionLength: 100,
+	}
+}
+
+func settingsProposal(key, value string) *proposalRequest {
+	input, _ := json.Marshal(&config2.StringMap{Fields: map[string]string{key: value}})
+	return &proposalRequest{
+		Target:      ADDRESS,
+		Function:    "update_settings",
+		Input:       input,
+		Description: "update " + key,
+	}
+}
+
+func TestCouncilProposal(t *testing.T) {
+	const (
+		alice = "a11ce"
+		bob   = "b0b"
+		carol = "ca201"
+		dave  = "da7e"
+	)
+	tg := newTestGovernance(t, testConfig(VotingCouncil, alice, bob, carol))
+
+	_, err := tg.call(dave, "create_proposal", 0, 10, settingsProposal("quorum", "0.6"))
+	require.Error(t, err)
+	_, err = tg.call(alice, "create_proposal", 0, 10, &proposalRequest{
+		Target: ADDRESS, Function: "lock",
+	})
+	require.Error(t, err, "not governed function")
+
+	_, err = tg.call(alice, "create_proposal", 0, 10, settingsProposal("quorum", "0.6"))
+	require.NoError(t, err)
+	id := "txn" + strconv.Itoa(tg.txns)
+
+	vote := func(clientID string, approve bool, now common.Timestamp) error {
+		_, err := tg.call(clientID, "vote", 0, now, &voteRequest{ProposalID: id, Approve: approve})
+		return err
+	}
+	execute := func(now common.Timestamp) error {
+		_, err := tg.call(dave, "execute_proposal", 0, now, &proposalIDRequest{ProposalID: id})
+		return err
+	}
+
+	require.NoError(t, vote(alice, true, 20))
+	require.Error(t, vote(alice, true, 20), "already voted")
+	require.Error(t, vote(dave, true, 20), "not a council member")
+	require.NoError(t, vote(bob, true, 30))
+	require.Error(t, execute(40), "voting")
+	require.Error(t, vote(carol, false, 110), "voting ended")
+
+	require.Error(t, execute(150), "timelock")
+	require.NoError(t, execute(160))
+	require.EqualValues(t, 0.6, tg.config().Quorum)
+	require.Error(t, execute(170), "already executed")
+
+	p, err := getProposal(id, tg.balances)
+	require.NoError(t, err)
+	require.Equal(t, ProposalExecuted, p.status(170))
+}
+
+func TestStakeProposal(t *testing.T) {
+	const (
+		alice = "a11ce"
+		bob   = "b0b"
+	)
+	tg := newTestGovernance(t, testConfig(VotingStake))
+	tg.balances.balances[alice] = 100e10
+	tg.balances.balances[bob] = 100e10
+
+	_, err := tg.call(alice, "lock", 0.5e10, 1, nil)
+	require.Error(t, err, "less than min lock")
+	_, err = tg.call(alice, "lock", 20e10, 1, nil)
+	require.NoError(t, err)
+	_, err = tg.call(bob, "lock", 5e10, 1, nil)
+	require.NoError(t, err)
+
+	_, err = tg.call(bob, "create_proposal", 0, 10, settingsProposal("threshold", "0.9"))
+	require.Error(t, err, "not enough stake to propose")
+	_, err = tg.call(alice, "create_proposal", 0, 10, settingsProposal("threshold", "0.9"))
+	require.NoError(t, err)
+	id := "txn" + strconv.Itoa(tg.txns)
+
+	// the vote is weighted by the stake at the vote time, as the total
+	_, err = tg.call(bob, "lock", 5e10, 15, nil)
+	require.NoError(t, err)
+	_, err = tg.call(bob, "vote", 0, 20, &voteRequest{ProposalID: id, Approve: true})
+	require.NoError(t, err)
+	_, err = tg.call(bob, "unlock", 0, 30, nil)
+	require.Error(t, err, "stake locked by the vote")
+
+	// 10 of 30 staked is under the quorum
+	p, err := getProposal(id, tg.balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 10e10, p.Yes)
+	require.EqualValues(t, 30e10, p.TotalWeight)
+	require.Equal(t, ProposalRejected, p.status(110))
+
+	_, err = tg.call(bob, "unlock", 0, 110, nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 100e10, tg.balances.balances[bob])
+
+	gn, err := getGlobalNode(tg.balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 20e10, gn.TotalStake)
+
+	_, err = tg.call(alice, "execute_proposal", 0, 160, &proposalIDRequest{ProposalID: id})
+	require.Error(t, err)
+	require.EqualValues(t, 0.66, tg.config().Threshold)
+}
+
+func TestCancelProposal(t *testing.T) {
+	const (
+		alice = "a11ce"
+		bob   = "b0b"
+	)
+	tg := newTestGovernance(t, testConfig(VotingCouncil, alice, bob))
+
+	_, err := tg.call(alice, "create_proposal", 0, 10, settingsProposal("quorum", "1"))
+	require.NoError(t, err)
+	id := "txn" + strconv.Itoa(tg.txns)
+
+	_, err = tg.call(bob, "cancel_proposal", 0, 20, &proposalIDRequest{ProposalID: id})
+	require.Error(t, err, "only the proposer cancels")
+	_, err = tg.call(alice, "cancel_proposal", 0, 20, &proposalIDRequest{ProposalID: id})
+	require.NoError(t, err)
+	_, err = tg.call(bob, "vote", 0, 30, &voteRequest{ProposalID: id, Approve: true})
+	require.Error(t, err, "cancelled")
+}
+
+func TestUpdateConfigAuthorization(t *testing.T) {
+	conf := testConfig(VotingCouncil, "a11ce")
+	conf.OwnerId = "0123"
+	tg := newTestGovernance(t, conf)
+
+	update := &config2.StringMap{Fields: map[string]string{"voting_mode": "stake"}}
+	_, err := tg.call("a11ce", "update_settings", 0, 1, update)
+	require.Error(t, err)
+	_, err = tg.call("0123", "update_settings", 0, 1, update)
+	require.NoError(t, err)
+	require.Equal(t, VotingStake, tg.config().VotingMode)
+
+	update.Fields = map[string]string{"quorum": "2"}
+	_, err = tg.call("0123", "update_settings", 0, 1, update)
+	require.Error(t, err, "invalid quorum")
+}
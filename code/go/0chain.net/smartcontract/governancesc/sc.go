@@ -0,0 +1,99 @@
+package governancesc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+const (
+	ADDRESS = "f5486a3f1cc02f8613b33469bef0bac9846e9a1c8b0219f32e0a59e93e19731e"
+	name    = "governance"
+)
+
+// GovernanceSmartContract replaces the single owner administration of the
+// smart contracts. Proposals to call the administrative functions are voted
+// by a council or by stake weighted delegates, time locked, and executed by
+// the governance smart contract the other smart contracts accept as an
+// authorized caller.
+type GovernanceSmartContract struct {
+	*smartcontractinterface.SmartContract
+}
+
+func NewGovernanceSmartContract() smartcontractinterface.SmartContractInterface {
+	var gscCopy = &GovernanceSmartContract{
+		smartcontractinterface.NewSC(ADDRESS),
+	}
+	gscCopy.setSC(gscCopy.SmartContract, &smartcontract.BCContext{})
+	return gscCopy
+}
+
+func (gsc *GovernanceSmartContract) GetHandlerStats(ctx context.Context, params url.Values) (interface{}, error) {
+	return gsc.SmartContract.HandlerStats(ctx, params)
+}
+
+func (gsc *GovernanceSmartContract) GetExecutionStats() map[string]interface{} {
+	return gsc.SmartContractExecutionStats
+}
+
+func (gsc *GovernanceSmartContract) GetName() string {
+	return name
+}
+
+func (gsc *GovernanceSmartContract) GetAddress() string {
+	return ADDRESS
+}
+
+func (gsc *GovernanceSmartContract) GetCostTable(balances chainstate.StateContextI) (map[string]int, error) {
+	conf, err := gsc.getConfig(balances)
+	if err != nil {
+		return map[string]int{}, err
+	}
+	if conf.Cost == nil {
+		return map[string]int{}, err
+	}
+	return conf.Cost, nil
+}
+
+func (gsc *GovernanceSmartContract) setSC(sc *smartcontractinterface.SmartContract,
+	_ smartcontractinterface.BCContextI) {
+
+	gsc.SmartContract = sc
+	for _, f := range costFunctions {
+		gsc.SmartContractExecutionStats[f] = metrics.GetOrRegisterTimer(
+			fmt.Sprintf("sc:%v:func:%v", gsc.ID, f), nil)
+	}
+}
+
+func (gsc *GovernanceSmartContract) Execute(t *transaction.Transaction,
+	function string, input []byte, balances chainstate.StateContextI) (
+	resp string, err error) {
+
+	switch function {
+	case "create_proposal":
+		resp, err = gsc.createProposal(t, input, balances)
+	case "vote":
+		resp, err = gsc.vote(t, input, balances)
+	case "execute_proposal":
+		resp, err = gsc.executeProposal(t, input, balances)
+	case "cancel_proposal":
+		resp, err = gsc.cancelProposal(t, input, balances)
+	case "lock":
+		resp, err = gsc.lock(t, input, balances)
+	case "unlock":
+		resp, err = gsc.unlock(t, input, balances)
+	case "update_settings":
+		resp, err = gsc.updateConfig(t, input, balances)
+	default:
+		err = common.NewError("governance_sc_failed",
+			fmt.Sprintf("no function with %q name", function))
+	}
+	return
+}
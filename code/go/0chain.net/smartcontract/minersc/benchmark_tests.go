@@ -2,6 +2,7 @@ package minersc
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -83,7 +84,7 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, b *testing.B) er
 }
 
 func BenchmarkTests(
-	data bk.BenchData, _ bk.SignatureScheme,
+	data bk.BenchData, sigScheme bk.SignatureScheme,
 ) bk.TestSuite {
 	creationTimeRaw := viper.GetInt64("MptCreationTime")
 	creationTime := common.Now()
@@ -211,6 +212,44 @@ func BenchmarkTests(
 				CreationDate: creationTime,
 			},
 		},
+		{
+			name: "miner.report_equivocation",
+			endpoint: func(t *transaction.Transaction,
+				input []byte, gn *GlobalNode, balances cstate.StateContextI) (
+				resp string, err error) {
+				// the blocks are signed with the key of the first client
+				node, err := getMinerNode(data.Miners[0], balances)
+				if err != nil {
+					return "", err
+				}
+				node.PublicKey = data.PublicKeys[0]
+				if err := node.save(balances); err != nil {
+					return "", err
+				}
+				return msc.reportEquivocation(t, input, gn, balances)
+			},
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				_ = sigScheme.SetPublicKey(data.PublicKeys[0])
+				sigScheme.SetPrivateKey(data.PrivateKeys[0])
+				var evidence EquivocationEvidence
+				for i, sbh := range []*SignedBlockHeader{&evidence.First, &evidence.Second} {
+					sbh.MinerID = data.Miners[0]
+					sbh.PrevHash = encryption.Hash("previous block")
+					sbh.CreationDate = creationTime
+					sbh.Round = 1
+					sbh.MerkleTreeRoot = encryption.Hash(fmt.Sprintf("block %d", i))
+					sbh.VerifierID = data.Miners[0]
+					sbh.Signature, _ = sigScheme.Sign(sbh.Hash())
+				}
+				bytes, _ := json.Marshal(&evidence)
+				return bytes
+			}(),
+		},
 		{
 			name:     "miner.contributeMpk",
 			endpoint: msc.contributeMpk,
@@ -324,6 +363,8 @@ func BenchmarkTests(
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.report_equivocation":                     "111",
+					"cost.auto_compound":                           "111",
 				},
 			}).Encode(),
 		},
@@ -403,6 +444,20 @@ func BenchmarkTests(
 				ProviderID:   data.Miners[0],
 			}).Encode(),
 		},
+		{
+			name:     "miner.auto_compound",
+			endpoint: msc.autoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.AutoCompoundRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+				AutoCompound: true,
+			}).Encode(),
+		},
 		{
 			name:     "miner.sharder_keep",
 			endpoint: msc.sharderKeep,
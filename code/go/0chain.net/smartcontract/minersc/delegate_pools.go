@@ -56,3 +56,25 @@ func (msc *MinerSmartContract) deleteFromDelegatePool(
 
 	return stakepool.StakePoolUnlock(t, inputData, balances, msc.getStakePoolAdapter)
 }
+
+func (msc *MinerSmartContract) autoCompound(t *transaction.Transaction,
+	input []byte, _ *GlobalNode, balances cstate.StateContextI) (
+	resp string, err error) {
+
+	return stakepool.StakePoolAutoCompound(t, input, balances, msc.getStakePoolAdapter)
+}
+
+// the stakes of the miners and the sharders are compounded up to the max
+// stake of the current config
+func compoundLimits(_ string, balances cstate.CommonStateContextI) (stakepool.CompoundLimits, error) {
+	gn, err := getGlobalNode(balances)
+	if err != nil {
+		return stakepool.CompoundLimits{}, err
+	}
+	return stakepool.CompoundLimits{MaxStake: gn.MaxStake}, nil
+}
+
+func init() {
+	stakepool.SetCompoundLimitsFunc(spenum.Miner, compoundLimits)
+	stakepool.SetCompoundLimitsFunc(spenum.Sharder, compoundLimits)
+}
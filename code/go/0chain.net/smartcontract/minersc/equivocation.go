@@ -0,0 +1,229 @@
+package minersc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/stakepool"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported -v
+
+//msgp:ignore BlockHeader SignedBlockHeader EquivocationEvidence
+
+// BlockHeader is the block data the hash of a block is computed from
+type BlockHeader struct {
+	MinerID               datastore.Key    `json:"miner_id"`
+	PrevHash              string           `json:"prev_hash"`
+	CreationDate          common.Timestamp `json:"creation_date"`
+	Round                 int64            `json:"round"`
+	RoundRandomSeed       int64            `json:"round_random_seed"`
+	StateChangesCount     int              `json:"state_changes_count"`
+	MerkleTreeRoot        string           `json:"merkle_tree_root"`
+	ReceiptMerkleTreeRoot string           `json:"receipt_merkle_tree_root"`
+	MagicBlockHash        string           `json:"magic_block_hash,omitempty"`
+}
+
+// Hash of the block, computed the same way the chain hashes the blocks
+func (bh *BlockHeader) Hash() string {
+	fields := []string{
+		bh.MinerID,
+		bh.PrevHash,
+		common.TimeToString(bh.CreationDate),
+		strconv.FormatInt(bh.Round, 10),
+		strconv.FormatInt(bh.RoundRandomSeed, 10),
+		strconv.Itoa(bh.StateChangesCount),
+		bh.MerkleTreeRoot,
+		bh.ReceiptMerkleTreeRoot,
+	}
+	if bh.MagicBlockHash != "" {
+		fields = append(fields, bh.MagicBlockHash)
+	}
+	return encryption.Hash(strings.Join(fields, ":"))
+}
+
+// SignedBlockHeader is a block and the signature of its hash, either the
+// signature of the generator or a verification ticket of the block
+type SignedBlockHeader struct {
+	BlockHeader
+	block.VerificationTicket
+}
+
+func (sbh *SignedBlockHeader) verify(publicKey string,
+	balances cstate.StateContextI) error {
+
+	scheme := balances.GetSignatureScheme()
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return err
+	}
+	ok, err := scheme.Verify(sbh.Signature, sbh.Hash())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// EquivocationEvidence is two different blocks of one generator for the same
+// round, both signed by the same node; signed by the generator they're two
+// generated blocks, signed by another miner they're two verification tickets
+type EquivocationEvidence struct {
+	First  SignedBlockHeader `json:"first"`
+	Second SignedBlockHeader `json:"second"`
+}
+
+func (ee *EquivocationEvidence) Decode(p []byte) error {
+	return json.Unmarshal(p, ee)
+}
+
+// NodeID is the id of the node signed the blocks
+func (ee *EquivocationEvidence) NodeID() string {
+	return ee.First.VerifierID
+}
+
+func (ee *EquivocationEvidence) validate(publicKey string,
+	balances cstate.StateContextI) error {
+
+	first, second := &ee.First, &ee.Second
+	switch {
+	case first.VerifierID == "" || first.VerifierID != second.VerifierID:
+		return errors.New("blocks signed by different nodes")
+	case first.Round != second.Round:
+		return errors.New("blocks of different rounds")
+	case first.MinerID != second.MinerID:
+		return errors.New("blocks of different generators")
+	case first.Hash() == second.Hash():
+		return errors.New("same block")
+	}
+
+	if err := first.verify(publicKey, balances); err != nil {
+		return fmt.Errorf("first block: %v", err)
+	}
+	if err := second.verify(publicKey, balances); err != nil {
+		return fmt.Errorf("second block: %v", err)
+	}
+	return nil
+}
+
+func equivocationKey(nodeID string, round int64) datastore.Key {
+	return ADDRESS + ":equivocation:" + nodeID + ":" + strconv.FormatInt(round, 10)
+}
+
+// equivocation is a reported equivocation, a node is slashed once a round
+type equivocation struct {
+	NodeID   string        `json:"node_id"`
+	Round    int64         `json:"round"`
+	Reporter string        `json:"reporter"`
+	Slashed  currency.Coin `json:"slashed"`
+	Reward   currency.Coin `json:"reward"`
+}
+
+func (eq *equivocation) Encode() []byte {
+	buff, _ := json.Marshal(eq)
+	return buff
+}
+
+// reportEquivocation verifies the evidence of an equivocation of a miner or
+// sharder, slashes its stake pool and rewards the reporter with a part of
+// the slashed tokens
+func (msc *MinerSmartContract) reportEquivocation(
+	t *transaction.Transaction,
+	input []byte,
+	gn *GlobalNode,
+	balances cstate.StateContextI,
+) (resp string, err error) {
+	var evidence EquivocationEvidence
+	if err = evidence.Decode(input); err != nil {
+		return "", common.NewError("report_equivocation_failed",
+			"malformed request: "+err.Error())
+	}
+
+	nodeID, round := evidence.NodeID(), evidence.First.Round
+	err = balances.GetTrieNode(equivocationKey(nodeID, round), &equivocation{})
+	switch err {
+	case nil:
+		return "", common.NewErrorf("report_equivocation_failed",
+			"equivocation of %s in round %d is already reported", nodeID, round)
+	case util.ErrValueNotPresent:
+	default:
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	node := NewMinerNode()
+	node.ID = nodeID
+	if err = balances.GetTrieNode(node.GetKey(), node); err != nil {
+		return "", common.NewError("report_equivocation_failed",
+			"can't get node: "+err.Error())
+	}
+
+	if err = evidence.validate(node.PublicKey, balances); err != nil {
+		return "", common.NewError("report_equivocation_failed",
+			"invalid evidence: "+err.Error())
+	}
+
+	before, err := stakedTokens(node.StakePool)
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	if err = node.SlashFraction(gn.EquivocationSlash, node.ID, node.ProviderType, balances); err != nil {
+		return "", common.NewError("report_equivocation_failed",
+			"slashing stake pool: "+err.Error())
+	}
+	after, err := stakedTokens(node.StakePool)
+	if err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	eq := &equivocation{
+		NodeID:   nodeID,
+		Round:    round,
+		Reporter: t.ClientID,
+		Slashed:  before - after,
+	}
+	if eq.Reward, err = currency.MultFloat64(eq.Slashed, gn.EquivocationReporterReward); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	if eq.Reward > 0 {
+		if err = balances.AddTransfer(state.NewTransfer(ADDRESS, t.ClientID, eq.Reward)); err != nil {
+			return "", common.NewError("report_equivocation_failed",
+				"rewarding reporter: "+err.Error())
+		}
+	}
+
+	if err = node.save(balances); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+	if _, err = balances.InsertTrieNode(equivocationKey(nodeID, round), eq); err != nil {
+		return "", common.NewError("report_equivocation_failed", err.Error())
+	}
+
+	return string(eq.Encode()), nil
+}
+
+// stakedTokens of the delegate pools, including the unbonding tokens
+func stakedTokens(sp *stakepool.StakePool) (total currency.Coin, err error) {
+	for _, dp := range sp.GetOrderedPools() {
+		if total, err = currency.AddCoin(total, dp.Balance); err != nil {
+			return 0, err
+		}
+	}
+	unbonding, err := sp.Unbonding()
+	if err != nil {
+		return 0, err
+	}
+	return currency.AddCoin(total, unbonding)
+}
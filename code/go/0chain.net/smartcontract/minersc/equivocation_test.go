@@ -0,0 +1,76 @@
+package minersc
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+func (c *Client) signBlock(t *testing.T, bh BlockHeader) SignedBlockHeader {
+	sig, err := c.scheme.Sign(bh.Hash())
+	require.NoError(t, err)
+	return SignedBlockHeader{
+		BlockHeader:        bh,
+		VerificationTicket: block.VerificationTicket{VerifierID: c.id, Signature: sig},
+	}
+}
+
+func TestReportEquivocation(t *testing.T) {
+	var (
+		msc      = newTestMinerSC()
+		balances = newTestBalances()
+		gn       = &GlobalNode{EquivocationSlash: 0.5, EquivocationReporterReward: 0.2}
+		node     = newClient(0, balances)
+		reporter = newClient(0, balances)
+	)
+
+	mn := node.addNodeRequest("delegate_wallet")
+	mn.ProviderType = spenum.Miner
+	mn.Pools["delegate"] = &stakepool.DelegatePool{DelegateID: "delegate", Balance: 100}
+	require.NoError(t, mn.save(balances))
+	balances.balances[ADDRESS] = 100
+
+	report := func(evidence *EquivocationEvidence) error {
+		tx := newTransaction(reporter.id, ADDRESS, 0, 10)
+		balances.txn = tx
+		_, err := msc.reportEquivocation(tx, mustEncode(evidence), gn, balances)
+		return err
+	}
+
+	first := BlockHeader{MinerID: node.id, PrevHash: "prev", Round: 5, MerkleTreeRoot: "a"}
+	second := first
+	second.MerkleTreeRoot = "b"
+	other := first
+	other.Round = 6
+
+	require.Error(t, report(&EquivocationEvidence{
+		First:  node.signBlock(t, first),
+		Second: node.signBlock(t, first),
+	}), "same block")
+	require.Error(t, report(&EquivocationEvidence{
+		First:  node.signBlock(t, first),
+		Second: node.signBlock(t, other),
+	}), "different rounds")
+	forged := node.signBlock(t, second)
+	forged.Signature = reporter.signBlock(t, second).Signature
+	require.Error(t, report(&EquivocationEvidence{
+		First:  node.signBlock(t, first),
+		Second: forged,
+	}), "invalid signature")
+
+	evidence := &EquivocationEvidence{
+		First:  node.signBlock(t, first),
+		Second: node.signBlock(t, second),
+	}
+	require.NoError(t, report(evidence))
+	require.EqualValues(t, 10, balances.balances[reporter.id])
+
+	mn, err := getMinerNode(node.id, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, mn.Pools["delegate"].Balance)
+
+	require.Error(t, report(evidence), "already reported")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/0chain/common/core/currency"
 
 	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/smartcontract/governancesc"
 
 	"github.com/0chain/common/core/util"
 
@@ -305,7 +306,7 @@ func (msc *MinerSmartContract) updateGlobals(
 	balances cstate.StateContextI,
 ) (resp string, err error) {
 	if err := smartcontractinterface.AuthorizeWithOwner("update_globals", func() bool {
-		return gn.OwnerId == txn.ClientID
+		return gn.OwnerId == txn.ClientID || txn.ClientID == governancesc.ADDRESS
 	}); err != nil {
 		return "", err
 	}
@@ -50,7 +50,9 @@ func (msc *MinerSmartContract) initSC() {
 	msc.smartContractFunctions["update_settings"] = msc.updateSettings
 	msc.smartContractFunctions["addToDelegatePool"] = msc.addToDelegatePool
 	msc.smartContractFunctions["deleteFromDelegatePool"] = msc.deleteFromDelegatePool
+	msc.smartContractFunctions["auto_compound"] = msc.autoCompound
 	msc.smartContractFunctions["sharder_keep"] = msc.sharderKeep
+	msc.smartContractFunctions["report_equivocation"] = msc.reportEquivocation
 }
 
 func (msc *MinerSmartContract) AddMinerIntegrationTests(
@@ -9,6 +9,7 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/smartcontract/dbs"
 	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/governancesc"
 	"0chain.net/smartcontract/provider"
 )
 
@@ -54,7 +55,7 @@ func kill(
 	}
 
 	if err := smartcontractinterface.AuthorizeWithOwner("only the owner can kill a provider", func() bool {
-		return ownerId == clientId
+		return ownerId == clientId || clientId == governancesc.ADDRESS
 	}); err != nil {
 		return err
 	}
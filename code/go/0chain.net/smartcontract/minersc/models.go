@@ -262,6 +262,13 @@ type GlobalNode struct {
 	OwnerId              string         `json:"owner_id"`
 	CooldownPeriod       int64          `json:"cooldown_period"`
 	Cost                 map[string]int `json:"cost"`
+
+	// EquivocationSlash is the fraction of the stake of a node slashed when
+	// it signs two different blocks of a round.
+	EquivocationSlash float64 `json:"equivocation_slash"`
+	// EquivocationReporterReward is the part of the slashed tokens paid to
+	// the reporter of the equivocation.
+	EquivocationReporterReward float64 `json:"equivocation_reporter_reward"`
 }
 
 func (gn *GlobalNode) readConfig() (err error) {
@@ -307,6 +314,8 @@ func (gn *GlobalNode) readConfig() (err error) {
 	}
 	gn.OwnerId = config2.SmartContractConfig.GetString(pfx + SettingName[OwnerId])
 	gn.CooldownPeriod = config2.SmartContractConfig.GetInt64(pfx + SettingName[CooldownPeriod])
+	gn.EquivocationSlash = config2.SmartContractConfig.GetFloat64(pfx + SettingName[EquivocationSlash])
+	gn.EquivocationReporterReward = config2.SmartContractConfig.GetFloat64(pfx + SettingName[EquivocationReporterReward])
 	gn.Cost = config2.SmartContractConfig.GetStringMapInt(pfx + "cost")
 	return nil
 }
@@ -343,6 +352,14 @@ func (gn *GlobalNode) validate() error {
 		return fmt.Errorf("%s cannot be negative: %d",
 			NumShardersRewarded.String(), gn.NumShardersRewarded)
 	}
+	if gn.EquivocationSlash < 0 || gn.EquivocationSlash > 1 {
+		return fmt.Errorf("%s should be in [0, 1]: %v",
+			EquivocationSlash.String(), gn.EquivocationSlash)
+	}
+	if gn.EquivocationReporterReward < 0 || gn.EquivocationReporterReward > 1 {
+		return fmt.Errorf("%s should be in [0, 1]: %v",
+			EquivocationReporterReward.String(), gn.EquivocationReporterReward)
+	}
 	return nil
 }
 
@@ -427,6 +444,10 @@ func (gn *GlobalNode) Get(key Setting) (interface{}, error) {
 		return gn.OwnerId, nil
 	case CooldownPeriod:
 		return gn.CooldownPeriod, nil
+	case EquivocationSlash:
+		return gn.EquivocationSlash, nil
+	case EquivocationReporterReward:
+		return gn.EquivocationReporterReward, nil
 	default:
 		return nil, errors.New("Setting not implemented")
 	}
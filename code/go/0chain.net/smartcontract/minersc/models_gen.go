@@ -308,9 +308,9 @@ func (z *DKGMinerNodes) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 30
+	// map header, size 32
 	// string "ViewChange"
-	o = append(o, 0xde, 0x0, 0x1e, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
+	o = append(o, 0xde, 0x0, 0x20, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
 	o = msgp.AppendInt64(o, z.ViewChange)
 	// string "MaxN"
 	o = append(o, 0xa4, 0x4d, 0x61, 0x78, 0x4e)
@@ -441,6 +441,12 @@ func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 		o = msgp.AppendString(o, k)
 		o = msgp.AppendInt(o, za0002)
 	}
+	// string "EquivocationSlash"
+	o = append(o, 0xb1, 0x45, 0x71, 0x75, 0x69, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6c, 0x61, 0x73, 0x68)
+	o = msgp.AppendFloat64(o, z.EquivocationSlash)
+	// string "EquivocationReporterReward"
+	o = append(o, 0xba, 0x45, 0x71, 0x75, 0x69, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64)
+	o = msgp.AppendFloat64(o, z.EquivocationReporterReward)
 	return
 }
 
@@ -677,6 +683,18 @@ func (z *GlobalNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Cost[za0001] = za0002
 			}
+		case "EquivocationSlash":
+			z.EquivocationSlash, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EquivocationSlash")
+				return
+			}
+		case "EquivocationReporterReward":
+			z.EquivocationReporterReward, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "EquivocationReporterReward")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -704,6 +722,7 @@ func (z *GlobalNode) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
 		}
 	}
+	s += 18 + msgp.Float64Size + 27 + msgp.Float64Size
 	return
 }
 
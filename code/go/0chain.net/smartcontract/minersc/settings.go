@@ -15,6 +15,7 @@ import (
 	"0chain.net/chaincore/smartcontractinterface"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
+	"0chain.net/smartcontract/governancesc"
 )
 
 const x10 float64 = 10 * 1000 * 1000 * 1000
@@ -46,6 +47,8 @@ const (
 	MaxMint
 	OwnerId
 	CooldownPeriod
+	EquivocationSlash
+	EquivocationReporterReward
 	CostAddMiner
 	CostAddSharder
 	CostDeleteMiner
@@ -66,6 +69,8 @@ const (
 	CostSharderKeep
 	CostKillMiner
 	CostKillSharder
+	CostReportEquivocation
+	CostAutoCompound
 	HealthCheckPeriod
 	NumberOfSettings
 )
@@ -115,6 +120,8 @@ func initSettingName() {
 	SettingName[MaxMint] = "max_mint"
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CooldownPeriod] = "cooldown_period"
+	SettingName[EquivocationSlash] = "equivocation_slash"
+	SettingName[EquivocationReporterReward] = "equivocation_reporter_reward"
 	SettingName[HealthCheckPeriod] = "health_check_period"
 	SettingName[CostAddMiner] = "cost.add_miner"
 	SettingName[CostAddSharder] = "cost.add_sharder"
@@ -136,6 +143,8 @@ func initSettingName() {
 	SettingName[CostSharderKeep] = "cost.sharder_keep"
 	SettingName[CostKillMiner] = "cost.kill_miner"
 	SettingName[CostKillSharder] = "cost.kill_sharder"
+	SettingName[CostReportEquivocation] = "cost.report_equivocation"
+	SettingName[CostAutoCompound] = "cost.auto_compound"
 }
 
 func initSettings() {
@@ -167,6 +176,8 @@ func initSettings() {
 		MaxMint.String():                     {MaxMint, config.CurrencyCoin},
 		OwnerId.String():                     {OwnerId, config.Key},
 		CooldownPeriod.String():              {CooldownPeriod, config.Int64},
+		EquivocationSlash.String():           {EquivocationSlash, config.Float64},
+		EquivocationReporterReward.String():  {EquivocationReporterReward, config.Float64},
 		HealthCheckPeriod.String():           {HealthCheckPeriod, config.Duration},
 		CostAddMiner.String():                {CostAddMiner, config.Cost},
 		CostAddSharder.String():              {CostAddSharder, config.Cost},
@@ -188,6 +199,8 @@ func initSettings() {
 		CostSharderKeep.String():             {CostSharderKeep, config.Cost},
 		CostKillMiner.String():               {CostKillMiner, config.Cost},
 		CostKillSharder.String():             {CostKillSharder, config.Cost},
+		CostReportEquivocation.String():      {CostReportEquivocation, config.Cost},
+		CostAutoCompound.String():            {CostAutoCompound, config.Cost},
 	}
 }
 
@@ -273,6 +286,10 @@ func (gn *GlobalNode) setFloat64(key string, change float64) error {
 		gn.MaxCharge = change
 	case RewardDeclineRate:
 		gn.RewardDeclineRate = change
+	case EquivocationSlash:
+		gn.EquivocationSlash = change
+	case EquivocationReporterReward:
+		gn.EquivocationReporterReward = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -410,7 +427,7 @@ func (msc *MinerSmartContract) updateSettings(
 ) (resp string, err error) {
 	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
 		get, _ := gn.Get(OwnerId)
-		return get == t.ClientID
+		return get == t.ClientID || t.ClientID == governancesc.ADDRESS
 	}); err != nil {
 		return "", err
 	}
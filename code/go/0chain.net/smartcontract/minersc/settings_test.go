@@ -220,6 +220,8 @@ func TestUpdateSettings(t *testing.T) {
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.report_equivocation":                     "111",
+					"cost.auto_compound":                           "111",
 				},
 			},
 		},
@@ -1,8 +1,10 @@
 package multisigsc
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 
 	"0chain.net/chaincore/state"
 	"0chain.net/core/common"
@@ -19,6 +21,10 @@ const (
 	MaxSigners   = 20
 	MinSigners   = 2
 	MaxFieldSize = 256
+
+	// MaxCallInputSize is the max size of the input data of a smart contract
+	// call proposal.
+	MaxCallInputSize = 4096
 )
 
 type Wallet struct {
@@ -30,6 +36,10 @@ type Wallet struct {
 	SignerPublicKeys   []string `json:"signer_public_keys"`
 
 	NumRequired int `json:"num_required"`
+
+	// SignersVersion is increased every time the signers or the threshold of
+	// the wallet are changed by a signer change proposal.
+	SignersVersion int64 `json:"signers_version"`
 }
 
 func (w Wallet) Encode() []byte {
@@ -151,10 +161,30 @@ func (w Wallet) isVoteAuthorized(signingClientID string, v Vote) bool {
 		return false
 	}
 
+	if v.Call != nil {
+		return w.verifySignature(publicKey, v.Signature, v.Call.hash(v.Transfer))
+	}
+	if v.SignerChange != nil {
+		return w.verifySignature(publicKey, v.Signature, v.SignerChange.hash(v.Transfer, w.SignersVersion))
+	}
+
 	err := w.makeSignedTransferForVote(publicKey, v).VerifySignature(false)
 	return err == nil
 }
 
+// Verify a signature on the hash of a smart contract call or signer change
+// proposal. Unlike the transfers, these proposals are not signed transfers
+// verified by the blockchain, so the signatures are checked here.
+func (w Wallet) verifySignature(publicKey, signature, hash string) bool {
+	scheme := encryption.GetSignatureScheme(w.SignatureScheme)
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return false
+	}
+
+	ok, err := scheme.Verify(signature, hash)
+	return err == nil && ok
+}
+
 func (w Wallet) makeSignedTransferForVote(signingPublicKey string, v Vote) state.SignedTransfer {
 	return state.SignedTransfer{
 		Transfer:   v.Transfer,
@@ -216,11 +246,17 @@ func (w Wallet) publicKeyForThresholdID(signerThresholdID string) string {
 // y-intercept of this polynomial is the proposal's signature. (This process is
 // called reconstruction in the literature.)
 func (w Wallet) constructTransferSignature(p proposal) (string, error) {
+	return w.constructSignature(p.SignerThresholdIDs, p.SignerSignatures)
+}
+
+// Reconstruct the wallet's signature from the signature shares of the given
+// signers.
+func (w Wallet) constructSignature(signerThresholdIDs, signerSignatures []string) (string, error) {
 	t := w.NumRequired
 	n := len(w.SignerThresholdIDs)
 	rec := encryption.GetReconstructSignatureScheme(w.SignatureScheme, t, n)
 
-	for i, id := range p.SignerThresholdIDs {
+	for i, id := range signerThresholdIDs {
 		publicKey := w.publicKeyForThresholdID(id)
 		if publicKey == "" {
 			// Logic error?
@@ -239,7 +275,7 @@ func (w Wallet) constructTransferSignature(p proposal) (string, error) {
 			return "", err
 		}
 
-		sig := p.SignerSignatures[i]
+		sig := signerSignatures[i]
 
 		err = rec.Add(tss, sig)
 		if err != nil {
@@ -258,10 +294,26 @@ type Vote struct {
 	// Client ID in transfer is that of the multi-sig wallet, not the signer.
 	Transfer state.Transfer `json:"transfer"`
 
+	// Call of a smart contract made by the multi-sig wallet instead of the
+	// transfer. The transfer then goes to the called smart contract and its
+	// amount, which may be zero, is the value of the call.
+	Call *SmartContractCall `json:"call,omitempty"`
+
+	// Change of the signers of the multi-sig wallet instead of the transfer.
+	// The transfer then goes to the multi-sig smart contract with no tokens.
+	SignerChange *SignerChange `json:"signer_change,omitempty"`
+
 	Signature string `json:"signature"`
 }
 
 func (v Vote) notTooBig() bool {
+	if v.Call != nil && !v.Call.notTooBig() {
+		return false
+	}
+	if v.SignerChange != nil && !v.SignerChange.notTooBig() {
+		return false
+	}
+
 	return len(v.ProposalID) <= MaxFieldSize &&
 		len(v.Transfer.ClientID) <= MaxFieldSize &&
 		len(v.Transfer.ToClientID) <= MaxFieldSize &&
@@ -269,7 +321,10 @@ func (v Vote) notTooBig() bool {
 }
 
 func (v Vote) hasValidAmount() bool {
-	return v.Transfer.Amount > 0
+	if v.SignerChange != nil {
+		return v.Transfer.Amount == 0
+	}
+	return v.Call != nil || v.Transfer.Amount > 0
 }
 
 func (v Vote) hasSignature() bool {
@@ -284,7 +339,174 @@ func (v Vote) getProposalRef() proposalRef {
 }
 
 func (v Vote) isCompatibleWithProposal(p proposal) bool {
-	return v.Transfer == p.Transfer
+	if v.Transfer != p.Transfer {
+		return false
+	}
+
+	if v.SignerChange == nil || p.SignerChange == nil {
+		if v.SignerChange != p.SignerChange {
+			return false
+		}
+	} else if !v.SignerChange.equal(p.SignerChange) {
+		return false
+	}
+
+	if v.Call == nil || p.Call == nil {
+		return v.Call == p.Call
+	}
+
+	return *v.Call == *p.Call
+}
+
+// SmartContractCall is a call of a smart contract function proposed for the
+// multi-sig wallet. It's executed with the wallet as the client once the
+// proposal has the required number of votes.
+type SmartContractCall struct {
+	Address      string `json:"address"`
+	FunctionName string `json:"function_name"`
+	InputData    string `json:"input_data"`
+}
+
+func (c *SmartContractCall) Encode() []byte {
+	buff, _ := json.Marshal(c)
+	return buff
+}
+
+func (c *SmartContractCall) notTooBig() bool {
+	return len(c.Address) <= MaxFieldSize &&
+		len(c.FunctionName) <= MaxFieldSize &&
+		len(c.InputData) <= MaxCallInputSize
+}
+
+func (c *SmartContractCall) validate(t state.Transfer) error {
+	if !encryption.IsHash(c.Address) {
+		return common.NewError("err_vote_call_invalid", "invalid smart contract address")
+	}
+	if c.Address == Address {
+		return common.NewError("err_vote_call_invalid", "the multi-sig smart contract can't be called by a proposal")
+	}
+	if c.FunctionName == "" {
+		return common.NewError("err_vote_call_invalid", "missing function name")
+	}
+	if t.ToClientID != c.Address {
+		return common.NewError("err_vote_call_invalid", "transfer must go to the called smart contract")
+	}
+	if c.InputData != "" && !json.Valid([]byte(c.InputData)) {
+		return common.NewError("err_vote_call_invalid", "input data must be JSON")
+	}
+
+	return nil
+}
+
+// The hash the signers of a call proposal sign. It covers the transfer too, so
+// the value of the call and the wallet are signed as well.
+func (c *SmartContractCall) hash(t state.Transfer) string {
+	return encryption.Hash(string(t.Encode()) + string(c.Encode()))
+}
+
+// SignerChange replaces the signers and the threshold of the multi-sig wallet.
+// The signature shares of the new signers have to be dealt off chain from the
+// wallet's key; the first NumRequired new signers prove it by signing the
+// change with their shares.
+//
+// The wallet's key doesn't change, so the removed signers keep valid shares
+// of it. The smart contract rejects their votes, but NumRequired of the old
+// shares still reconstruct the wallet's signature and can sign transactions
+// of the wallet directly. A change doesn't revoke compromised signers; the
+// tokens of such a wallet have to be moved to a new one.
+type SignerChange struct {
+	SignerThresholdIDs []string `json:"signer_threshold_ids"`
+	SignerPublicKeys   []string `json:"signer_public_keys"`
+	NumRequired        int      `json:"num_required"`
+
+	// Signatures of the change by the first NumRequired new signers.
+	ProofSignatures []string `json:"proof_signatures,omitempty"`
+}
+
+func (sc *SignerChange) Encode() []byte {
+	buff, _ := json.Marshal(sc)
+	return buff
+}
+
+func (sc *SignerChange) notTooBig() bool {
+	if len(sc.SignerThresholdIDs) > MaxSigners ||
+		len(sc.SignerPublicKeys) > MaxSigners ||
+		len(sc.ProofSignatures) > MaxSigners {
+		return false
+	}
+	for _, sig := range sc.ProofSignatures {
+		if len(sig) > MaxFieldSize {
+			return false
+		}
+	}
+	// The ids and the keys are checked by Wallet.valid.
+	return true
+}
+
+func (sc *SignerChange) validate(t state.Transfer) error {
+	if t.ToClientID != Address {
+		return common.NewError("err_vote_signer_change_invalid", "transfer must go to the multi-sig smart contract")
+	}
+	if sc.NumRequired < MinSigners || sc.NumRequired > len(sc.SignerThresholdIDs) {
+		return common.NewError("err_vote_signer_change_invalid", "invalid number of signers required")
+	}
+	if len(sc.ProofSignatures) != sc.NumRequired {
+		return common.NewError("err_vote_signer_change_invalid", "the first num_required new signers must sign the change")
+	}
+
+	return nil
+}
+
+func (sc *SignerChange) equal(other *SignerChange) bool {
+	return bytes.Equal(sc.Encode(), other.Encode())
+}
+
+// The hash the signers of a signer change proposal and the first NumRequired
+// new signers sign. It covers the wallet and its signers version, so the
+// change can't be replayed on a later signer set.
+func (sc *SignerChange) hash(t state.Transfer, signersVersion int64) string {
+	change := *sc
+	change.ProofSignatures = nil
+	return encryption.Hash(fmt.Sprintf("%s%d%s", t.Encode(), signersVersion, change.Encode()))
+}
+
+// Returns a copy of the wallet with the signers of the change.
+func (w Wallet) withSigners(sc *SignerChange) Wallet {
+	w.SignerThresholdIDs = sc.SignerThresholdIDs
+	w.SignerPublicKeys = sc.SignerPublicKeys
+	w.NumRequired = sc.NumRequired
+	return w
+}
+
+// SignerSet is a past set of signers of a multi-sig wallet, kept for the
+// audit trail of the signer changes.
+type SignerSet struct {
+	SignersVersion     int64            `json:"signers_version"`
+	SignerThresholdIDs []string         `json:"signer_threshold_ids"`
+	SignerPublicKeys   []string         `json:"signer_public_keys"`
+	NumRequired        int              `json:"num_required"`
+	ReplacedInTxnHash  string           `json:"replaced_in_txn_hash"`
+	ReplacedAt         common.Timestamp `json:"replaced_at"`
+}
+
+// History of the signer sets of a multi-sig wallet, the oldest first.
+type signerHistory struct {
+	ClientID   string      `json:"client_id"`
+	SignerSets []SignerSet `json:"signer_sets"`
+}
+
+func (h *signerHistory) Encode() []byte {
+	buff, _ := json.Marshal(h)
+	return buff
+}
+
+func (h *signerHistory) Decode(input []byte) error {
+	err := json.Unmarshal(input, h)
+	return err
+}
+
+func getSignerHistoryKey(clientID string) datastore.Key {
+	return datastore.Key(Address + encryption.Hash("signer_history"+clientID))
 }
 
 // Uniquely identifies a proposal. Can be used to refer to one.
@@ -317,6 +539,16 @@ type proposal struct {
 
 	Transfer state.Transfer `json:"transfer"`
 
+	// Set for the smart contract call proposals.
+	Call *SmartContractCall `json:"call,omitempty"`
+
+	// Set for the signer change proposals.
+	SignerChange *SignerChange `json:"signer_change,omitempty"`
+
+	// Signers version of the wallet the proposal was made for. The votes for
+	// the proposals of a previous signer set are rejected.
+	SignersVersion int64 `json:"signers_version"`
+
 	// Pertinent data from votes.
 	SignerThresholdIDs []string `json:"signer_threshold_ids"`
 	SignerSignatures   []string `json:"signer_signatures"`
@@ -7,11 +7,360 @@ import (
 )
 
 // MarshalMsg implements msgp.Marshaler
-func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *SignerChange) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "SignerThresholdIDs"
+	o = append(o, 0x84, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
+	for za0001 := range z.SignerThresholdIDs {
+		o = msgp.AppendString(o, z.SignerThresholdIDs[za0001])
+	}
+	// string "SignerPublicKeys"
+	o = append(o, 0xb0, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerPublicKeys)))
+	for za0002 := range z.SignerPublicKeys {
+		o = msgp.AppendString(o, z.SignerPublicKeys[za0002])
+	}
+	// string "NumRequired"
+	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendInt(o, z.NumRequired)
+	// string "ProofSignatures"
+	o = append(o, 0xaf, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ProofSignatures)))
+	for za0003 := range z.ProofSignatures {
+		o = msgp.AppendString(o, z.ProofSignatures[za0003])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SignerChange) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SignerThresholdIDs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerThresholdIDs")
+				return
+			}
+			if cap(z.SignerThresholdIDs) >= int(zb0002) {
+				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0002]
+			} else {
+				z.SignerThresholdIDs = make([]string, zb0002)
+			}
+			for za0001 := range z.SignerThresholdIDs {
+				z.SignerThresholdIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerThresholdIDs", za0001)
+					return
+				}
+			}
+		case "SignerPublicKeys":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerPublicKeys")
+				return
+			}
+			if cap(z.SignerPublicKeys) >= int(zb0003) {
+				z.SignerPublicKeys = (z.SignerPublicKeys)[:zb0003]
+			} else {
+				z.SignerPublicKeys = make([]string, zb0003)
+			}
+			for za0002 := range z.SignerPublicKeys {
+				z.SignerPublicKeys[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerPublicKeys", za0002)
+					return
+				}
+			}
+		case "NumRequired":
+			z.NumRequired, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NumRequired")
+				return
+			}
+		case "ProofSignatures":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProofSignatures")
+				return
+			}
+			if cap(z.ProofSignatures) >= int(zb0004) {
+				z.ProofSignatures = (z.ProofSignatures)[:zb0004]
+			} else {
+				z.ProofSignatures = make([]string, zb0004)
+			}
+			for za0003 := range z.ProofSignatures {
+				z.ProofSignatures[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ProofSignatures", za0003)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SignerChange) Msgsize() (s int) {
+	s = 1 + 19 + msgp.ArrayHeaderSize
+	for za0001 := range z.SignerThresholdIDs {
+		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0002 := range z.SignerPublicKeys {
+		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
+	}
+	s += 12 + msgp.IntSize + 16 + msgp.ArrayHeaderSize
+	for za0003 := range z.ProofSignatures {
+		s += msgp.StringPrefixSize + len(z.ProofSignatures[za0003])
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SignerSet) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// map header, size 6
+	// string "SignersVersion"
+	o = append(o, 0x86, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt64(o, z.SignersVersion)
+	// string "SignerThresholdIDs"
+	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
+	for za0001 := range z.SignerThresholdIDs {
+		o = msgp.AppendString(o, z.SignerThresholdIDs[za0001])
+	}
+	// string "SignerPublicKeys"
+	o = append(o, 0xb0, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerPublicKeys)))
+	for za0002 := range z.SignerPublicKeys {
+		o = msgp.AppendString(o, z.SignerPublicKeys[za0002])
+	}
+	// string "NumRequired"
+	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendInt(o, z.NumRequired)
+	// string "ReplacedInTxnHash"
+	o = append(o, 0xb1, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x49, 0x6e, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68)
+	o = msgp.AppendString(o, z.ReplacedInTxnHash)
+	// string "ReplacedAt"
+	o = append(o, 0xaa, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x41, 0x74)
+	o, err = z.ReplacedAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplacedAt")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SignerSet) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "SignersVersion":
+			z.SignersVersion, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignersVersion")
+				return
+			}
+		case "SignerThresholdIDs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerThresholdIDs")
+				return
+			}
+			if cap(z.SignerThresholdIDs) >= int(zb0002) {
+				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0002]
+			} else {
+				z.SignerThresholdIDs = make([]string, zb0002)
+			}
+			for za0001 := range z.SignerThresholdIDs {
+				z.SignerThresholdIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerThresholdIDs", za0001)
+					return
+				}
+			}
+		case "SignerPublicKeys":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerPublicKeys")
+				return
+			}
+			if cap(z.SignerPublicKeys) >= int(zb0003) {
+				z.SignerPublicKeys = (z.SignerPublicKeys)[:zb0003]
+			} else {
+				z.SignerPublicKeys = make([]string, zb0003)
+			}
+			for za0002 := range z.SignerPublicKeys {
+				z.SignerPublicKeys[za0002], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerPublicKeys", za0002)
+					return
+				}
+			}
+		case "NumRequired":
+			z.NumRequired, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "NumRequired")
+				return
+			}
+		case "ReplacedInTxnHash":
+			z.ReplacedInTxnHash, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplacedInTxnHash")
+				return
+			}
+		case "ReplacedAt":
+			bts, err = z.ReplacedAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplacedAt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SignerSet) Msgsize() (s int) {
+	s = 1 + 15 + msgp.Int64Size + 19 + msgp.ArrayHeaderSize
+	for za0001 := range z.SignerThresholdIDs {
+		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
+	}
+	s += 17 + msgp.ArrayHeaderSize
+	for za0002 := range z.SignerPublicKeys {
+		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
+	}
+	s += 12 + msgp.IntSize + 18 + msgp.StringPrefixSize + len(z.ReplacedInTxnHash) + 11 + z.ReplacedAt.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SmartContractCall) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Address"
+	o = append(o, 0x83, 0xa7, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73)
+	o = msgp.AppendString(o, z.Address)
+	// string "FunctionName"
+	o = append(o, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.FunctionName)
+	// string "InputData"
+	o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendString(o, z.InputData)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SmartContractCall) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Address":
+			z.Address, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Address")
+				return
+			}
+		case "FunctionName":
+			z.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FunctionName")
+				return
+			}
+		case "InputData":
+			z.InputData, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "InputData")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *SmartContractCall) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.Address) + 13 + msgp.StringPrefixSize + len(z.FunctionName) + 10 + msgp.StringPrefixSize + len(z.InputData)
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
 	// string "ClientID"
-	o = append(o, 0x86, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = append(o, 0x87, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ClientID)
 	// string "SignatureScheme"
 	o = append(o, 0xaf, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65)
@@ -34,6 +383,9 @@ func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "NumRequired"
 	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
 	o = msgp.AppendInt(o, z.NumRequired)
+	// string "SignersVersion"
+	o = append(o, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt64(o, z.SignersVersion)
 	return
 }
 
@@ -117,6 +469,12 @@ func (z *Wallet) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "NumRequired")
 				return
 			}
+		case "SignersVersion":
+			z.SignersVersion, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignersVersion")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -139,7 +497,7 @@ func (z *Wallet) Msgsize() (s int) {
 	for za0002 := range z.SignerPublicKeys {
 		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
 	}
-	s += 12 + msgp.IntSize
+	s += 12 + msgp.IntSize + 15 + msgp.Int64Size
 	return
 }
 
@@ -277,9 +635,9 @@ func (z *expirationQueue) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 12
 	// string "ProposalID"
-	o = append(o, 0x89, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
+	o = append(o, 0x8c, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ProposalID)
 	// string "ExpirationDate"
 	o = append(o, 0xae, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65)
@@ -313,6 +671,31 @@ func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Transfer")
 		return
 	}
+	// string "Call"
+	o = append(o, 0xa4, 0x43, 0x61, 0x6c, 0x6c)
+	if z.Call == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Call.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Call")
+			return
+		}
+	}
+	// string "SignerChange"
+	o = append(o, 0xac, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
+	if z.SignerChange == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.SignerChange.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "SignerChange")
+			return
+		}
+	}
+	// string "SignersVersion"
+	o = append(o, 0xae, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt64(o, z.SignersVersion)
 	// string "SignerThresholdIDs"
 	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
@@ -440,6 +823,46 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Transfer")
 				return
 			}
+		case "Call":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Call = nil
+			} else {
+				if z.Call == nil {
+					z.Call = new(SmartContractCall)
+				}
+				bts, err = z.Call.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Call")
+					return
+				}
+			}
+		case "SignerChange":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.SignerChange = nil
+			} else {
+				if z.SignerChange == nil {
+					z.SignerChange = new(SignerChange)
+				}
+				bts, err = z.SignerChange.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerChange")
+					return
+				}
+			}
+		case "SignersVersion":
+			z.SignersVersion, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignersVersion")
+				return
+			}
 		case "SignerThresholdIDs":
 			var zb0004 uint32
 			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
@@ -504,7 +927,19 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *proposal) Msgsize() (s int) {
-	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 19 + msgp.ArrayHeaderSize
+	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 5
+	if z.Call == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Call.Msgsize()
+	}
+	s += 13
+	if z.SignerChange == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.SignerChange.Msgsize()
+	}
+	s += 15 + msgp.Int64Size + 19 + msgp.ArrayHeaderSize
 	for za0001 := range z.SignerThresholdIDs {
 		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
 	}
@@ -576,3 +1011,87 @@ func (z proposalRef) Msgsize() (s int) {
 	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 11 + msgp.StringPrefixSize + len(z.ProposalID)
 	return
 }
+
+// MarshalMsg implements msgp.Marshaler
+func (z *signerHistory) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "ClientID"
+	o = append(o, 0x82, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "SignerSets"
+	o = append(o, 0xaa, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerSets)))
+	for za0001 := range z.SignerSets {
+		o, err = z.SignerSets[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "SignerSets", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *signerHistory) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "SignerSets":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerSets")
+				return
+			}
+			if cap(z.SignerSets) >= int(zb0002) {
+				z.SignerSets = (z.SignerSets)[:zb0002]
+			} else {
+				z.SignerSets = make([]SignerSet, zb0002)
+			}
+			for za0001 := range z.SignerSets {
+				bts, err = z.SignerSets[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SignerSets", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *signerHistory) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 11 + msgp.ArrayHeaderSize
+	for za0001 := range z.SignerSets {
+		s += z.SignerSets[za0001].Msgsize()
+	}
+	return
+}
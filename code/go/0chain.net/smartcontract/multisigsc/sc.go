@@ -14,6 +14,7 @@ import (
 	"0chain.net/chaincore/smartcontractinterface"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
+	"0chain.net/core/datastore"
 	. "github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/util"
 	"go.uber.org/zap"
@@ -63,6 +64,30 @@ func (ms *MultiSigSmartContract) GetCostTable(balances c_state.StateContextI) (m
 	return map[string]int{}, nil
 }
 
+// GetCalls returns the smart contract call proposed by a vote, the vote
+// completing the proposal executes it.
+func (ms *MultiSigSmartContract) GetCalls(funcName string, inputData []byte) ([]smartcontractinterface.SmartContractCall, error) {
+	if funcName != VoteFuncName {
+		return nil, nil
+	}
+
+	var v Vote
+	if err := json.Unmarshal(inputData, &v); err != nil {
+		return nil, err
+	}
+	// the multi-sig smart contract can't be called by a proposal
+	if v.Call == nil || v.Call.Address == Address {
+		return nil, nil
+	}
+
+	call := smartcontractinterface.SmartContractCall{Address: v.Call.Address}
+	call.FunctionName = v.Call.FunctionName
+	if v.Call.InputData != "" {
+		call.InputData = json.RawMessage(v.Call.InputData)
+	}
+	return []smartcontractinterface.SmartContractCall{call}, nil
+}
+
 func (ms *MultiSigSmartContract) Execute(t *transaction.Transaction, funcName string, inputData []byte, balances state.StateContextI) (string, error) {
 	if LogTimingInfo {
 		start := time.Now().UnixNano()
@@ -94,6 +119,9 @@ func (ms MultiSigSmartContract) register(registeringClientID string, inputData [
 		return "err_register_formatting: incorrect request format", err
 	}
 
+	// The signers version is only increased by the signer changes.
+	w.SignersVersion = 0
+
 	// Check for silly parameters that don't make sense. Not a comprehensive
 	// check so errors might still pop up down the line.
 	isValid, err := w.valid(registeringClientID)
@@ -156,6 +184,19 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 	if !v.hasSignature() {
 		return "", common.NewError("err_vote_no_signature", " must sign vote")
 	}
+	if v.Call != nil && v.SignerChange != nil {
+		return "", common.NewError("err_vote_invalid", "a proposal can't both call a smart contract and change the signers")
+	}
+	if v.Call != nil {
+		if err := v.Call.validate(v.Transfer); err != nil {
+			return "", err
+		}
+	}
+	if v.SignerChange != nil {
+		if err := v.SignerChange.validate(v.Transfer); err != nil {
+			return "", err
+		}
+	}
 
 	// Every vote is associated with a proposal. If an appropriate proposal does
 	// not exist yet, create one.
@@ -185,6 +226,14 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "", common.NewError("err_vote_wallet_not_registered", " wallet not registered")
 	}
 
+	// Proposals are made for the current signer set of the wallet, the votes
+	// of a previous signer set can't be combined with the current ones.
+	if len(p.SignerSignatures) == 0 {
+		p.SignersVersion = w.SignersVersion
+	} else if p.SignersVersion != w.SignersVersion {
+		return "", common.NewError("err_vote_signers_changed", " proposal was made for a previous signer set of the wallet")
+	}
+
 	// Check that the voter is registered on the wallet and that the signature
 	// is valid.
 	signerThresholdID := w.thresholdIdForSigner(signingClientID)
@@ -232,11 +281,33 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 
 	p.ClientSignature = thresholdSignature
 
-	// Request the transfer. The blockchain will validate the signature and
-	// execute the transfer soon. If the signature is found to be invalid,
-	// this vote transaction will fail.
-	signedTransfer := w.makeSignedTransferForProposal(p)
-	balances.AddSignedTransfer(&signedTransfer)
+	var msg string
+	switch {
+	case p.SignerChange != nil:
+		// Replace the signers. The open proposals of the wallet can't be
+		// voted for anymore.
+		nw, err := ms.changeSigners(currentTxnHash, now, w, p, balances)
+		if err != nil {
+			return "", err
+		}
+		msg = fmt.Sprintf("success 0: signers changed, signers version %d", nw.SignersVersion)
+	case p.Call != nil:
+		// Execute the call with the multi-sig wallet as the client. If the
+		// call fails, this vote transaction fails and the proposal stays
+		// open for the votes to come.
+		output, err := ms.executeCall(currentTxnHash, now, w, p, balances)
+		if err != nil {
+			return "", err
+		}
+		msg = "success 0: call executed with output " + output
+	default:
+		// Request the transfer. The blockchain will validate the signature and
+		// execute the transfer soon. If the signature is found to be invalid,
+		// this vote transaction will fail.
+		signedTransfer := w.makeSignedTransferForProposal(p)
+		balances.AddSignedTransfer(&signedTransfer)
+		msg = "success 0: transfer executed with signature " + p.ClientSignature
+	}
 
 	// Save the proposal again.
 	p.ExecutedInTxnHash = currentTxnHash
@@ -247,10 +318,132 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "", err
 	}
 
-	msg := "success 0: transfer executed with signature " + p.ClientSignature
 	return msg, nil
 }
 
+// Execute the smart contract call of a proposal with the multi-sig wallet as
+// the client. The call runs in its own state context on top of the state of
+// the vote transaction, its transfers, mints and events are added to the
+// vote's once it succeeds.
+func (ms MultiSigSmartContract) executeCall(currentTxnHash string, now common.Timestamp, w Wallet, p proposal, balances state.StateContextI) (string, error) {
+	// The reconstructed signature is the wallet's signature on the call.
+	if !w.verifySignature(w.PublicKey, p.ClientSignature, p.Call.hash(p.Transfer)) {
+		return "", common.NewError("err_vote_call", "invalid wallet signature on the call")
+	}
+
+	callBalances, ok := balances.(state.CallStateContextI)
+	if !ok {
+		return "", common.NewError("err_vote_call", "smart contract calls are not supported")
+	}
+
+	if p.Transfer.Amount > 0 {
+		balance, err := balances.GetClientBalance(w.ClientID)
+		if err != nil && err != util.ErrValueNotPresent {
+			// I/O error.
+			return "", err
+		}
+		if balance < p.Transfer.Amount {
+			return "", common.NewError("err_vote_call", "insufficient balance of the multi-sig wallet")
+		}
+	}
+
+	scData := transaction.SmartContractData{FunctionName: p.Call.FunctionName}
+	if p.Call.InputData != "" {
+		scData.InputData = json.RawMessage(p.Call.InputData)
+	}
+	data, err := json.Marshal(&scData)
+	if err != nil {
+		return "", err
+	}
+
+	ct := &transaction.Transaction{
+		HashIDField:       datastore.HashIDField{Hash: currentTxnHash},
+		ClientID:          w.ClientID,
+		PublicKey:         w.PublicKey,
+		ToClientID:        p.Call.Address,
+		Value:             p.Transfer.Amount,
+		CreationDate:      now,
+		TransactionType:   transaction.TxnTypeSmartContract,
+		TransactionData:   string(data),
+		SmartContractData: &scData,
+	}
+
+	callCtx := callBalances.NewCallStateContext(ct)
+	output, err := smartcontract.ExecuteSmartContract(ct, callCtx)
+	if err != nil {
+		if state.ErrInvalidState(err) {
+			// Internal error, handled upwards.
+			return "", err
+		}
+		return "", common.NewError("err_vote_call", fmt.Sprintf("%s: %v", ct.FunctionName, err))
+	}
+
+	if err := callCtx.Validate(); err != nil {
+		return "", common.NewError("err_vote_call", fmt.Sprintf("%s: %v", ct.FunctionName, err))
+	}
+
+	callBalances.AddBatchCallResults(callCtx)
+	return output, nil
+}
+
+// Replace the signers of the wallet with the ones of a signer change proposal
+// and add the previous signer set to the wallet's signer history. See
+// SignerChange on the shares of the removed signers.
+func (ms MultiSigSmartContract) changeSigners(currentTxnHash string, now common.Timestamp, w Wallet, p proposal, balances state.StateContextI) (Wallet, error) {
+	hash := p.SignerChange.hash(p.Transfer, p.SignersVersion)
+
+	// The reconstructed signature is the wallet's signature on the change.
+	if !w.verifySignature(w.PublicKey, p.ClientSignature, hash) {
+		return Wallet{}, common.NewError("err_vote_signer_change", "invalid wallet signature on the signer change")
+	}
+
+	nw := w.withSigners(p.SignerChange)
+	if _, err := nw.valid(w.ClientID); err != nil {
+		return Wallet{}, err
+	}
+
+	// The shares of the new signers must reconstruct the wallet's signature,
+	// otherwise the wallet couldn't be used anymore.
+	proof, err := nw.constructSignature(nw.SignerThresholdIDs[:nw.NumRequired], p.SignerChange.ProofSignatures)
+	if err != nil {
+		return Wallet{}, common.NewError("err_vote_signer_change", "in proof signature recovery: "+err.Error())
+	}
+	if !w.verifySignature(w.PublicKey, proof, hash) {
+		return Wallet{}, common.NewError("err_vote_signer_change", "the shares of the new signers don't match the wallet key")
+	}
+
+	h, err := ms.getSignerHistory(w.ClientID, balances)
+	if err != nil {
+		// I/O error.
+		return Wallet{}, err
+	}
+
+	h.SignerSets = append(h.SignerSets, SignerSet{
+		SignersVersion:     w.SignersVersion,
+		SignerThresholdIDs: w.SignerThresholdIDs,
+		SignerPublicKeys:   w.SignerPublicKeys,
+		NumRequired:        w.NumRequired,
+		ReplacedInTxnHash:  currentTxnHash,
+		ReplacedAt:         now,
+	})
+
+	err = ms.putSignerHistory(&h, balances)
+	if err != nil {
+		// I/O error.
+		return Wallet{}, err
+	}
+
+	nw.SignersVersion++
+
+	err = ms.putWallet(nw, balances)
+	if err != nil {
+		// I/O error.
+		return Wallet{}, err
+	}
+
+	return nw, nil
+}
+
 // Prune the oldest proposal if it has expired.
 func (ms MultiSigSmartContract) pruneExpirationQueue(now common.Timestamp, balances state.StateContextI) error {
 	q, err := ms.getOrCreateExpirationQueue(balances)
@@ -392,7 +585,9 @@ func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, bal
 		Next: proposalRef{},
 		Prev: q.Tail,
 
-		Transfer: v.Transfer,
+		Transfer:     v.Transfer,
+		Call:         v.Call,
+		SignerChange: v.SignerChange,
 
 		SignerThresholdIDs: []string{},
 		SignerSignatures:   []string{},
@@ -474,6 +669,22 @@ func (ms MultiSigSmartContract) putWallet(w Wallet, balances c_state.StateContex
 	return err
 }
 
+func (ms MultiSigSmartContract) getSignerHistory(clientID string, balances c_state.StateContextI) (signerHistory, error) {
+	h := signerHistory{ClientID: clientID}
+	err := balances.GetTrieNode(getSignerHistoryKey(clientID), &h)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return h, nil
+	default:
+		return signerHistory{}, err
+	}
+}
+
+func (ms MultiSigSmartContract) putSignerHistory(h *signerHistory, balances c_state.StateContextI) error {
+	_, err := balances.InsertTrieNode(getSignerHistoryKey(h.ClientID), h)
+	return err
+}
+
 func (ms MultiSigSmartContract) getProposal(ref proposalRef, balances c_state.StateContextI) (proposal, error) {
 	p := proposal{}
 	err := balances.GetTrieNode(getProposalKey(ref.ClientID, ref.ProposalID), &p)
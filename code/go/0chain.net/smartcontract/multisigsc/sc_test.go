@@ -0,0 +1,578 @@
+package multisigsc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/core/config/mocks"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logging.InitLogging("testing", "")
+}
+
+var testCallAddress = encryption.Hash("multisig_test_call_sc")
+
+// Smart contract called by the call proposals of the tests. It moves the
+// value of the call to itself like the real smart contracts do.
+type testCallSC struct {
+	fail  bool
+	calls []*transaction.Transaction
+}
+
+func (sc *testCallSC) Execute(t *transaction.Transaction, funcName string, input []byte, balances cstate.StateContextI) (string, error) {
+	if sc.fail {
+		return "", errors.New("call failed")
+	}
+	if t.Value > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(t.ClientID, t.ToClientID, t.Value)); err != nil {
+			return "", err
+		}
+	}
+	sc.calls = append(sc.calls, t)
+	return funcName + " " + string(input), nil
+}
+
+func (sc *testCallSC) GetHandlerStats(ctx context.Context, params url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (sc *testCallSC) GetExecutionStats() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (sc *testCallSC) GetName() string {
+	return "multisig_test_call"
+}
+
+func (sc *testCallSC) GetAddress() string {
+	return testCallAddress
+}
+
+func (sc *testCallSC) GetCostTable(balances cstate.StateContextI) (map[string]int, error) {
+	return map[string]int{"call": 100}, nil
+}
+
+// Multi-sig wallet with the keys of its signers.
+type testWallet struct {
+	Wallet
+	key     encryption.SignatureScheme
+	signers []encryption.ThresholdSignatureScheme
+}
+
+func newTestWallet(t *testing.T, numRequired, numSigners int) testWallet {
+	key := encryption.GetSignatureScheme(encryption.SignatureSchemeBls0chain)
+	require.NoError(t, key.GenerateKeys())
+
+	signers, err := encryption.GenerateThresholdKeyShares(encryption.SignatureSchemeBls0chain, numRequired, numSigners, key)
+	require.NoError(t, err)
+
+	tw := testWallet{
+		Wallet: Wallet{
+			ClientID:        clientIDForKey(t, key.GetPublicKey()),
+			SignatureScheme: encryption.SignatureSchemeBls0chain,
+			PublicKey:       key.GetPublicKey(),
+			NumRequired:     numRequired,
+		},
+		key:     key,
+		signers: signers,
+	}
+	for _, s := range signers {
+		tw.SignerThresholdIDs = append(tw.SignerThresholdIDs, s.GetID())
+		tw.SignerPublicKeys = append(tw.SignerPublicKeys, s.GetPublicKey())
+	}
+	return tw
+}
+
+func clientIDForKey(t *testing.T, publicKey string) string {
+	b, err := hex.DecodeString(publicKey)
+	require.NoError(t, err)
+	return encryption.Hash(b)
+}
+
+func (tw testWallet) signerID(t *testing.T, i int) string {
+	return clientIDForKey(t, tw.signers[i].GetPublicKey())
+}
+
+func sign(t *testing.T, signer encryption.ThresholdSignatureScheme, hash string) string {
+	sig, err := signer.Sign(hash)
+	require.NoError(t, err)
+	return sig
+}
+
+// The vote of the i-th signer of the wallet for a call of the test smart
+// contract.
+func (tw testWallet) callVote(t *testing.T, i int, proposalID, input string, amount currency.Coin) Vote {
+	v := Vote{
+		ProposalID: proposalID,
+		Transfer: state.Transfer{
+			ClientID:   tw.ClientID,
+			ToClientID: testCallAddress,
+			Amount:     amount,
+		},
+		Call: &SmartContractCall{
+			Address:      testCallAddress,
+			FunctionName: "call",
+			InputData:    input,
+		},
+	}
+	v.Signature = sign(t, tw.signers[i], v.Call.hash(v.Transfer))
+	return v
+}
+
+// Chain state the transactions of the tests are executed on. Like on the
+// blockchain, the changes of a transaction are only kept if it succeeds.
+type testChain struct {
+	t     *testing.T
+	ms    *MultiSigSmartContract
+	mpt   util.MerklePatriciaTrieI
+	now   common.Timestamp
+	txnID int
+}
+
+func newTestChain(t *testing.T) *testChain {
+	chainConfig := mocks.NewChainConfig(t)
+	chainConfig.On("IsFeeEnabled").Return(false).Maybe()
+	config.Configuration().ChainConfig = chainConfig
+
+	sc := &testCallSC{}
+	smartcontract.ContractMap[testCallAddress] = sc
+	t.Cleanup(func() { delete(smartcontract.ContractMap, testCallAddress) })
+
+	return &testChain{
+		t:   t,
+		ms:  NewMultiSigSmartContract().(*MultiSigSmartContract),
+		mpt: util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 0, nil),
+		now: common.Now(),
+	}
+}
+
+func (tc *testChain) callSC() *testCallSC {
+	return smartcontract.ContractMap[testCallAddress].(*testCallSC)
+}
+
+// Execute a transaction of the client on a copy of the state, the changes
+// are merged into the state if it succeeds.
+func (tc *testChain) execute(clientID string, f func(txnHash string, balances *cstate.StateContext) (string, error)) (string, *cstate.StateContext, error) {
+	tc.txnID++
+	txn := &transaction.Transaction{
+		HashIDField: datastore.HashIDField{Hash: encryption.Hash(fmt.Sprintf("txn %d", tc.txnID))},
+		ClientID:    clientID,
+		ToClientID:  Address,
+	}
+
+	ndb := util.NewLevelNodeDB(util.NewMemoryNodeDB(), tc.mpt.GetNodeDB(), false)
+	mpt := util.NewMerklePatriciaTrie(ndb, tc.mpt.GetVersion(), tc.mpt.GetRoot())
+	balances := cstate.NewStateContext(nil, mpt, txn, nil, nil, nil, nil, nil, nil)
+
+	out, err := f(txn.Hash, balances)
+	if err != nil {
+		return out, balances, err
+	}
+	require.NoError(tc.t, tc.mpt.MergeMPTChanges(mpt))
+	return out, balances, nil
+}
+
+func (tc *testChain) register(tw testWallet, balance currency.Coin) {
+	input, err := json.Marshal(&tw.Wallet)
+	require.NoError(tc.t, err)
+
+	_, _, err = tc.execute(tw.ClientID, func(txnHash string, balances *cstate.StateContext) (string, error) {
+		s := &state.State{Balance: balance}
+		if err := s.SetTxnHash(txnHash); err != nil {
+			return "", err
+		}
+		if _, err := balances.SetClientState(tw.ClientID, s); err != nil {
+			return "", err
+		}
+		return tc.ms.register(tw.ClientID, input, balances)
+	})
+	require.NoError(tc.t, err)
+}
+
+func (tc *testChain) vote(signerID string, v Vote) (string, *cstate.StateContext, error) {
+	input, err := json.Marshal(&v)
+	require.NoError(tc.t, err)
+
+	return tc.execute(signerID, func(txnHash string, balances *cstate.StateContext) (string, error) {
+		return tc.ms.vote(txnHash, signerID, tc.now, input, balances)
+	})
+}
+
+func (tc *testChain) proposal(clientID, proposalID string) proposal {
+	var p proposal
+	_, _, err := tc.execute(clientID, func(_ string, balances *cstate.StateContext) (string, error) {
+		var err error
+		p, err = tc.ms.getProposal(proposalRef{ClientID: clientID, ProposalID: proposalID}, balances)
+		return "", err
+	})
+	require.NoError(tc.t, err)
+	return p
+}
+
+func TestVoteCallSignatureBinding(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	// A share signed for another input of the call.
+	v := tw.callVote(t, 0, "p1", `{"a":1}`, 0)
+	v.Call.InputData = `{"a":2}`
+	_, _, err := tc.vote(tw.signerID(t, 0), v)
+	require.EqualError(t, err, "err_vote_auth:  authorization failure")
+
+	// A share signed for another value of the call.
+	v = tw.callVote(t, 0, "p1", `{"a":1}`, 0)
+	v.Transfer.Amount = 10
+	_, _, err = tc.vote(tw.signerID(t, 0), v)
+	require.EqualError(t, err, "err_vote_auth:  authorization failure")
+
+	// A share signed for the call by another signer.
+	v = tw.callVote(t, 1, "p1", `{"a":1}`, 0)
+	_, _, err = tc.vote(tw.signerID(t, 0), v)
+	require.EqualError(t, err, "err_vote_auth:  authorization failure")
+
+	_, _, err = tc.vote(tw.signerID(t, 0), tw.callVote(t, 0, "p1", `{"a":1}`, 0))
+	require.NoError(t, err)
+}
+
+func TestVoteCallCompatibility(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	out, _, err := tc.vote(tw.signerID(t, 0), tw.callVote(t, 0, "p1", `{"a":1}`, 0))
+	require.NoError(t, err)
+	require.Equal(t, "success 1: need 1 more votes", out)
+
+	// Properly signed, but for another call than the one of the proposal.
+	_, _, err = tc.vote(tw.signerID(t, 1), tw.callVote(t, 1, "p1", `{"a":2}`, 0))
+	require.EqualError(t, err, "err_vote_not_compatible:  previous votes for same proposal differed")
+
+	require.Empty(t, tc.callSC().calls)
+	require.Empty(t, tc.proposal(tw.ClientID, "p1").ExecutedInTxnHash)
+}
+
+func TestVoteCallTransfer(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	_, _, err := tc.vote(tw.signerID(t, 0), tw.callVote(t, 0, "p1", `{"a":1}`, 10))
+	require.NoError(t, err)
+
+	out, balances, err := tc.vote(tw.signerID(t, 2), tw.callVote(t, 2, "p1", `{"a":1}`, 10))
+	require.NoError(t, err)
+	require.Equal(t, `success 0: call executed with output call {"a":1}`, out)
+
+	// The call is made by the multi-sig wallet and its value goes to the
+	// called smart contract within the vote transaction.
+	calls := tc.callSC().calls
+	require.Len(t, calls, 1)
+	require.Equal(t, tw.ClientID, calls[0].ClientID)
+	require.Equal(t, currency.Coin(10), calls[0].Value)
+	require.Equal(t, []*state.Transfer{state.NewTransfer(tw.ClientID, testCallAddress, 10)}, balances.GetTransfers())
+
+	p := tc.proposal(tw.ClientID, "p1")
+	require.Equal(t, balances.GetTransaction().Hash, p.ExecutedInTxnHash)
+
+	// The call is executed only once.
+	out, _, err = tc.vote(tw.signerID(t, 1), tw.callVote(t, 1, "p1", `{"a":1}`, 10))
+	require.NoError(t, err)
+	require.Equal(t, "success 0: proposal previously executed in transaction hash "+p.ExecutedInTxnHash, out)
+	require.Len(t, tc.callSC().calls, 1)
+
+	// The value can't exceed the balance of the wallet.
+	_, _, err = tc.vote(tw.signerID(t, 0), tw.callVote(t, 0, "p2", `{}`, 1000))
+	require.NoError(t, err)
+	_, _, err = tc.vote(tw.signerID(t, 1), tw.callVote(t, 1, "p2", `{}`, 1000))
+	require.EqualError(t, err, "err_vote_call: insufficient balance of the multi-sig wallet")
+}
+
+func TestVoteCallFailure(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	_, _, err := tc.vote(tw.signerID(t, 0), tw.callVote(t, 0, "p1", `{"a":1}`, 10))
+	require.NoError(t, err)
+
+	// The failed call fails the vote, so the proposal stays open.
+	tc.callSC().fail = true
+	_, _, err = tc.vote(tw.signerID(t, 1), tw.callVote(t, 1, "p1", `{"a":1}`, 10))
+	require.EqualError(t, err, "err_vote_call: call: call failed")
+
+	p := tc.proposal(tw.ClientID, "p1")
+	require.Empty(t, p.ExecutedInTxnHash)
+	require.Len(t, p.SignerSignatures, 1)
+
+	tc.callSC().fail = false
+	out, _, err := tc.vote(tw.signerID(t, 1), tw.callVote(t, 1, "p1", `{"a":1}`, 10))
+	require.NoError(t, err)
+	require.Equal(t, `success 0: call executed with output call {"a":1}`, out)
+	require.Len(t, tc.callSC().calls, 1)
+	require.NotEmpty(t, tc.proposal(tw.ClientID, "p1").ExecutedInTxnHash)
+}
+
+func TestVoteCallMultiSigSC(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	v := Vote{
+		ProposalID: "p1",
+		Transfer:   state.Transfer{ClientID: tw.ClientID, ToClientID: Address},
+		Call:       &SmartContractCall{Address: Address, FunctionName: VoteFuncName, InputData: `{}`},
+	}
+	v.Signature = sign(t, tw.signers[0], v.Call.hash(v.Transfer))
+
+	_, _, err := tc.vote(tw.signerID(t, 0), v)
+	require.EqualError(t, err, "err_vote_call_invalid: the multi-sig smart contract can't be called by a proposal")
+	require.True(t, tc.proposal(tw.ClientID, "p1").isEmpty())
+}
+
+// Deal the shares of a key.
+func dealShares(t *testing.T, key encryption.SignatureScheme, numRequired, numSigners int) []encryption.ThresholdSignatureScheme {
+	signers, err := encryption.GenerateThresholdKeyShares(encryption.SignatureSchemeBls0chain, numRequired, numSigners, key)
+	require.NoError(t, err)
+	return signers
+}
+
+// Change of the wallet to the signers, proved by the first numRequired of them
+// for the given signers version.
+func (tw testWallet) signerChange(t *testing.T, signers []encryption.ThresholdSignatureScheme, numRequired int, signersVersion int64) *SignerChange {
+	change := &SignerChange{NumRequired: numRequired}
+	for _, s := range signers {
+		change.SignerThresholdIDs = append(change.SignerThresholdIDs, s.GetID())
+		change.SignerPublicKeys = append(change.SignerPublicKeys, s.GetPublicKey())
+	}
+
+	hash := change.hash(tw.signerChangeTransfer(), signersVersion)
+	for _, s := range signers[:numRequired] {
+		change.ProofSignatures = append(change.ProofSignatures, sign(t, s, hash))
+	}
+	return change
+}
+
+func (tw testWallet) signerChangeTransfer() state.Transfer {
+	return state.Transfer{ClientID: tw.ClientID, ToClientID: Address}
+}
+
+// The vote of the i-th signer of the wallet for a signer change.
+func (tw testWallet) signerChangeVote(t *testing.T, i int, proposalID string, change *SignerChange) Vote {
+	v := Vote{
+		ProposalID:   proposalID,
+		Transfer:     tw.signerChangeTransfer(),
+		SignerChange: change,
+	}
+	v.Signature = sign(t, tw.signers[i], change.hash(v.Transfer, tw.SignersVersion))
+	return v
+}
+
+// Returns the wallet with the signers of the change.
+func (tw testWallet) withChange(signers []encryption.ThresholdSignatureScheme, change *SignerChange) testWallet {
+	tw.Wallet = tw.withSigners(change)
+	tw.SignersVersion++
+	tw.signers = signers
+	return tw
+}
+
+func (tc *testChain) wallet(clientID string) (w Wallet, h signerHistory) {
+	_, _, err := tc.execute(clientID, func(_ string, balances *cstate.StateContext) (string, error) {
+		var err error
+		if w, err = tc.ms.getWallet(clientID, balances); err != nil {
+			return "", err
+		}
+		h, err = tc.ms.getSignerHistory(clientID, balances)
+		return "", err
+	})
+	require.NoError(tc.t, err)
+	return w, h
+}
+
+func (tc *testChain) balance(clientID string) currency.Coin {
+	var b currency.Coin
+	_, _, err := tc.execute(clientID, func(_ string, balances *cstate.StateContext) (string, error) {
+		var err error
+		b, err = balances.GetClientBalance(clientID)
+		return "", err
+	})
+	require.NoError(tc.t, err)
+	return b
+}
+
+func TestVoteSignerChange(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	signers := dealShares(t, tw.key, 3, 4)
+	change := tw.signerChange(t, signers, 3, 0)
+
+	_, _, err := tc.vote(tw.signerID(t, 0), tw.signerChangeVote(t, 0, "p1", change))
+	require.NoError(t, err)
+	out, balances, err := tc.vote(tw.signerID(t, 1), tw.signerChangeVote(t, 1, "p1", change))
+	require.NoError(t, err)
+	require.Equal(t, "success 0: signers changed, signers version 1", out)
+
+	// The signer change doesn't move the tokens of the wallet.
+	require.Empty(t, balances.GetTransfers())
+	require.Empty(t, balances.GetSignedTransfers())
+	require.Equal(t, currency.Coin(100), tc.balance(tw.ClientID))
+
+	nw := tw.withChange(signers, change)
+
+	w, h := tc.wallet(tw.ClientID)
+	require.Equal(t, nw.Wallet, w)
+	require.Len(t, h.SignerSets, 1)
+	require.Equal(t, int64(0), h.SignerSets[0].SignersVersion)
+	require.Equal(t, tw.SignerPublicKeys, h.SignerSets[0].SignerPublicKeys)
+	require.Equal(t, 2, h.SignerSets[0].NumRequired)
+
+	// The removed signers can't vote anymore.
+	_, _, err = tc.vote(tw.signerID(t, 2), tw.callVote(t, 2, "p2", `{}`, 0))
+	require.EqualError(t, err, "err_vote_auth:  authorization failure")
+
+	// The shares of the new signers reconstruct the wallet's signature.
+	for i := 0; i < 3; i++ {
+		out, _, err = tc.vote(nw.signerID(t, i), nw.callVote(t, i, "p2", `{}`, 0))
+		require.NoError(t, err)
+	}
+	require.Equal(t, "success 0: call executed with output call {}", out)
+}
+
+func TestVoteSignerChangeWrongProof(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	// The new shares are dealt from another key.
+	other := encryption.GetSignatureScheme(encryption.SignatureSchemeBls0chain)
+	require.NoError(t, other.GenerateKeys())
+	change := tw.signerChange(t, dealShares(t, other, 2, 3), 2, 0)
+
+	_, _, err := tc.vote(tw.signerID(t, 0), tw.signerChangeVote(t, 0, "p1", change))
+	require.NoError(t, err)
+	_, _, err = tc.vote(tw.signerID(t, 1), tw.signerChangeVote(t, 1, "p1", change))
+	require.EqualError(t, err, "err_vote_signer_change: the shares of the new signers don't match the wallet key")
+
+	// A proof share of a new signer swapped for the one of another.
+	signers := dealShares(t, tw.key, 2, 3)
+	change = tw.signerChange(t, signers, 2, 0)
+	change.ProofSignatures[1] = sign(t, signers[2], change.hash(tw.signerChangeTransfer(), 0))
+
+	_, _, err = tc.vote(tw.signerID(t, 0), tw.signerChangeVote(t, 0, "p2", change))
+	require.NoError(t, err)
+	_, _, err = tc.vote(tw.signerID(t, 1), tw.signerChangeVote(t, 1, "p2", change))
+	require.EqualError(t, err, "err_vote_signer_change: the shares of the new signers don't match the wallet key")
+
+	w, h := tc.wallet(tw.ClientID)
+	require.Equal(t, tw.Wallet, w)
+	require.Empty(t, h.SignerSets)
+	require.Empty(t, tc.proposal(tw.ClientID, "p1").ExecutedInTxnHash)
+	require.Empty(t, tc.proposal(tw.ClientID, "p2").ExecutedInTxnHash)
+}
+
+func TestVoteSignerChangeReplay(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	// Raise the threshold of the same signers to all of them.
+	change := tw.signerChange(t, tw.signers, 3, 0)
+	votes := []Vote{
+		tw.signerChangeVote(t, 0, "p1", change),
+		tw.signerChangeVote(t, 1, "p1", change),
+	}
+	for i, v := range votes {
+		_, _, err := tc.vote(tw.signerID(t, i), v)
+		require.NoError(t, err)
+	}
+	nw := tw.withChange(tw.signers, change)
+
+	w, _ := tc.wallet(tw.ClientID)
+	require.Equal(t, nw.Wallet, w)
+
+	// The signers are still registered, but their votes for the signers
+	// version 0 can't be replayed on the signers version 1.
+	for i, v := range votes {
+		v.ProposalID = "p2"
+		_, _, err := tc.vote(tw.signerID(t, i), v)
+		require.EqualError(t, err, "err_vote_auth:  authorization failure")
+	}
+
+	// The change must be proved for the current signers version too.
+	for i := 0; i < 3; i++ {
+		_, _, err := tc.vote(nw.signerID(t, i), nw.signerChangeVote(t, i, "p3", change))
+		if i < 2 {
+			require.NoError(t, err)
+			continue
+		}
+		require.EqualError(t, err, "err_vote_signer_change: the shares of the new signers don't match the wallet key")
+	}
+}
+
+func TestVoteSignersChanged(t *testing.T) {
+	tc := newTestChain(t)
+	tw := newTestWallet(t, 2, 3)
+	tc.register(tw, 100)
+
+	// A proposal opened by the old signers.
+	_, _, err := tc.vote(tw.signerID(t, 0), tw.callVote(t, 0, "p1", `{}`, 0))
+	require.NoError(t, err)
+
+	signers := dealShares(t, tw.key, 2, 3)
+	change := tw.signerChange(t, signers, 2, 0)
+	for i := 0; i < 2; i++ {
+		_, _, err = tc.vote(tw.signerID(t, i), tw.signerChangeVote(t, i, "p2", change))
+		require.NoError(t, err)
+	}
+	nw := tw.withChange(signers, change)
+
+	// Its vote can't be combined with the ones of the new signers.
+	_, _, err = tc.vote(nw.signerID(t, 1), nw.callVote(t, 1, "p1", `{}`, 0))
+	require.EqualError(t, err, "err_vote_signers_changed:  proposal was made for a previous signer set of the wallet")
+	require.Empty(t, tc.callSC().calls)
+}
+
+func TestGetCalls(t *testing.T) {
+	ms := NewMultiSigSmartContract().(*MultiSigSmartContract)
+	tw := newTestWallet(t, 2, 3)
+
+	input, err := json.Marshal(tw.callVote(t, 0, "p1", `{"a":1}`, 10))
+	require.NoError(t, err)
+	calls, err := ms.GetCalls(VoteFuncName, input)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Equal(t, testCallAddress, calls[0].Address)
+	require.Equal(t, "call", calls[0].FunctionName)
+	require.JSONEq(t, `{"a":1}`, string(calls[0].InputData))
+
+	// The transfer and the signer change proposals don't call anything.
+	input, err = json.Marshal(tw.signerChangeVote(t, 0, "p2", tw.signerChange(t, tw.signers, 3, 0)))
+	require.NoError(t, err)
+	calls, err = ms.GetCalls(VoteFuncName, input)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+
+	calls, err = ms.GetCalls(RegisterFuncName, nil)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+}
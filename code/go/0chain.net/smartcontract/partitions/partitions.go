@@ -436,6 +436,45 @@ func (p *Partitions) GetRandomItems(state state.StateContextI, r *rand.Rand, vs
 	return setPartitionItems(its, vs)
 }
 
+// GetItems returns all the items of all the partitions, in the partitions order
+func (p *Partitions) GetItems(state state.StateContextI, vs interface{}) error {
+	var its []item
+	for i := 0; i <= p.Last.Loc; i++ {
+		part, err := p.getPartition(state, i)
+		if err != nil {
+			return err
+		}
+
+		pits, err := part.itemRange(0, part.length())
+		if err != nil {
+			return err
+		}
+		its = append(its, pits...)
+	}
+
+	return setPartitionItems(its, vs)
+}
+
+// NumPartitions returns the number of partitions
+func (p *Partitions) NumPartitions() int {
+	return p.Last.Loc + 1
+}
+
+// GetPartitionItems returns the items of the partition at the index
+func (p *Partitions) GetPartitionItems(state state.StateContextI, index int, vs interface{}) error {
+	part, err := p.getPartition(state, index)
+	if err != nil {
+		return err
+	}
+
+	its, err := part.itemRange(0, part.length())
+	if err != nil {
+		return err
+	}
+
+	return setPartitionItems(its, vs)
+}
+
 func (p *Partitions) Size(state state.StateContextI) (int, error) {
 	if p.Last.length() == 0 {
 		return 0, nil
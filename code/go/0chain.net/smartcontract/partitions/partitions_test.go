@@ -840,6 +840,51 @@ func TestGetRandomItems(t *testing.T) {
 	}
 }
 
+func TestGetItems(t *testing.T) {
+	for _, num := range []int{0, 5, 10, 25} {
+		t.Run(fmt.Sprintf("%d items", num), func(t *testing.T) {
+			pn := "test_ps"
+			s := prepareState(t, pn, 10, num)
+			p, err := GetPartitions(s, pn)
+			require.NoError(t, err)
+
+			var its []testItem
+			require.NoError(t, p.GetItems(s, &its))
+			require.Len(t, its, num)
+
+			ids := make(map[string]bool, num)
+			for _, it := range its {
+				ids[it.ID] = true
+			}
+			for i := 0; i < num; i++ {
+				require.True(t, ids[fmt.Sprintf("k%d", i)])
+			}
+		})
+	}
+}
+
+func TestGetPartitionItems(t *testing.T) {
+	pn := "test_ps"
+	s := prepareState(t, pn, 10, 25)
+	p, err := GetPartitions(s, pn)
+	require.NoError(t, err)
+	require.Equal(t, 3, p.NumPartitions())
+
+	ids := make(map[string]bool, 25)
+	for i, l := range []int{10, 10, 5} {
+		var its []testItem
+		require.NoError(t, p.GetPartitionItems(s, i, &its))
+		require.Len(t, its, l)
+		for _, it := range its {
+			ids[it.ID] = true
+		}
+	}
+	require.Len(t, ids, 25)
+
+	var its []testItem
+	require.Error(t, p.GetPartitionItems(s, 3, &its))
+}
+
 func FuzzAdd(f *testing.F) {
 	rand.Seed(time.Now().UnixNano())
 	f.Add(10)
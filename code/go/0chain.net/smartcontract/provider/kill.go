@@ -11,6 +11,7 @@ import (
 
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/smartcontract/governancesc"
 )
 
 type ProviderRequest struct {
@@ -45,7 +46,7 @@ func Kill(
 
 	var errCode = "kill_" + p.Type().String() + "_failed"
 	if err := smartcontractinterface.AuthorizeWithOwner(errCode, func() bool {
-		return ownerId == clientID
+		return ownerId == clientID || clientID == governancesc.ADDRESS
 	}); err != nil {
 		return err
 	}
@@ -20,9 +20,15 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
 )
 
+func init() {
+	logging.Logger = zap.NewNop()
+}
+
 //
 // helper for tests implements chainState.StateContextI
 //
@@ -0,0 +1,232 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// AutoCompoundRequest opts a delegate pool in or out of the compounding of
+// its rewards
+type AutoCompoundRequest struct {
+	ProviderType spenum.Provider `json:"provider_type,omitempty"`
+	ProviderID   string          `json:"provider_id,omitempty"`
+	AutoCompound bool            `json:"auto_compound"`
+}
+
+func (acr *AutoCompoundRequest) Encode() []byte {
+	bytes, _ := json.Marshal(acr)
+	return bytes
+}
+
+func (acr *AutoCompoundRequest) decode(p []byte) error {
+	return json.Unmarshal(p, acr)
+}
+
+// CompoundLimits are the limits of the compounded stakes of a provider, zero
+// is no limit
+type CompoundLimits struct {
+	// MaxStake of a delegate pool
+	MaxStake currency.Coin
+	// MaxTotalStake of the provider, e.g. the stake covering the capacity of
+	// a blobber
+	MaxTotalStake currency.Coin
+}
+
+// CompoundLimitsFunc returns the compound limits of the provider with the
+// current config of the SC holding its stake
+type CompoundLimitsFunc func(providerID string, balances cstate.CommonStateContextI) (CompoundLimits, error)
+
+var compoundLimitsFuncs = make(map[spenum.Provider]CompoundLimitsFunc)
+
+// SetCompoundLimitsFunc sets the compound limits of the provider type, the
+// SCs holding the stakes set them on init
+func SetCompoundLimitsFunc(providerType spenum.Provider, f CompoundLimitsFunc) {
+	compoundLimitsFuncs[providerType] = f
+}
+
+func getCompoundLimits(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (CompoundLimits, error) {
+	f, ok := compoundLimitsFuncs[providerType]
+	if !ok {
+		return CompoundLimits{}, nil
+	}
+	return f(providerID, balances)
+}
+
+// stakeHolder returns the minter of the SC holding the stakes of the provider
+// type, the stakes are locked to the SC of the provider
+func stakeHolder(providerType spenum.Provider) (cstate.ApprovedMinter, error) {
+	switch providerType {
+	case spenum.Miner, spenum.Sharder:
+		return cstate.MinterMiner, nil
+	case spenum.Blobber, spenum.Validator:
+		return cstate.MinterStorage, nil
+	case spenum.Authorizer:
+		return cstate.MinterZcn, nil
+	default:
+		return 0, fmt.Errorf("unknown provider type: %v", providerType)
+	}
+}
+
+// StakePoolAutoCompound sets the auto-compounding of the delegate pool of the
+// client, the rewards are compounded up to the compound limits of the provider
+func StakePoolAutoCompound(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	get func(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	var acr AutoCompoundRequest
+	if err = acr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"invalid request: %v", err)
+	}
+
+	var sp AbstractStakePool
+	if sp, err = get(acr.ProviderType, acr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"can't get stake pool: %v", err)
+	}
+	dp, ok := sp.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"no such delegate pool: %v", t.ClientID)
+	}
+
+	dp.AutoCompound = acr.AutoCompound
+
+	if err = sp.Save(acr.ProviderType, acr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"saving stake pool: %v", err)
+	}
+
+	return toJson(dp), nil
+}
+
+func (dp *DelegatePool) compounding() bool {
+	return dp.AutoCompound && dp.Reward > 0 &&
+		(dp.Status == spenum.Active || dp.Status == spenum.Pending)
+}
+
+// compoundable returns the rewards of the delegate pool to move to its stake,
+// total is the stake of the provider
+func (dp *DelegatePool) compoundable(limits CompoundLimits, total currency.Coin) currency.Coin {
+	if !dp.compounding() {
+		return 0
+	}
+	amount := dp.Reward
+	if limits.MaxStake > 0 {
+		if dp.Balance >= limits.MaxStake {
+			return 0
+		}
+		if room := limits.MaxStake - dp.Balance; room < amount {
+			amount = room
+		}
+	}
+	if limits.MaxTotalStake > 0 {
+		if total >= limits.MaxTotalStake {
+			return 0
+		}
+		if room := limits.MaxTotalStake - total; room < amount {
+			amount = room
+		}
+	}
+	return amount
+}
+
+// compoundRewards moves the rewards of the auto-compounding delegate pools
+// to their stake; the rewards are minted to the SC holding the stake, as the
+// collect_reward would mint them to the delegates
+func (sp *StakePool) compoundRewards(
+	pools []*DelegatePool,
+	providerId string,
+	providerType spenum.Provider,
+	balances cstate.StateContextI,
+) error {
+	var compounding bool
+	for _, dp := range pools {
+		if dp.compounding() {
+			compounding = true
+			break
+		}
+	}
+	if !compounding {
+		return nil
+	}
+
+	limits, err := getCompoundLimits(providerType, providerId, balances)
+	if err != nil {
+		return fmt.Errorf("getting compound limits: %v", err)
+	}
+	total, err := sp.stake()
+	if err != nil {
+		return err
+	}
+	holder, err := stakeHolder(providerType)
+	if err != nil {
+		return err
+	}
+	minter, err := cstate.GetMinter(holder)
+	if err != nil {
+		return err
+	}
+
+	var compounded bool
+	for _, dp := range pools {
+		amount := dp.compoundable(limits, total)
+		if amount == 0 {
+			continue
+		}
+
+		if err := balances.AddMint(&state.Mint{
+			Minter:     minter,
+			ToClientID: minter,
+			Amount:     amount,
+		}); err != nil {
+			return err
+		}
+
+		if dp.Balance, err = currency.AddCoin(dp.Balance, amount); err != nil {
+			return err
+		}
+		if total, err = currency.AddCoin(total, amount); err != nil {
+			return err
+		}
+		dp.Reward -= amount
+		compounded = true
+
+		i, _ := amount.Int64()
+		balances.EmitEvent(event.TypeStats, event.TagMintReward, dp.DelegateID, event.RewardMint{
+			Amount:       i,
+			BlockNumber:  balances.GetBlock().Round,
+			ClientID:     dp.DelegateID,
+			ProviderType: providerType.String(),
+			ProviderID:   providerId,
+		})
+		balances.EmitEvent(event.TypeStats, event.TagUpdateUserCollectedRewards, dp.DelegateID, event.UserAggregate{
+			CollectedReward: i,
+			UserID:          dp.DelegateID,
+		})
+		balances.EmitEvent(event.TypeStats, event.TagLockStakePool, dp.DelegateID, event.DelegatePoolLock{
+			Client:       dp.DelegateID,
+			ProviderId:   providerId,
+			ProviderType: providerType,
+			Amount:       i,
+			Total:        i,
+		})
+
+		update := newDelegatePoolUpdate(dp.DelegateID, providerId, providerType)
+		update.Updates["balance"] = dp.Balance
+		update.Updates["reward"] = dp.Reward
+		update.emitUpdate(balances)
+	}
+
+	if !compounded {
+		return nil
+	}
+	return sp.EmitStakeEvent(providerType, providerId, balances)
+}
@@ -0,0 +1,78 @@
+package stakepool
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakePoolAutoCompound(t *testing.T) {
+	sp := NewStakePool()
+	sp.Pools["d1"] = &DelegatePool{DelegateID: "d1", Balance: 100, Status: spenum.Active}
+	sp.Pools["d2"] = &DelegatePool{DelegateID: "d2", Balance: 100, Status: spenum.Active}
+	balances := newTestBalances(t, false)
+
+	limits := CompoundLimits{MaxStake: 150}
+	SetCompoundLimitsFunc(spenum.Blobber, func(string, state.CommonStateContextI) (CompoundLimits, error) {
+		return limits, nil
+	})
+	t.Cleanup(func() { delete(compoundLimitsFuncs, spenum.Blobber) })
+
+	get := func(spenum.Provider, string, state.CommonStateContextI) (AbstractStakePool, error) {
+		return sp, nil
+	}
+
+	autoCompound := func(clientID string, on bool) error {
+		txn := &transaction.Transaction{ClientID: clientID, ToClientID: "stake_pool_sc"}
+		balances.setTransaction(t, txn)
+		input := (&AutoCompoundRequest{
+			ProviderType: spenum.Blobber,
+			ProviderID:   "provider_id",
+			AutoCompound: on,
+		}).Encode()
+		_, err := StakePoolAutoCompound(txn, input, balances, get)
+		return err
+	}
+
+	require.Error(t, autoCompound("d3", true), "no such delegate pool")
+	require.NoError(t, autoCompound("d1", true))
+	require.True(t, sp.Pools["d1"].AutoCompound)
+
+	require.NoError(t, sp.DistributeRewards(80, "provider_id", spenum.Blobber, spenum.BlockRewardBlobber, balances))
+	require.EqualValues(t, 140, sp.Pools["d1"].Balance)
+	require.Zero(t, sp.Pools["d1"].Reward)
+	require.EqualValues(t, 100, sp.Pools["d2"].Balance, "not compounding")
+	require.EqualValues(t, 40, sp.Pools["d2"].Reward)
+
+	// the stake is compounded up to the max stake, the rest is kept as rewards
+	require.NoError(t, sp.DistributeRewards(60, "provider_id", spenum.Blobber, spenum.BlockRewardBlobber, balances))
+	require.EqualValues(t, 150, sp.Pools["d1"].Balance)
+	require.EqualValues(t, 25, sp.Pools["d1"].Reward)
+
+	// the limits follow the config, the total stake of the provider is
+	// capped too
+	limits = CompoundLimits{MaxStake: 300, MaxTotalStake: 260}
+	require.NoError(t, sp.DistributeRewards(20, "provider_id", spenum.Blobber, spenum.BlockRewardBlobber, balances))
+	require.EqualValues(t, 160, sp.Pools["d1"].Balance)
+	require.EqualValues(t, 27, sp.Pools["d1"].Reward)
+
+	require.NoError(t, autoCompound("d1", false))
+	require.False(t, sp.Pools["d1"].AutoCompound)
+}
+
+func TestStakeHolder(t *testing.T) {
+	for providerType, minter := range map[spenum.Provider]state.ApprovedMinter{
+		spenum.Miner:      state.MinterMiner,
+		spenum.Sharder:    state.MinterMiner,
+		spenum.Blobber:    state.MinterStorage,
+		spenum.Validator:  state.MinterStorage,
+		spenum.Authorizer: state.MinterZcn,
+	} {
+		holder, err := stakeHolder(providerType)
+		require.NoError(t, err)
+		require.Equal(t, minter, holder, providerType.String())
+	}
+}
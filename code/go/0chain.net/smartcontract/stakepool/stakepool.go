@@ -2,7 +2,6 @@ package stakepool
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -41,7 +40,7 @@ type AbstractStakePool interface {
 	Save(providerType spenum.Provider, providerID string,
 		balances cstate.StateContextI) error
 	GetSettings() Settings
-	Empty(sscID, poolID, clientID string, balances cstate.StateContextI) error
+	Unstake(clientID string, amount currency.Coin, releaseAt common.Timestamp) error
 	UnlockPool(clientID string, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) (string, error)
 	DeletePool(clientID string, providerType spenum.Provider, providerId datastore.Key, balances cstate.StateContextI) error
 	Kill(float64, string, spenum.Provider, cstate.StateContextI) error
@@ -72,6 +71,18 @@ type DelegatePool struct {
 	RoundCreated int64             `json:"round_created"` // used for cool down
 	DelegateID   string            `json:"delegate_id"`
 	StakedAt     common.Timestamp  `json:"staked_at"`
+	// Unbonding is the unstaked tokens waiting for the unbonding period
+	Unbonding []UnbondingStake `json:"unbonding,omitempty"`
+	// AutoCompound moves the rewards to the stake on each reward
+	// distribution, up to the compound limits of the provider
+	AutoCompound bool `json:"auto_compound,omitempty"`
+}
+
+// UnbondingStake is an unstaked amount of a delegate pool, it doesn't earn
+// rewards but can be slashed until it's released
+type UnbondingStake struct {
+	Amount    currency.Coin    `json:"amount"`
+	ReleaseAt common.Timestamp `json:"release_at"`
 }
 
 // swagger:model stakePoolStat
@@ -318,27 +329,6 @@ func (sp *StakePool) MintRewards(
 	return delegateReward + serviceCharge, nil
 }
 
-func (sp *StakePool) Empty(sscID, poolID, clientID string, balances cstate.StateContextI) error {
-	var dp, ok = sp.Pools[poolID]
-	if !ok {
-		return fmt.Errorf("no such delegate pool: %q", poolID)
-	}
-
-	if dp.DelegateID != clientID {
-		return errors.New("trying to unlock not by delegate pool owner")
-	}
-
-	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
-	if err := balances.AddTransfer(transfer); err != nil {
-		return err
-	}
-
-	sp.Pools[poolID].Balance = 0
-	sp.Pools[poolID].Status = spenum.Deleted
-
-	return nil
-}
-
 // SlashFraction
 // slash stake pools funds, if a provider is killed
 func (sp *StakePool) SlashFraction(
@@ -368,6 +358,9 @@ func (sp *StakePool) SlashFraction(
 		if err != nil {
 			return err
 		}
+		if _, err = dp.SlashUnbonding(killSlashFraction); err != nil {
+			return err
+		}
 	}
 	sp.EmitStakePoolBalanceUpdate(providerId, providerType, balances)
 	return nil
@@ -474,7 +467,7 @@ func (sp *StakePool) DistributeRewardsRandN(
 	if err := spUpdate.Emit(event.TagStakePoolReward, balances); err != nil {
 		return err
 	}
-	return nil
+	return sp.compoundRewards(pools, providerId, providerType, balances)
 }
 
 func (sp *StakePool) getRandPools(seed int64, n int) []*DelegatePool {
@@ -647,7 +640,7 @@ func (sp *StakePool) DistributeRewards(
 		return err
 	}
 
-	return nil
+	return sp.compoundRewards(sp.GetOrderedPools(), providerId, providerType, balances)
 }
 
 func (sp *StakePool) stake() (stake currency.Coin, err error) {
@@ -715,6 +708,8 @@ func equallyDistributeRewards(coins currency.Coin, pools []*DelegatePool, spUpda
 type StakePoolRequest struct {
 	ProviderType spenum.Provider `json:"provider_type,omitempty"`
 	ProviderID   string          `json:"provider_id,omitempty"`
+	// Amount to unstake, the whole stake if zero
+	Amount currency.Coin `json:"amount,omitempty"`
 }
 
 func (spr *StakePoolRequest) Encode() []byte {
@@ -806,7 +801,9 @@ func validateLockRequest(t *transaction.Transaction, sp AbstractStakePool, vs Va
 	return "", nil
 }
 
-// StakePoolUnlock unlock tokens from provider, stake pool can return excess tokens from stake pool
+// StakePoolUnlock unstakes tokens from provider, the unstaked tokens enter the
+// unbonding queue of the delegate pool and are released by this or a later
+// call once the unbonding period elapsed
 func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
 	get func(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (AbstractStakePool, error),
 ) (resp string, err error) {
@@ -821,16 +818,18 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 		return "", common.NewErrorf("stake_pool_unlock_failed",
 			"can't get related stake pool: %v", err)
 	}
-	if err != nil {
-		return "", err
-	}
 	dp, ok := sp.GetPools()[t.ClientID]
 	if !ok {
 		return "", common.NewErrorf("stake_pool_unlock_failed", "no such delegate pool: %v ", t.ClientID)
 	}
 
+	amount := spr.Amount
+	if amount == 0 || amount > dp.Balance {
+		amount = dp.Balance
+	}
+
 	// if StakeAt has valid value and lock period is less than MinLockPeriod
-	if dp.StakedAt > 0 {
+	if amount > 0 && dp.StakedAt > 0 {
 		stakedAt := common.ToTime(dp.StakedAt)
 		minLockPeriod := config.SmartContractConfig.GetDuration("stakepool.min_lock_period")
 		if !stakedAt.Add(minLockPeriod).Before(time.Now()) {
@@ -838,21 +837,44 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 		}
 	}
 
-	output, err := sp.UnlockPool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
+	var output string
+	if amount > 0 {
+		releaseAt := t.CreationDate + common.Timestamp(UnbondingPeriod()/time.Second)
+		if err = sp.Unstake(t.ClientID, amount, releaseAt); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"unstaking tokens: %v", err)
+		}
+		output = dp.emitUnstake(t.ClientID, spr.ProviderID, spr.ProviderType, amount, balances)
 	}
 
-	err = sp.Empty(t.ToClientID, t.ClientID, t.ClientID, balances)
+	released, err := dp.releaseUnbonded(t.CreationDate)
 	if err != nil {
 		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"unlocking tokens: %v", err)
+			"releasing unbonded tokens: %v", err)
+	}
+	if released > 0 {
+		if err = balances.AddTransfer(state.NewTransfer(t.ToClientID, t.ClientID, released)); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"unlocking tokens: %v", err)
+		}
 	}
 
-	err = sp.DeletePool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"deleting stake pool: %v", err)
+	switch {
+	case dp.Balance == 0 && len(dp.Unbonding) == 0:
+		// the delegate left the provider, the rewards are paid
+		if output, err = sp.UnlockPool(t.ClientID, spr.ProviderType, spr.ProviderID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
+		}
+		dp.Status = spenum.Deleted
+		if err = sp.DeletePool(t.ClientID, spr.ProviderType, spr.ProviderID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"deleting stake pool: %v", err)
+		}
+	case amount == 0 && released == 0:
+		return "", common.NewError("stake_pool_unlock_failed",
+			"no stake to unlock and no unbonded tokens to release")
+	case output == "":
+		output = toJson(UnbondingRelease{Released: released, Unbonding: dp.Unbonding})
 	}
 
 	// Save the pool
@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *DelegatePool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 8
 	// string "Balance"
-	o = append(o, 0x86, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o = append(o, 0x88, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
 	o, err = z.Balance.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Balance")
@@ -44,6 +44,29 @@ func (z *DelegatePool) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "StakedAt")
 		return
 	}
+	// string "Unbonding"
+	o = append(o, 0xa9, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Unbonding)))
+	for za0001 := range z.Unbonding {
+		// map header, size 2
+		// string "Amount"
+		o = append(o, 0x82, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+		o, err = z.Unbonding[za0001].Amount.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Unbonding", za0001, "Amount")
+			return
+		}
+		// string "ReleaseAt"
+		o = append(o, 0xa9, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x74)
+		o, err = z.Unbonding[za0001].ReleaseAt.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Unbonding", za0001, "ReleaseAt")
+			return
+		}
+	}
+	// string "AutoCompound"
+	o = append(o, 0xac, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendBool(o, z.AutoCompound)
 	return
 }
 
@@ -101,6 +124,60 @@ func (z *DelegatePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "StakedAt")
 				return
 			}
+		case "Unbonding":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbonding")
+				return
+			}
+			if cap(z.Unbonding) >= int(zb0002) {
+				z.Unbonding = (z.Unbonding)[:zb0002]
+			} else {
+				z.Unbonding = make([]UnbondingStake, zb0002)
+			}
+			for za0001 := range z.Unbonding {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Unbonding", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Unbonding", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Amount":
+						bts, err = z.Unbonding[za0001].Amount.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Unbonding", za0001, "Amount")
+							return
+						}
+					case "ReleaseAt":
+						bts, err = z.Unbonding[za0001].ReleaseAt.UnmarshalMsg(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Unbonding", za0001, "ReleaseAt")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Unbonding", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "AutoCompound":
+			z.AutoCompound, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoCompound")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -115,7 +192,11 @@ func (z *DelegatePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *DelegatePool) Msgsize() (s int) {
-	s = 1 + 8 + z.Balance.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Status.Msgsize() + 13 + msgp.Int64Size + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 9 + z.StakedAt.Msgsize()
+	s = 1 + 8 + z.Balance.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Status.Msgsize() + 13 + msgp.Int64Size + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 9 + z.StakedAt.Msgsize() + 10 + msgp.ArrayHeaderSize
+	for za0001 := range z.Unbonding {
+		s += 1 + 7 + z.Unbonding[za0001].Amount.Msgsize() + 10 + z.Unbonding[za0001].ReleaseAt.Msgsize()
+	}
+	s += 13 + msgp.BoolSize
 	return
 }
 
@@ -545,9 +626,9 @@ func (z *StakePool) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *StakePoolRequest) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 3
 	// string "ProviderType"
-	o = append(o, 0x82, 0xac, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65)
+	o = append(o, 0x83, 0xac, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65)
 	o, err = z.ProviderType.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "ProviderType")
@@ -556,6 +637,13 @@ func (z *StakePoolRequest) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "ProviderID"
 	o = append(o, 0xaa, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ProviderID)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
 	return
 }
 
@@ -589,6 +677,12 @@ func (z *StakePoolRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ProviderID")
 				return
 			}
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -603,7 +697,7 @@ func (z *StakePoolRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *StakePoolRequest) Msgsize() (s int) {
-	s = 1 + 13 + z.ProviderType.Msgsize() + 11 + msgp.StringPrefixSize + len(z.ProviderID)
+	s = 1 + 13 + z.ProviderType.Msgsize() + 11 + msgp.StringPrefixSize + len(z.ProviderID) + 7 + z.Amount.Msgsize()
 	return
 }
 
@@ -757,6 +851,75 @@ func (z *StakePoolStat) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *UnbondingStake) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "Amount"
+	o = append(o, 0x82, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	// string "ReleaseAt"
+	o = append(o, 0xa9, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x41, 0x74)
+	o, err = z.ReleaseAt.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ReleaseAt")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *UnbondingStake) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		case "ReleaseAt":
+			bts, err = z.ReleaseAt.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReleaseAt")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *UnbondingStake) Msgsize() (s int) {
+	s = 1 + 7 + z.Amount.Msgsize() + 10 + z.ReleaseAt.Msgsize()
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z UserPoolStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -2,6 +2,11 @@ package stakepool
 
 import (
 	"fmt"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"github.com/0chain/common/core/currency"
 
 	"0chain.net/smartcontract/dbs/event"
 
@@ -60,3 +65,112 @@ func (sp *StakePool) DeletePool(clientID string, providerType spenum.Provider, p
 
 	return nil
 }
+
+// UnbondingPeriod is the time the unstaked tokens stay in the unbonding
+// queue, zero releases them at once
+func UnbondingPeriod() time.Duration {
+	return config.SmartContractConfig.GetDuration("smart_contracts.stakepool.unbonding_period")
+}
+
+// UnbondingRelease is the response of a partial unstake or of a release
+type UnbondingRelease struct {
+	Released  currency.Coin    `json:"released"`
+	Unbonding []UnbondingStake `json:"unbonding"`
+}
+
+// Unstake moves the amount of the delegate pool to its unbonding queue, the
+// amount stops earning rewards at once
+func (sp *StakePool) Unstake(clientID string, amount currency.Coin, releaseAt common.Timestamp) error {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return fmt.Errorf("can't find pool of %v", clientID)
+	}
+	if amount == 0 || amount > dp.Balance {
+		return fmt.Errorf("invalid amount to unstake %v, staked %v", amount, dp.Balance)
+	}
+
+	dp.Balance -= amount
+	dp.Unbonding = append(dp.Unbonding, UnbondingStake{
+		Amount:    amount,
+		ReleaseAt: releaseAt,
+	})
+	return nil
+}
+
+// Unbonding returns the tokens of all the delegate pools in the unbonding
+// queues
+func (sp *StakePool) Unbonding() (total currency.Coin, err error) {
+	for _, dp := range sp.GetOrderedPools() {
+		unbonding, err := dp.unbonding()
+		if err != nil {
+			return 0, err
+		}
+		if total, err = currency.AddCoin(total, unbonding); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+func (dp *DelegatePool) unbonding() (total currency.Coin, err error) {
+	for _, us := range dp.Unbonding {
+		if total, err = currency.AddCoin(total, us.Amount); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// releaseUnbonded removes the unbonding stakes of the elapsed unbonding
+// periods, returning the tokens to release
+func (dp *DelegatePool) releaseUnbonded(now common.Timestamp) (released currency.Coin, err error) {
+	var left []UnbondingStake
+	for _, us := range dp.Unbonding {
+		if us.ReleaseAt > now {
+			left = append(left, us)
+			continue
+		}
+		if released, err = currency.AddCoin(released, us.Amount); err != nil {
+			return 0, err
+		}
+	}
+	dp.Unbonding = left
+	return released, nil
+}
+
+// SlashUnbonding slashes the fraction of the unbonding stakes, returning
+// the slashed tokens
+func (dp *DelegatePool) SlashUnbonding(fraction float64) (slashed currency.Coin, err error) {
+	for i := range dp.Unbonding {
+		slash, err := currency.MultFloat64(dp.Unbonding[i].Amount, fraction)
+		if err != nil {
+			return 0, err
+		}
+		if slash > dp.Unbonding[i].Amount {
+			slash = dp.Unbonding[i].Amount
+		}
+		dp.Unbonding[i].Amount -= slash
+		if slashed, err = currency.AddCoin(slashed, slash); err != nil {
+			return 0, err
+		}
+	}
+	return slashed, nil
+}
+
+func (dp *DelegatePool) emitUnstake(clientID string, providerId datastore.Key, providerType spenum.Provider,
+	amount currency.Coin, balances cstate.StateContextI) string {
+	i, _ := amount.Int64()
+	lock := event.DelegatePoolLock{
+		Client:       clientID,
+		ProviderId:   providerId,
+		ProviderType: providerType,
+		Amount:       i,
+		Total:        i,
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUnlockStakePool, clientID, lock)
+
+	update := newDelegatePoolUpdate(clientID, providerId, providerType)
+	update.Updates["balance"] = dp.Balance
+	update.emitUpdate(balances)
+	return toJson(lock)
+}
@@ -0,0 +1,129 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/config"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakePool_Unstake(t *testing.T) {
+	sp := NewStakePool()
+	sp.Pools["d1"] = &DelegatePool{DelegateID: "d1", Balance: 100}
+	sp.Pools["d2"] = &DelegatePool{DelegateID: "d2", Balance: 100}
+
+	require.Error(t, sp.Unstake("d3", 10, 10), "no such pool")
+	require.Error(t, sp.Unstake("d1", 0, 10), "zero amount")
+	require.Error(t, sp.Unstake("d1", 101, 10), "more than staked")
+
+	require.NoError(t, sp.Unstake("d1", 40, 10))
+	require.NoError(t, sp.Unstake("d1", 20, 20))
+	require.EqualValues(t, 40, sp.Pools["d1"].Balance)
+	require.Len(t, sp.Pools["d1"].Unbonding, 2)
+
+	unbonding, err := sp.Unbonding()
+	require.NoError(t, err)
+	require.EqualValues(t, 60, unbonding)
+
+	// the unbonding tokens don't earn rewards
+	balances := newTestBalances(t, false)
+	require.NoError(t, sp.DistributeRewards(140, "provider_id", spenum.Blobber, spenum.BlockRewardBlobber, balances))
+	require.EqualValues(t, 40, sp.Pools["d1"].Reward)
+	require.EqualValues(t, 100, sp.Pools["d2"].Reward)
+
+	dp := sp.Pools["d1"]
+	released, err := dp.releaseUnbonded(9)
+	require.NoError(t, err)
+	require.Zero(t, released)
+	released, err = dp.releaseUnbonded(15)
+	require.NoError(t, err)
+	require.EqualValues(t, 40, released)
+	require.Equal(t, []UnbondingStake{{Amount: 20, ReleaseAt: 20}}, dp.Unbonding)
+}
+
+func TestDelegatePool_SlashUnbonding(t *testing.T) {
+	dp := &DelegatePool{
+		Balance: 100,
+		Unbonding: []UnbondingStake{
+			{Amount: 50, ReleaseAt: 10},
+			{Amount: 30, ReleaseAt: 20},
+		},
+	}
+
+	slashed, err := dp.SlashUnbonding(0.1)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, slashed)
+	require.EqualValues(t, 45, dp.Unbonding[0].Amount)
+	require.EqualValues(t, 27, dp.Unbonding[1].Amount)
+	require.EqualValues(t, 100, dp.Balance)
+}
+
+func TestStakePoolUnlock(t *testing.T) {
+	const (
+		providerID = "provider_id"
+		delegateID = "delegate_id"
+		poolID     = "stake_pool_sc"
+	)
+	config.SmartContractConfig.Set("smart_contracts.stakepool.unbonding_period", "100s")
+	t.Cleanup(func() {
+		config.SmartContractConfig.Set("smart_contracts.stakepool.unbonding_period", "0s")
+	})
+
+	sp := NewStakePool()
+	sp.Pools[delegateID] = &DelegatePool{
+		DelegateID: delegateID,
+		Balance:    100,
+		Status:     spenum.Active,
+	}
+	balances := newTestBalances(t, false)
+	balances.balances[poolID] = 100
+	get := func(spenum.Provider, string, state.CommonStateContextI) (AbstractStakePool, error) {
+		return sp, nil
+	}
+
+	unlock := func(amount currency.Coin, now common.Timestamp) error {
+		input, err := json.Marshal(&StakePoolRequest{
+			ProviderType: spenum.Blobber,
+			ProviderID:   providerID,
+			Amount:       amount,
+		})
+		require.NoError(t, err)
+		txn := &transaction.Transaction{
+			ClientID:     delegateID,
+			ToClientID:   poolID,
+			CreationDate: now,
+		}
+		balances.setTransaction(t, txn)
+		_, err = StakePoolUnlock(txn, input, balances, get)
+		return err
+	}
+
+	require.NoError(t, unlock(30, 10))
+	require.EqualValues(t, 70, sp.Pools[delegateID].Balance)
+	require.Zero(t, balances.balances[delegateID], "the unstaked tokens are unbonding")
+
+	require.NoError(t, unlock(20, 50))
+	require.Zero(t, balances.balances[delegateID])
+
+	// the first unstake is released, the second is still unbonding
+	require.NoError(t, unlock(0, 110), "unstakes the rest")
+	require.EqualValues(t, 30, balances.balances[delegateID])
+	dp := sp.Pools[delegateID]
+	require.Zero(t, dp.Balance)
+	require.Len(t, dp.Unbonding, 2)
+
+	require.NoError(t, unlock(0, 150))
+	require.EqualValues(t, 50, balances.balances[delegateID])
+	require.Error(t, unlock(0, 160), "nothing to release")
+
+	require.NoError(t, unlock(0, 210))
+	require.EqualValues(t, 100, balances.balances[delegateID])
+	_, ok := sp.Pools[delegateID]
+	require.False(t, ok, "the delegate pool is deleted")
+}
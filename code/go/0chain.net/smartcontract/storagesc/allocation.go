@@ -17,6 +17,7 @@ import (
 	"go.uber.org/zap"
 
 	chainstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 )
@@ -92,6 +93,10 @@ type newAllocationRequest struct {
 	ThirdPartyExtendable bool       `json:"third_party_extendable"`
 	FileOptionsChanged   bool       `json:"file_options_changed"`
 	FileOptions          uint16     `json:"file_options"`
+	AutoRepair           bool       `json:"auto_repair"`
+	AutoRenew            bool       `json:"auto_renew"`
+	RenewalSource        string     `json:"renewal_source"`
+	StorageClass         string     `json:"storage_class"`
 }
 
 // storageAllocation from the request
@@ -106,8 +111,14 @@ func (nar *newAllocationRequest) storageAllocation(conf *Config, now common.Time
 	sa.PreferredBlobbers = nar.Blobbers
 	sa.ReadPriceRange = nar.ReadPriceRange
 	sa.WritePriceRange = nar.WritePriceRange
+	sa.StorageClass = nar.StorageClass
 	sa.ThirdPartyExtendable = nar.ThirdPartyExtendable
 	sa.FileOptions = nar.FileOptions
+	sa.AutoRepair = nar.AutoRepair
+	sa.AutoRenew = nar.AutoRenew
+	if nar.AutoRenew {
+		sa.RenewalSource = nar.RenewalSource
+	}
 
 	return
 }
@@ -133,7 +144,13 @@ func (nar *newAllocationRequest) validate(conf *Config) error {
 		return errors.New("insufficient allocation size")
 	}
 
-	return nil
+	source, err := parseRenewalSource(nar.RenewalSource)
+	if err != nil {
+		return err
+	}
+	nar.RenewalSource = source
+
+	return validateStorageClass(nar.StorageClass)
 }
 
 func (nar *newAllocationRequest) decode(b []byte) error {
@@ -356,6 +373,12 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 	}
 	m.tick("add_allocation")
 
+	if sa.renewsFromPool() {
+		if err := partitionsAutoRenewAllocationsAdd(balances, sa.ID); err != nil {
+			return "", common.NewErrorf("allocation_creation_failed", "%v", err)
+		}
+	}
+
 	// emit event to eventDB
 	emitAddOrOverwriteAllocationBlobberTerms(sa, balances, txn)
 
@@ -394,7 +417,7 @@ func setupNewAllocation(
 	sa := request.storageAllocation(conf, now) // (set fields, ignore expiration)
 	m.tick("fetch_pools")
 	sa.TimeUnit = conf.TimeUnit
-	sa.MinLockDemand = conf.MinLockDemand
+	sa.MinLockDemand = conf.minLockDemand(sa.StorageClass)
 	sa.ID = allocId
 	sa.Tx = allocId
 
@@ -482,6 +505,11 @@ type updateAllocationRequest struct {
 	SetThirdPartyExtendable bool   `json:"set_third_party_extendable"`
 	FileOptionsChanged      bool   `json:"file_options_changed"`
 	FileOptions             uint16 `json:"file_options"`
+	AutoRepairChanged       bool   `json:"auto_repair_changed"`
+	AutoRepair              bool   `json:"auto_repair"`
+	AutoRenewChanged        bool   `json:"auto_renew_changed"`
+	AutoRenew               bool   `json:"auto_renew"`
+	RenewalSource           string `json:"renewal_source"`
 }
 
 func (uar *updateAllocationRequest) decode(b []byte) error {
@@ -493,17 +521,35 @@ func (uar *updateAllocationRequest) validate(
 	conf *Config,
 	alloc *StorageAllocation,
 ) error {
+	if uar.AutoRenewChanged {
+		source, err := parseRenewalSource(uar.RenewalSource)
+		if err != nil {
+			return err
+		}
+		if !uar.AutoRenew {
+			source = ""
+		}
+		uar.RenewalSource = source
+	}
+
 	if uar.Size == 0 &&
 		uar.Extend == false &&
 		len(uar.AddBlobberId) == 0 &&
 		len(uar.Name) == 0 &&
 		(!uar.SetThirdPartyExtendable || (uar.SetThirdPartyExtendable && alloc.ThirdPartyExtendable)) &&
 		(!uar.FileOptionsChanged || uar.FileOptions == alloc.FileOptions) &&
+		(!uar.AutoRepairChanged || uar.AutoRepair == alloc.AutoRepair) &&
+		(!uar.AutoRenewChanged || (uar.AutoRenew == alloc.AutoRenew && uar.RenewalSource == alloc.RenewalSource)) &&
 		(alloc.Owner == uar.OwnerID) {
 		return errors.New("update allocation changes nothing")
-	} else {
-		if uar.Size < 0 {
-			return fmt.Errorf("allocation can't be reduced")
+	}
+
+	if uar.Size < 0 {
+		if uar.Extend || len(uar.AddBlobberId) > 0 {
+			return errors.New("allocation can't be reduced while extending it or changing its blobbers")
+		}
+		if alloc.Size+uar.Size < conf.MinAllocSize {
+			return fmt.Errorf("allocation size can't be reduced below %d", conf.MinAllocSize)
 		}
 	}
 
@@ -916,6 +962,129 @@ func (sc *StorageSmartContract) extendAllocation(
 	return nil
 }
 
+// reduceAllocation reduces the size of the allocation down to the data stored by its
+// blobbers, the released capacity is returned to the blobbers, and the write pool
+// share of the capacity is refunded to the owner, keeping the tokens needed for the
+// cancellation charge and the min lock demand not paid yet; the terms, the min lock
+// demand and the challenge pool are kept as the stored data doesn't change
+func (sc *StorageSmartContract) reduceAllocation(
+	txn *transaction.Transaction,
+	conf *Config,
+	alloc *StorageAllocation,
+	blobbers []*StorageNode,
+	req *updateAllocationRequest,
+	balances chainstate.StateContextI,
+) error {
+	var (
+		diff    = req.getBlobbersSizeDiff(alloc) // size difference, negative
+		size    = req.getNewBlobbersSize(alloc)  // blobber size
+		oldSize = alloc.Size
+	)
+
+	if size <= 0 {
+		return common.NewError("allocation_reducing_failed",
+			"allocation can't be reduced to zero size")
+	}
+
+	for i, details := range alloc.BlobberAllocs {
+		var b = blobbers[i]
+		if b.ID != details.BlobberID {
+			return common.NewErrorf("allocation_reducing_failed",
+				"blobber %s and %s don't match", b.ID, details.BlobberID)
+		}
+
+		if details.Stats != nil && details.Stats.UsedSize > size {
+			return common.NewErrorf("allocation_reducing_failed",
+				"blobber %s stores %d bytes of the allocation, more than the new size %d",
+				b.ID, details.Stats.UsedSize, size)
+		}
+
+		oldOffer := details.Offer()
+		details.Size = size
+
+		b.Allocated += diff // release the capacity
+		if b.Allocated < 0 {
+			b.Allocated = 0
+		}
+
+		if newOffer := details.Offer(); newOffer < oldOffer {
+			sp, err := sc.getStakePool(spenum.Blobber, details.BlobberID, balances)
+			if err != nil {
+				return fmt.Errorf("can't get stake pool of %s: %v", details.BlobberID, err)
+			}
+			if err := sp.reduceOffer(oldOffer - newOffer); err != nil {
+				return fmt.Errorf("reduce offer: %v", err)
+			}
+			if err := sp.Save(spenum.Blobber, details.BlobberID, balances); err != nil {
+				return fmt.Errorf("can't save stake pool of %s: %v", details.BlobberID, err)
+			}
+		}
+	}
+
+	alloc.Size += req.Size
+
+	refund, err := alloc.reduceRefund(oldSize, conf.CancellationCharge)
+	if err != nil {
+		return common.NewError("allocation_reducing_failed", err.Error())
+	}
+	if refund == 0 {
+		return nil
+	}
+
+	if err := balances.AddTransfer(state.NewTransfer(sc.ID, alloc.Owner, refund)); err != nil {
+		return common.NewError("allocation_reducing_failed", err.Error())
+	}
+	if alloc.WritePool, err = currency.MinusCoin(alloc.WritePool, refund); err != nil {
+		return common.NewError("allocation_reducing_failed", err.Error())
+	}
+
+	i, err := refund.Int64()
+	if err != nil {
+		return common.NewError("allocation_reducing_failed", err.Error())
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUnlockWritePool, alloc.ID, event.WritePoolLock{
+		Client:       txn.ClientID,
+		AllocationId: alloc.ID,
+		Amount:       i,
+	})
+	return nil
+}
+
+// reduceRefund returns the write pool share of the capacity released by reducing the
+// allocation from the old size, the tokens needed for the cancellation charge and the
+// rest of the min lock demand are not refunded
+func (sa *StorageAllocation) reduceRefund(oldSize int64, cancellationFraction float64) (currency.Coin, error) {
+	if oldSize <= sa.Size || sa.WritePool == 0 {
+		return 0, nil
+	}
+
+	refund, err := currency.MultFloat64(sa.WritePool, float64(oldSize-sa.Size)/float64(oldSize))
+	if err != nil {
+		return 0, err
+	}
+
+	cancellationCharge, err := sa.cancellationCharge(cancellationFraction)
+	if err != nil {
+		return 0, err
+	}
+	mld, err := sa.restMinLockDemand()
+	if err != nil {
+		return 0, err
+	}
+	required, err := currency.AddCoin(cancellationCharge, mld)
+	if err != nil {
+		return 0, err
+	}
+
+	if sa.WritePool <= required {
+		return 0, nil
+	}
+	if rest := sa.WritePool - required; refund > rest {
+		refund = rest
+	}
+	return refund, nil
+}
+
 // update allocation allows to change allocation size or expiration;
 // if expiration reduced or unchanged, then existing terms of blobbers used,
 // otherwise new terms used; also, it locks additional tokens if size is
@@ -1025,6 +1194,13 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 			}
 		}
 
+		if request.Size < 0 {
+			err = sc.reduceAllocation(t, conf, alloc, blobbers, &request, balances)
+			if err != nil {
+				return "", err
+			}
+		}
+
 		if err := alloc.checkFunding(conf.CancellationCharge); err != nil {
 			return "", common.NewError("allocation_updating_failed", err.Error())
 		}
@@ -1037,6 +1213,16 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 			alloc.FileOptions = request.FileOptions
 		}
 
+		if request.AutoRepairChanged {
+			alloc.AutoRepair = request.AutoRepair
+		}
+
+		if request.AutoRenewChanged {
+			if err := alloc.setAutoRenew(request.AutoRenew, request.RenewalSource, balances); err != nil {
+				return "", common.NewError("allocation_updating_failed", err.Error())
+			}
+		}
+
 		if len(request.RemoveBlobberId) > 0 {
 			balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, []event.AllocationBlobberTerm{
 				{
@@ -1055,6 +1241,8 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		}
 	}
 
+	alloc.updateDegradedBlobbers(blobbers)
+
 	err = alloc.saveUpdatedAllocation(blobbers, balances)
 	if err != nil {
 		return "", common.NewErrorf("allocation_reducing_failed", "%v", err)
@@ -1401,6 +1589,10 @@ func (sc *StorageSmartContract) finishAllocation(
 		}
 	}
 
+	if err = sc.refundRenewalPool(alloc, balances); err != nil {
+		return err
+	}
+
 	alloc.Finalized = true
 	return nil
 }
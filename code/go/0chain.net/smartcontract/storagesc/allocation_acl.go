@@ -0,0 +1,293 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore allocationACLRequest
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// The access control list of an allocation names the collaborators of the
+// allocation, each with a role and an optional expiry. A reader can download
+// the files, a writer can also upload, update, move, copy, rename and delete
+// them, and an admin can also manage the reader and writer collaborators. Only
+// the owner manages the admin collaborators. The list is kept in the MPT and
+// mirrored into the event DB, the blobbers enforce it. The FileOptions of the
+// allocation still apply to the clients not on the list.
+
+const (
+	ACLRoleReader = "reader"
+	ACLRoleWriter = "writer"
+	ACLRoleAdmin  = "admin"
+)
+
+// maxAllocationACLSize is the max number of collaborators of an allocation
+const maxAllocationACLSize = 100
+
+var aclRoleRanks = map[string]int{
+	ACLRoleReader: 1,
+	ACLRoleWriter: 2,
+	ACLRoleAdmin:  3,
+}
+
+func allocationACLKey(allocationID string) datastore.Key {
+	return datastore.Key(ADDRESS + ":allocationacl:" + allocationID)
+}
+
+// AllocationACLEntry is a collaborator of an allocation, the entry never
+// expires if the expiration is zero
+type AllocationACLEntry struct {
+	ClientID   string           `json:"client_id"`
+	Role       string           `json:"role"`
+	Expiration common.Timestamp `json:"expiration"`
+}
+
+func (e *AllocationACLEntry) isActive(now common.Timestamp) bool {
+	return e.Expiration == 0 || now < e.Expiration
+}
+
+// AllocationACL is the access control list of an allocation
+type AllocationACL struct {
+	AllocationID string                `json:"allocation_id"`
+	Entries      []*AllocationACLEntry `json:"entries"`
+}
+
+func (acl *AllocationACL) Encode() []byte {
+	var b, err = json.Marshal(acl)
+	if err != nil {
+		panic(err) // must never happens
+	}
+	return b
+}
+
+func (acl *AllocationACL) find(clientID string) (int, *AllocationACLEntry) {
+	for i, e := range acl.Entries {
+		if e.ClientID == clientID {
+			return i, e
+		}
+	}
+	return -1, nil
+}
+
+// set adds the collaborator or overwrites its role and expiration
+func (acl *AllocationACL) set(entry *AllocationACLEntry) error {
+	if _, e := acl.find(entry.ClientID); e != nil {
+		e.Role = entry.Role
+		e.Expiration = entry.Expiration
+		return nil
+	}
+	if len(acl.Entries) >= maxAllocationACLSize {
+		return fmt.Errorf("max %d collaborators per allocation", maxAllocationACLSize)
+	}
+	acl.Entries = append(acl.Entries, entry)
+	return nil
+}
+
+func (acl *AllocationACL) remove(clientID string) bool {
+	i, e := acl.find(clientID)
+	if e == nil {
+		return false
+	}
+	acl.Entries = append(acl.Entries[:i], acl.Entries[i+1:]...)
+	return true
+}
+
+// hasRole reports whether the client is a collaborator with the given role or
+// a higher one at the given time
+func (acl *AllocationACL) hasRole(clientID, role string, now common.Timestamp) bool {
+	_, e := acl.find(clientID)
+	if e == nil || !e.isActive(now) {
+		return false
+	}
+	return aclRoleRanks[e.Role] >= aclRoleRanks[role]
+}
+
+func (acl *AllocationACL) save(balances cstate.StateContextI) error {
+	if len(acl.Entries) == 0 {
+		_, err := balances.DeleteTrieNode(allocationACLKey(acl.AllocationID))
+		if err != nil && err != util.ErrValueNotPresent {
+			return err
+		}
+	} else if _, err := balances.InsertTrieNode(allocationACLKey(acl.AllocationID), acl); err != nil {
+		return err
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocationACL, acl.AllocationID, acl.toEvent())
+	return nil
+}
+
+func (acl *AllocationACL) toEvent() []event.AllocationACL {
+	rows := make([]event.AllocationACL, 0, len(acl.Entries))
+	for _, e := range acl.Entries {
+		rows = append(rows, event.AllocationACL{
+			AllocationID: acl.AllocationID,
+			ClientID:     e.ClientID,
+			Role:         e.Role,
+			Expiration:   int64(e.Expiration),
+		})
+	}
+	return rows
+}
+
+func getAllocationACL(allocationID string, balances cstate.CommonStateContextI) (*AllocationACL, error) {
+	acl := &AllocationACL{AllocationID: allocationID}
+	err := balances.GetTrieNode(allocationACLKey(allocationID), acl)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return acl, nil
+	default:
+		return nil, err
+	}
+}
+
+type allocationACLRequest struct {
+	AllocationID string           `json:"allocation_id"`
+	ClientID     string           `json:"client_id"`
+	Role         string           `json:"role,omitempty"`
+	Expiration   common.Timestamp `json:"expiration,omitempty"`
+}
+
+func (req *allocationACLRequest) decode(b []byte) error {
+	if err := json.Unmarshal(b, req); err != nil {
+		return err
+	}
+	if req.AllocationID == "" {
+		return errors.New("missing allocation id")
+	}
+	if req.ClientID == "" {
+		return errors.New("missing client id")
+	}
+	return nil
+}
+
+// canManageACL checks the client can grant or revoke the given role, the owner
+// manages any collaborator and an admin manages the readers and the writers
+func canManageACL(alloc *StorageAllocation, acl *AllocationACL, clientID, role string,
+	now common.Timestamp) bool {
+
+	if clientID == alloc.Owner {
+		return true
+	}
+	return role != ACLRoleAdmin && acl.hasRole(clientID, ACLRoleAdmin, now)
+}
+
+// setAllocationACL adds a collaborator to the allocation or changes its role
+// and expiration
+func (sc *StorageSmartContract) setAllocationACL(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req allocationACLRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("set_allocation_acl_failed",
+			"invalid request: "+err.Error())
+	}
+	if _, ok := aclRoleRanks[req.Role]; !ok {
+		return "", common.NewErrorf("set_allocation_acl_failed",
+			"invalid role %q", req.Role)
+	}
+	if req.Expiration != 0 && req.Expiration <= txn.CreationDate {
+		return "", common.NewError("set_allocation_acl_failed",
+			"expiration in the past")
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("set_allocation_acl_failed",
+			"can't get allocation: "+err.Error())
+	}
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("set_allocation_acl_failed",
+			"allocation is finalized or canceled")
+	}
+	if req.ClientID == alloc.Owner {
+		return "", common.NewError("set_allocation_acl_failed",
+			"owner can't be a collaborator")
+	}
+
+	acl, err := getAllocationACL(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("set_allocation_acl_failed",
+			"can't get allocation acl: "+err.Error())
+	}
+
+	// an admin can't change the role of another admin either
+	role := req.Role
+	if _, e := acl.find(req.ClientID); e != nil && e.Role == ACLRoleAdmin {
+		role = ACLRoleAdmin
+	}
+	if !canManageACL(alloc, acl, txn.ClientID, role, txn.CreationDate) {
+		return "", common.NewError("set_allocation_acl_failed",
+			"only owner or admin collaborators can manage the allocation acl")
+	}
+
+	entry := &AllocationACLEntry{
+		ClientID:   req.ClientID,
+		Role:       req.Role,
+		Expiration: req.Expiration,
+	}
+	if err := acl.set(entry); err != nil {
+		return "", common.NewError("set_allocation_acl_failed", err.Error())
+	}
+	if err := acl.save(balances); err != nil {
+		return "", common.NewError("set_allocation_acl_failed",
+			"can't save allocation acl: "+err.Error())
+	}
+
+	return string(acl.Encode()), nil
+}
+
+// removeAllocationACL removes a collaborator from the allocation, any
+// collaborator can remove itself
+func (sc *StorageSmartContract) removeAllocationACL(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req allocationACLRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("remove_allocation_acl_failed",
+			"invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("remove_allocation_acl_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	acl, err := getAllocationACL(alloc.ID, balances)
+	if err != nil {
+		return "", common.NewError("remove_allocation_acl_failed",
+			"can't get allocation acl: "+err.Error())
+	}
+
+	_, e := acl.find(req.ClientID)
+	if e == nil {
+		return "", common.NewError("remove_allocation_acl_failed",
+			"client is not a collaborator of the allocation")
+	}
+	if txn.ClientID != req.ClientID &&
+		!canManageACL(alloc, acl, txn.ClientID, e.Role, txn.CreationDate) {
+		return "", common.NewError("remove_allocation_acl_failed",
+			"only owner or admin collaborators can manage the allocation acl")
+	}
+
+	acl.remove(req.ClientID)
+	if err := acl.save(balances); err != nil {
+		return "", common.NewError("remove_allocation_acl_failed",
+			"can't save allocation acl: "+err.Error())
+	}
+
+	return string(acl.Encode()), nil
+}
@@ -0,0 +1,87 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func callAllocationACL(t *testing.T, ssc *StorageSmartContract, set bool, clientID string,
+	req *allocationACLRequest, now int64, balances *testBalances) error {
+
+	input, err := json.Marshal(req)
+	require.NoError(t, err)
+	tx := newTransaction(clientID, ADDRESS, 0, now)
+	balances.setTransaction(t, tx)
+	if set {
+		_, err = ssc.setAllocationACL(tx, input, balances)
+	} else {
+		_, err = ssc.removeAllocationACL(tx, input, balances)
+	}
+	return err
+}
+
+func TestAllocationACL(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		admin    = newClient(100*x10, balances)
+		writer   = newClient(100*x10, balances)
+		reader   = newClient(100*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, tp, 0, balances)
+	set := func(clientID, collaborator, role string, expiration common.Timestamp) error {
+		return callAllocationACL(t, ssc, true, clientID, &allocationACLRequest{
+			AllocationID: allocID,
+			ClientID:     collaborator,
+			Role:         role,
+			Expiration:   expiration,
+		}, tp+100, balances)
+	}
+	remove := func(clientID, collaborator string) error {
+		return callAllocationACL(t, ssc, false, clientID, &allocationACLRequest{
+			AllocationID: allocID,
+			ClientID:     collaborator,
+		}, tp+100, balances)
+	}
+
+	require.Error(t, set(client.id, admin.id, "owner", 0))
+	require.Error(t, set(client.id, admin.id, ACLRoleAdmin, common.Timestamp(tp)))
+	require.Error(t, set(client.id, client.id, ACLRoleAdmin, 0))
+
+	// only the owner manages the admin collaborators
+	require.Error(t, set(admin.id, admin.id, ACLRoleAdmin, 0))
+	require.NoError(t, set(client.id, admin.id, ACLRoleAdmin, 0))
+	require.Error(t, set(admin.id, writer.id, ACLRoleAdmin, 0))
+
+	// an admin manages the readers and the writers
+	require.NoError(t, set(admin.id, writer.id, ACLRoleWriter, 0))
+	require.NoError(t, set(admin.id, reader.id, ACLRoleReader, common.Timestamp(tp+200)))
+	require.Error(t, set(writer.id, reader.id, ACLRoleWriter, 0))
+	require.Error(t, set(admin.id, admin.id, ACLRoleReader, 0))
+
+	acl, err := getAllocationACL(allocID, balances)
+	require.NoError(t, err)
+	require.Len(t, acl.Entries, 3)
+	require.True(t, acl.hasRole(admin.id, ACLRoleWriter, common.Timestamp(tp+100)))
+	require.True(t, acl.hasRole(writer.id, ACLRoleReader, common.Timestamp(tp+100)))
+	require.False(t, acl.hasRole(writer.id, ACLRoleAdmin, common.Timestamp(tp+100)))
+	require.True(t, acl.hasRole(reader.id, ACLRoleReader, common.Timestamp(tp+100)))
+	require.False(t, acl.hasRole(reader.id, ACLRoleReader, common.Timestamp(tp+200)))
+
+	// a collaborator removes itself
+	require.Error(t, remove(writer.id, admin.id))
+	require.NoError(t, remove(reader.id, reader.id))
+	require.Error(t, remove(client.id, reader.id))
+	require.NoError(t, remove(admin.id, writer.id))
+	require.NoError(t, remove(client.id, admin.id))
+
+	acl, err = getAllocationACL(allocID, balances)
+	require.NoError(t, err)
+	require.Empty(t, acl.Entries)
+}
@@ -43,7 +43,11 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 	var gbSize = sizeInGB(bSize(alloc.Size, alloc.DataShards))
 	var rdtu = float64(time.Second*time.Duration(alloc.Expiration-alloc.StartTime)) / float64(alloc.TimeUnit)
 
+	var degraded []string
 	for _, b := range blobbers {
+		if b.IsKilled || b.IsShutdown {
+			degraded = append(degraded, b.ID)
+		}
 		storageNodes = append(storageNodes, &storageNodeResponse{
 			ID:      b.ID,
 			BaseURL: b.BaseURL,
@@ -52,6 +56,7 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 				Longitude: b.Longitude,
 			},
 			Terms:           blobberTermsMap[b.ID],
+			StorageClass:    b.StorageClass,
 			Capacity:        b.Capacity,
 			Allocated:       b.Allocated,
 			SavedData:       b.SavedData,
@@ -108,6 +113,7 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 		BlobberAllocsMap:  blobberMap,
 		ReadPriceRange:    PriceRange{alloc.ReadPriceMin, alloc.ReadPriceMax},
 		WritePriceRange:   PriceRange{alloc.WritePriceMin, alloc.WritePriceMax},
+		StorageClass:      alloc.StorageClass,
 		StartTime:         common.Timestamp(alloc.StartTime),
 		Finalized:         alloc.Finalized,
 		Canceled:          alloc.Cancelled,
@@ -116,6 +122,11 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 		MovedToValidators: alloc.MovedToValidators,
 		TimeUnit:          time.Duration(alloc.TimeUnit),
 		MinLockDemand:     alloc.MinLockDemand,
+		AutoRepair:        alloc.AutoRepair,
+		DegradedBlobbers:  degraded,
+		AutoRenew:         alloc.AutoRenew,
+		RenewalSource:     alloc.RenewalSource,
+		RenewalPool:       alloc.RenewalPool,
 	}
 
 	return &StorageAllocationBlobbers{
@@ -139,6 +150,7 @@ func storageAllocationToAllocationTable(sa *StorageAllocation) *event.Allocation
 		ReadPriceMax:         sa.ReadPriceRange.Max,
 		WritePriceMin:        sa.WritePriceRange.Min,
 		WritePriceMax:        sa.WritePriceRange.Max,
+		StorageClass:         storageClassOf(sa.StorageClass),
 		StartTime:            int64(sa.StartTime),
 		Finalized:            sa.Finalized,
 		Cancelled:            sa.Canceled,
@@ -151,6 +163,11 @@ func storageAllocationToAllocationTable(sa *StorageAllocation) *event.Allocation
 		ThirdPartyExtendable: sa.ThirdPartyExtendable,
 		FileOptions:          sa.FileOptions,
 		MinLockDemand:        sa.MinLockDemand,
+		AutoRepair:           sa.AutoRepair,
+		Degraded:             len(sa.DegradedBlobbers) > 0,
+		AutoRenew:            sa.AutoRenew,
+		RenewalSource:        sa.RenewalSource,
+		RenewalPool:          sa.RenewalPool,
 	}
 
 	if sa.Stats != nil {
@@ -195,6 +212,7 @@ func (sa *StorageAllocation) buildDbUpdates() event.Allocation {
 		ReadPriceMax:         sa.ReadPriceRange.Max,
 		WritePriceMin:        sa.WritePriceRange.Min,
 		WritePriceMax:        sa.WritePriceRange.Max,
+		StorageClass:         storageClassOf(sa.StorageClass),
 		StartTime:            int64(sa.StartTime),
 		Finalized:            sa.Finalized,
 		Cancelled:            sa.Canceled,
@@ -206,6 +224,11 @@ func (sa *StorageAllocation) buildDbUpdates() event.Allocation {
 		WritePool:            sa.WritePool,
 		ThirdPartyExtendable: sa.ThirdPartyExtendable,
 		FileOptions:          sa.FileOptions,
+		AutoRepair:           sa.AutoRepair,
+		Degraded:             len(sa.DegradedBlobbers) > 0,
+		AutoRenew:            sa.AutoRenew,
+		RenewalSource:        sa.RenewalSource,
+		RenewalPool:          sa.RenewalPool,
 	}
 
 	if sa.Stats != nil {
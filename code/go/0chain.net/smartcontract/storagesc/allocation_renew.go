@@ -0,0 +1,480 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+// An allocation with the auto renew policy is extended by a time unit the
+// auto_renew.window before it expires, exactly as update_allocation_request
+// extends it. The renewal price, the cost of the allocation for a time unit
+// at the terms of its blobbers, is paid by the renewal source of the allocation:
+//
+//   - the owner balance: the owner sends the renew_allocation transaction with the
+//     renewal price as its value, usually scheduled to be eligible in the window;
+//   - the renewal pool, funded by renewal_pool_lock: the allocations are renewed by
+//     the renew_allocations transaction the miners add every auto_renew.trigger_period
+//     rounds, or by anyone sending the renew_allocation transaction. The transaction
+//     goes through one partition of these allocations at a time. The tokens left
+//     in the pool return to the owner when the allocation is finalized or canceled.
+//
+// A renewal the source can't pay emits a TagAllocationRenewalFailed event instead
+// of failing the transaction, as does any failed renewal of renew_allocations.
+
+const (
+	renewFromOwner = "owner"
+	renewFromPool  = "pool"
+)
+
+// renewalFailure is a renewal failure found before the allocation is changed,
+// it's reported by an event rather than failing the transaction
+type renewalFailure string
+
+func (f renewalFailure) Error() string {
+	return string(f)
+}
+
+const (
+	errRenewalNotFunded renewalFailure = "not enough tokens to renew the allocation"
+	errRenewalDegraded  renewalFailure = "allocation has killed or shut down blobbers"
+)
+
+type renewAllocationRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (rar *renewAllocationRequest) decode(b []byte) error {
+	return json.Unmarshal(b, rar)
+}
+
+type RenewAllocationsInput struct {
+	Round int64 `json:"round"`
+}
+
+type renewalPoolRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (rpr *renewalPoolRequest) decode(b []byte) error {
+	return json.Unmarshal(b, rpr)
+}
+
+// parseRenewalSource returns the renewal source, the owner balance by default
+func parseRenewalSource(source string) (string, error) {
+	switch source {
+	case "", renewFromOwner:
+		return renewFromOwner, nil
+	case renewFromPool:
+		return renewFromPool, nil
+	default:
+		return "", fmt.Errorf("invalid renewal source: %q", source)
+	}
+}
+
+func (sa *StorageAllocation) renewsFromPool() bool {
+	return sa.AutoRenew && sa.RenewalSource == renewFromPool
+}
+
+// setAutoRenew sets the auto renew policy of the allocation, the allocations
+// renewed from their renewal pools are kept in the auto renew partitions
+func (sa *StorageAllocation) setAutoRenew(autoRenew bool, source string,
+	balances cstate.StateContextI) error {
+
+	fromPool := sa.renewsFromPool()
+	sa.AutoRenew = autoRenew
+	sa.RenewalSource = ""
+	if autoRenew {
+		sa.RenewalSource = source
+	}
+
+	switch {
+	case sa.renewsFromPool() && !fromPool:
+		return partitionsAutoRenewAllocationsAdd(balances, sa.ID)
+	case !sa.renewsFromPool() && fromPool:
+		return partitionsAutoRenewAllocationsRemove(balances, sa.ID)
+	}
+	return nil
+}
+
+// renewalDue checks the allocation is in its renewal window
+func (sa *StorageAllocation) renewalDue(window time.Duration, now common.Timestamp) bool {
+	return sa.AutoRenew && !sa.Finalized && !sa.Canceled && window > 0 &&
+		now <= sa.Expiration && sa.Expiration-now <= toSeconds(window)
+}
+
+// renewalPrice is the cost of the allocation for a time unit, using the
+// greater of the current and the new write prices of its blobbers
+func (sa *StorageAllocation) renewalPrice(blobbers []*StorageNode) (currency.Coin, error) {
+	var price currency.Coin
+	for i, ba := range sa.BlobberAllocs {
+		writePrice := ba.Terms.WritePrice
+		if blobbers[i].Terms.WritePrice > writePrice {
+			writePrice = blobbers[i].Terms.WritePrice
+		}
+		c, err := currency.MultFloat64(writePrice, sizeInGB(ba.Size))
+		if err != nil {
+			return 0, err
+		}
+		if price, err = currency.AddCoin(price, c); err != nil {
+			return 0, err
+		}
+	}
+	return price, nil
+}
+
+func (sa *StorageAllocation) renewalEvent(price currency.Coin, reason string) event.AllocationRenewal {
+	return event.AllocationRenewal{
+		AllocationID: sa.ID,
+		Owner:        sa.Owner,
+		Source:       sa.RenewalSource,
+		Price:        price,
+		Expiration:   int64(sa.Expiration),
+		Reason:       reason,
+	}
+}
+
+// renew extends the allocation by a time unit paying the renewal price from its
+// renewal source; a renewalFailure is returned before the allocation is changed
+func (sc *StorageSmartContract) renew(
+	txn *transaction.Transaction,
+	conf *Config,
+	alloc *StorageAllocation,
+	balances cstate.StateContextI,
+) (currency.Coin, error) {
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, b := range blobbers {
+		if b.IsKilled() || b.IsShutDown() || b.Capacity == 0 {
+			return 0, errRenewalDegraded
+		}
+	}
+
+	price, err := alloc.renewalPrice(blobbers)
+	if err != nil {
+		return 0, err
+	}
+
+	switch alloc.RenewalSource {
+	case renewFromPool:
+		if alloc.RenewalPool < price {
+			return price, errRenewalNotFunded
+		}
+		alloc.RenewalPool -= price
+		if alloc.WritePool, err = currency.AddCoin(alloc.WritePool, price); err != nil {
+			return price, err
+		}
+	default:
+		if txn.ClientID != alloc.Owner {
+			return price, errors.New("only owner can renew the allocation from its balance")
+		}
+		if txn.Value < price {
+			return price, errRenewalNotFunded
+		}
+		// the transaction value is locked in the write pool by extendAllocation
+	}
+
+	alloc.Tx = txn.Hash
+	err = sc.extendAllocation(txn, conf, alloc, blobbers,
+		&updateAllocationRequest{ID: alloc.ID, Extend: true}, balances)
+	if err != nil {
+		return price, err
+	}
+
+	if err := alloc.checkFunding(conf.CancellationCharge); err != nil {
+		return price, err
+	}
+
+	if err := alloc.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return price, err
+	}
+
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, txn)
+	balances.EmitEvent(event.TypeStats, event.TagAllocationRenewed, alloc.ID, alloc.renewalEvent(price, ""))
+	return price, nil
+}
+
+// renewAllocation renews the allocation in its renewal window, the owner
+// renews the allocations renewed from its balance, anyone the others
+func (sc *StorageSmartContract) renewAllocation(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	conf, err := sc.getConfig(balances, false)
+	if err != nil {
+		return "", common.NewError("renew_allocation_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	var req renewAllocationRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("renew_allocation_failed",
+			"invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("renew_allocation_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if !alloc.AutoRenew {
+		return "", common.NewError("renew_allocation_failed",
+			"allocation is not auto renewed")
+	}
+
+	if !alloc.renewalDue(conf.AutoRenew.Window, txn.CreationDate) {
+		return "", common.NewError("renew_allocation_failed",
+			"allocation is not in its renewal window")
+	}
+
+	price, err := sc.renew(txn, conf, alloc, balances)
+	if f, ok := err.(renewalFailure); ok {
+		balances.EmitEvent(event.TypeStats, event.TagAllocationRenewalFailed, alloc.ID,
+			alloc.renewalEvent(price, f.Error()))
+		return "renewal failed: " + f.Error(), nil
+	}
+	if err != nil {
+		return "", common.NewError("renew_allocation_failed", err.Error())
+	}
+
+	return string(alloc.Encode()), nil
+}
+
+// renewAllocations renews the allocations in their renewal window from the next
+// partition of the allocations renewed from their renewal pools, a cursor saved
+// in the state goes through the partitions one trigger period after another
+func (sc *StorageSmartContract) renewAllocations(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var in RenewAllocationsInput
+	if err := json.Unmarshal(input, &in); err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	if in.Round != balances.GetBlock().Round {
+		return "", common.NewErrorf("renew_allocations_failed",
+			"bad round, block %v but input %v", balances.GetBlock().Round, in.Round)
+	}
+
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	if conf.AutoRenew.Window == 0 || conf.AutoRenew.TriggerPeriod == 0 {
+		return "auto renewals disabled in the config", nil
+	}
+
+	parts, err := partitionsAutoRenewAllocations(balances)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	size, err := parts.Size(balances)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+	if size == 0 {
+		return "no allocations to renew", nil
+	}
+
+	cursor, err := getAutoRenewCursor(balances)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed",
+			"can't get auto renew cursor: "+err.Error())
+	}
+	if cursor.Partition >= parts.NumPartitions() {
+		cursor.Partition = 0
+	}
+
+	var items []AutoRenewAllocation
+	if err := parts.GetPartitionItems(balances, cursor.Partition, &items); err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	var (
+		renewed int
+		removed bool
+	)
+	for _, it := range items {
+		alloc := &StorageAllocation{ID: it.AllocationID}
+		err := balances.GetTrieNode(alloc.GetKey(ADDRESS), alloc)
+		switch err {
+		case nil:
+		case util.ErrValueNotPresent:
+			alloc.AutoRenew = false // removed below
+		default:
+			return "", common.NewError("renew_allocations_failed", err.Error())
+		}
+
+		if !alloc.renewsFromPool() || alloc.Finalized || alloc.Canceled ||
+			alloc.Expiration < txn.CreationDate {
+			if err := parts.Remove(balances, it.AllocationID); err != nil {
+				return "", common.NewError("renew_allocations_failed", err.Error())
+			}
+			removed = true
+			continue
+		}
+
+		if !alloc.renewalDue(conf.AutoRenew.Window, txn.CreationDate) {
+			continue
+		}
+
+		// a failed renewal is reported and doesn't stop the others
+		price, err := sc.renew(txn, conf, alloc, balances)
+		if err != nil {
+			balances.EmitEvent(event.TypeStats, event.TagAllocationRenewalFailed, alloc.ID,
+				alloc.renewalEvent(price, err.Error()))
+			continue
+		}
+		renewed++
+	}
+
+	if removed {
+		if err := parts.Save(balances); err != nil {
+			return "", common.NewError("renew_allocations_failed", err.Error())
+		}
+	}
+
+	cursor.Partition = (cursor.Partition + 1) % parts.NumPartitions()
+	if err := cursor.save(balances); err != nil {
+		return "", common.NewError("renew_allocations_failed",
+			"can't save auto renew cursor: "+err.Error())
+	}
+
+	return fmt.Sprintf("%d allocations renewed", renewed), nil
+}
+
+// refundRenewalPool returns the tokens of the renewal pool of the finalized or
+// canceled allocation to its owner
+func (sc *StorageSmartContract) refundRenewalPool(alloc *StorageAllocation,
+	balances cstate.StateContextI) error {
+
+	if alloc.renewsFromPool() {
+		if err := partitionsAutoRenewAllocationsRemove(balances, alloc.ID); err != nil {
+			return fmt.Errorf("can't remove allocation from auto renew partitions: %v", err)
+		}
+	}
+
+	if alloc.RenewalPool == 0 {
+		return nil
+	}
+
+	transfer := state.NewTransfer(sc.ID, alloc.Owner, alloc.RenewalPool)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return fmt.Errorf("can't refund renewal pool: %v", err)
+	}
+	alloc.RenewalPool = 0
+	return nil
+}
+
+// renewalPoolLock locks the transaction value in the renewal pool of the allocation
+func (sc *StorageSmartContract) renewalPoolLock(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req renewalPoolRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("renewal_pool_lock_failed", err.Error())
+	}
+
+	if req.AllocationID == "" {
+		return "", common.NewError("renewal_pool_lock_failed",
+			"missing allocation ID in request")
+	}
+
+	if txn.Value == 0 {
+		return "", common.NewError("renewal_pool_lock_failed",
+			"no tokens to lock")
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("renewal_pool_lock_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("renewal_pool_lock_failed",
+			"can't lock tokens with a finalized or cancelled allocation")
+	}
+
+	if err := stakepool.CheckClientBalance(txn.ClientID, txn.Value, balances); err != nil {
+		return "", common.NewError("renewal_pool_lock_failed", err.Error())
+	}
+
+	transfer := state.NewTransfer(txn.ClientID, txn.ToClientID, txn.Value)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return "", common.NewError("renewal_pool_lock_failed", err.Error())
+	}
+
+	if alloc.RenewalPool, err = currency.AddCoin(alloc.RenewalPool, txn.Value); err != nil {
+		return "", common.NewError("renewal_pool_lock_failed",
+			fmt.Sprintf("renewal pool token overflow: %v", err))
+	}
+
+	if err := alloc.saveUpdatedAllocation(nil, balances); err != nil {
+		return "", common.NewError("renewal_pool_lock_failed", err.Error())
+	}
+
+	return "", nil
+}
+
+// renewalPoolUnlock returns the tokens of the renewal pool to the owner
+func (sc *StorageSmartContract) renewalPoolUnlock(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req renewalPoolRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("renewal_pool_unlock_failed", err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("renewal_pool_unlock_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if alloc.Owner != txn.ClientID {
+		return "", common.NewError("renewal_pool_unlock_failed",
+			"only owner can unlock tokens")
+	}
+
+	if alloc.RenewalPool == 0 {
+		return "", common.NewError("renewal_pool_unlock_failed",
+			"no tokens to unlock")
+	}
+
+	transfer := state.NewTransfer(sc.ID, txn.ClientID, alloc.RenewalPool)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return "", common.NewError("renewal_pool_unlock_failed", err.Error())
+	}
+
+	alloc.RenewalPool = 0
+	if err := alloc.saveUpdatedAllocation(nil, balances); err != nil {
+		return "", common.NewError("renewal_pool_unlock_failed", err.Error())
+	}
+
+	return "", nil
+}
@@ -0,0 +1,291 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func callRenewAllocation(t *testing.T, ssc *StorageSmartContract, clientID, allocID string,
+	value currency.Coin, now int64, balances *testBalances) (string, error) {
+
+	input, err := json.Marshal(&renewAllocationRequest{AllocationID: allocID})
+	require.NoError(t, err)
+	tx := newTransaction(clientID, ADDRESS, value, now)
+	balances.setTransaction(t, tx)
+	return ssc.renewAllocation(tx, input, balances)
+}
+
+func callRenewAllocations(t *testing.T, ssc *StorageSmartContract, round, now int64,
+	balances *testBalances) (string, error) {
+
+	b := &block.Block{}
+	b.Round = round
+	balances.setBlock(t, b)
+
+	input, err := json.Marshal(&RenewAllocationsInput{Round: round})
+	require.NoError(t, err)
+	tx := newTransaction(randString(32), ADDRESS, 0, now)
+	balances.setTransaction(t, tx)
+	return ssc.renewAllocations(tx, input, balances)
+}
+
+func callRenewalPool(t *testing.T, ssc *StorageSmartContract, lock bool, clientID, allocID string,
+	value currency.Coin, now int64, balances *testBalances) error {
+
+	input, err := json.Marshal(&renewalPoolRequest{AllocationID: allocID})
+	require.NoError(t, err)
+	tx := newTransaction(clientID, ADDRESS, value, now)
+	balances.setTransaction(t, tx)
+	if lock {
+		_, err = ssc.renewalPoolLock(tx, input, balances)
+	} else {
+		_, err = ssc.renewalPoolUnlock(tx, input, balances)
+	}
+	return err
+}
+
+func getRenewalPrice(t *testing.T, ssc *StorageSmartContract, allocID string,
+	balances *testBalances) currency.Coin {
+
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	blobbers, err := ssc.getAllocationBlobbers(alloc, balances)
+	require.NoError(t, err)
+	price, err := alloc.renewalPrice(blobbers)
+	require.NoError(t, err)
+	require.NotZero(t, price)
+	return price
+}
+
+func TestRenewAllocation(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, tp, 0, balances)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	conf, err := ssc.getConfig(balances, false)
+	require.NoError(t, err)
+	due := int64(alloc.Expiration) - 100
+
+	// not auto renewed
+	_, err = callRenewAllocation(t, ssc, client.id, allocID, 0, due, balances)
+	require.Error(t, err)
+
+	uar := updateAllocationRequest{ID: allocID, AutoRenewChanged: true, AutoRenew: true}
+	_, err = uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+	require.NoError(t, err)
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.True(t, alloc.AutoRenew)
+	require.Equal(t, renewFromOwner, alloc.RenewalSource)
+
+	// not in the renewal window
+	_, err = callRenewAllocation(t, ssc, client.id, allocID, 0, tp+200, balances)
+	require.Error(t, err)
+
+	// only the owner renews the allocation from its balance
+	price := getRenewalPrice(t, ssc, allocID, balances)
+	_, err = callRenewAllocation(t, ssc, other.id, allocID, price, due, balances)
+	require.Error(t, err)
+
+	// a renewal not funded doesn't fail the transaction
+	resp, err := callRenewAllocation(t, ssc, client.id, allocID, price-1, due, balances)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(resp, "renewal failed"))
+	renewed, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Equal(t, alloc.Expiration, renewed.Expiration)
+
+	before, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+	_, err = callRenewAllocation(t, ssc, client.id, allocID, price, due, balances)
+	require.NoError(t, err)
+
+	renewed, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Equal(t, common.Timestamp(common.ToTime(common.Timestamp(due)).Add(conf.TimeUnit).Unix()),
+		renewed.Expiration)
+	after, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+	require.Equal(t, before-price, after)
+}
+
+func TestRenewAllocationsFromPool(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		other    = newClient(2000*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, tp, 0, balances)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	due := int64(alloc.Expiration) - 100
+
+	uar := updateAllocationRequest{ID: allocID, AutoRenewChanged: true,
+		AutoRenew: true, RenewalSource: renewFromPool}
+	_, err = uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+	require.NoError(t, err)
+
+	parts, err := partitionsAutoRenewAllocations(balances)
+	require.NoError(t, err)
+	ok, err := parts.Exist(balances, allocID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the pool can't pay the renewal
+	price := getRenewalPrice(t, ssc, allocID, balances)
+	require.NoError(t, callRenewalPool(t, ssc, true, other.id, allocID, price-1, tp+200, balances))
+	_, err = callRenewAllocations(t, ssc, 100, due, balances)
+	require.NoError(t, err)
+	renewed, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Equal(t, alloc.Expiration, renewed.Expiration)
+	require.Equal(t, price-1, renewed.RenewalPool)
+
+	// bad round
+	b := &block.Block{}
+	b.Round = 300
+	balances.setBlock(t, b)
+	input, err := json.Marshal(&RenewAllocationsInput{Round: 200})
+	require.NoError(t, err)
+	_, err = ssc.renewAllocations(newTransaction(client.id, ADDRESS, 0, due), input, balances)
+	require.Error(t, err)
+
+	require.NoError(t, callRenewalPool(t, ssc, true, other.id, allocID, price, due, balances))
+	_, err = callRenewAllocations(t, ssc, 400, due, balances)
+	require.NoError(t, err)
+	renewed, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.True(t, renewed.Expiration > alloc.Expiration)
+	require.Equal(t, price-1, renewed.RenewalPool)
+	require.True(t, renewed.WritePool > alloc.WritePool)
+
+	// only the owner unlocks the renewal pool
+	require.Error(t, callRenewalPool(t, ssc, false, other.id, allocID, 0, due, balances))
+	before, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+	require.NoError(t, callRenewalPool(t, ssc, false, client.id, allocID, 0, due, balances))
+	after, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+	require.Equal(t, before+price-1, after)
+
+	// the allocation is removed from the partitions with the auto renew policy
+	uar = updateAllocationRequest{ID: allocID, AutoRenewChanged: true, AutoRenew: false}
+	_, err = uar.callUpdateAllocReq(t, client.id, 0, due, ssc, balances)
+	require.NoError(t, err)
+	parts, err = partitionsAutoRenewAllocations(balances)
+	require.NoError(t, err)
+	ok, err = parts.Exist(balances, allocID)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestRenewAllocationsCursor(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+	)
+	setConfig(t, balances)
+
+	// the allocations no longer exist, the partition gone through drops them
+	for i := 0; i < autoRenewAllocationsPartitionSize+10; i++ {
+		require.NoError(t, partitionsAutoRenewAllocationsAdd(balances, randString(32)))
+	}
+
+	for i, left := range []int{10, 0} {
+		_, err := callRenewAllocations(t, ssc, int64(100*(i+1)), 1000, balances)
+		require.NoError(t, err)
+
+		parts, err := partitionsAutoRenewAllocations(balances)
+		require.NoError(t, err)
+		size, err := parts.Size(balances)
+		require.NoError(t, err)
+		require.Equal(t, left, size)
+	}
+}
+
+func TestRenewalPoolRefund(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, tp, 0, balances)
+	uar := updateAllocationRequest{ID: allocID, AutoRenewChanged: true,
+		AutoRenew: true, RenewalSource: renewFromPool}
+	_, err := uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+	require.NoError(t, err)
+	require.NoError(t, callRenewalPool(t, ssc, true, client.id, allocID, 10*x10, tp+200, balances))
+
+	before, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+
+	tx := newTransaction(client.id, ssc.ID, 0, tp+300)
+	balances.setTransaction(t, tx)
+	_, err = ssc.cancelAllocationRequest(tx, mustEncode(t, &lockRequest{AllocationID: allocID}), balances)
+	require.NoError(t, err)
+
+	after, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+	require.Equal(t, before+10*x10, after)
+
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Zero(t, alloc.RenewalPool)
+
+	parts, err := partitionsAutoRenewAllocations(balances)
+	require.NoError(t, err)
+	ok, err := parts.Exist(balances, allocID)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestStorageAllocation_renewalDue(t *testing.T) {
+	const window = time.Hour
+	var (
+		now   = common.Timestamp(10000)
+		alloc = &StorageAllocation{AutoRenew: true, Expiration: now + 100}
+	)
+
+	require.True(t, alloc.renewalDue(window, now))
+	require.False(t, alloc.renewalDue(0, now))
+	require.False(t, alloc.renewalDue(window, now-toSeconds(window)))
+	require.False(t, alloc.renewalDue(window, now+101))
+
+	alloc.Canceled = true
+	require.False(t, alloc.renewalDue(window, now))
+	alloc.Canceled = false
+	alloc.AutoRenew = false
+	require.False(t, alloc.renewalDue(window, now))
+}
+
+func TestParseRenewalSource(t *testing.T) {
+	source, err := parseRenewalSource("")
+	require.NoError(t, err)
+	require.Equal(t, renewFromOwner, source)
+
+	source, err = parseRenewalSource(renewFromPool)
+	require.NoError(t, err)
+	require.Equal(t, renewFromPool, source)
+
+	_, err = parseRenewalSource("wallet")
+	require.Error(t, err)
+}
@@ -0,0 +1,258 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/url"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+// An allocation using a killed or shut down blobber is degraded. A blobber can
+// store data for any number of allocations, so the kill_blobber and the
+// shutdown_blobber transactions don't touch its allocations: an allocation is
+// marked as degraded in the state the next time it is updated or repaired, and
+// the event DB reports it as degraded as soon as its blobber is. A degraded
+// allocation is repaired by the repair_allocation transaction, which replaces its
+// killed and shut down blobbers with the blobbers chosen by the storage SC, exactly
+// as update_allocation_request replaces a blobber. The owner can repair its
+// allocation any time, anyone else only when the allocation has the auto repair
+// policy enabled.
+
+type repairAllocationRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (rar *repairAllocationRequest) decode(b []byte) error {
+	return json.Unmarshal(b, rar)
+}
+
+func (sa *StorageAllocation) removeDegradedBlobber(blobberID string) {
+	for i, id := range sa.DegradedBlobbers {
+		if id == blobberID {
+			sa.DegradedBlobbers = append(sa.DegradedBlobbers[:i], sa.DegradedBlobbers[i+1:]...)
+			return
+		}
+	}
+}
+
+// updateDegradedBlobbers sets the degraded blobbers of the allocation from the
+// given blobbers of the allocation
+func (sa *StorageAllocation) updateDegradedBlobbers(blobbers []*StorageNode) {
+	sa.DegradedBlobbers = nil
+	for _, b := range blobbers {
+		if b.IsKilled() || b.IsShutDown() {
+			sa.DegradedBlobbers = append(sa.DegradedBlobbers, b.ID)
+		}
+	}
+}
+
+// repairAllocation replaces the killed and shut down blobbers of the allocation
+// with the blobbers chosen by the storage SC
+func (sc *StorageSmartContract) repairAllocation(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	conf, err := sc.getConfig(balances, false)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	var req repairAllocationRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if txn.ClientID != alloc.Owner && !alloc.AutoRepair {
+		return "", common.NewError("repair_allocation_failed",
+			"only owner can repair the allocation")
+	}
+
+	if alloc.Finalized || alloc.Canceled || alloc.Expiration < txn.CreationDate {
+		return "", common.NewError("repair_allocation_failed",
+			"can't repair finalized, canceled or expired allocation")
+	}
+
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	alloc.updateDegradedBlobbers(blobbers)
+	if len(alloc.DegradedBlobbers) == 0 {
+		return "", common.NewError("repair_allocation_failed",
+			"allocation has no killed or shut down blobbers")
+	}
+
+	candidates, err := replacementCandidates(alloc, txn, balances)
+	if err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	alloc.Tx = txn.Hash
+	hosts := allocationHosts(blobbers)
+	for _, removeID := range append([]string(nil), alloc.DegradedBlobbers...) {
+		addID, err := selectReplacementBlobber(alloc, hosts, candidates, conf, txn.CreationDate, balances)
+		if err != nil {
+			return "", common.NewErrorf("repair_allocation_failed",
+				"can't replace blobber %s: %v", removeID, err)
+		}
+
+		blobbers, err = alloc.changeBlobbers(
+			conf, blobbers, addID, removeID, txn.CreationDate, balances, sc, alloc.Owner,
+		)
+		if err != nil {
+			return "", common.NewError("repair_allocation_failed", err.Error())
+		}
+
+		balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, txn.Hash, []event.AllocationBlobberTerm{
+			{
+				AllocationIdHash: alloc.ID,
+				BlobberID:        removeID,
+			},
+		})
+	}
+
+	// the new blobbers terms are used, as for update_allocation_request
+	err = sc.extendAllocation(txn, conf, alloc, blobbers, &updateAllocationRequest{ID: alloc.ID}, balances)
+	if err != nil {
+		return "", err
+	}
+
+	if err := alloc.checkFunding(conf.CancellationCharge); err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	if err := alloc.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return "", common.NewError("repair_allocation_failed", err.Error())
+	}
+
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, txn)
+
+	return string(alloc.Encode()), nil
+}
+
+// replacementCandidates returns the IDs of the blobbers which could replace a blobber
+// of the allocation: the preferred blobbers of the allocation first, then the blobbers
+// of a random partition of the blobbers ready for challenges, in a random order seeded
+// by the transaction
+func replacementCandidates(
+	alloc *StorageAllocation,
+	txn *transaction.Transaction,
+	balances cstate.StateContextI,
+) ([]string, error) {
+	parts, err := partitionsChallengeReadyBlobbers(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := strconv.ParseInt(encryption.Hash(txn.Hash)[0:15], 16, 64)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(seed))
+
+	size, err := parts.Size(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	var ready []ChallengeReadyBlobber
+	if size > 0 {
+		if err := parts.GetRandomItems(balances, r, &ready); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(ready))
+	for _, b := range ready {
+		ids = append(ids, b.BlobberID)
+	}
+
+	candidates := make([]string, 0, len(alloc.PreferredBlobbers)+len(ids))
+	candidates = append(candidates, alloc.PreferredBlobbers...)
+	return append(candidates, getRandomSubSlice(ids, len(ids), seed)...), nil
+}
+
+// allocationHosts returns the hosts of the active blobbers of the allocation
+func allocationHosts(blobbers []*StorageNode) map[string]bool {
+	hosts := make(map[string]bool, len(blobbers))
+	for _, b := range blobbers {
+		if b.IsKilled() || b.IsShutDown() {
+			continue
+		}
+		hosts[blobberHost(b)] = true
+	}
+	return hosts
+}
+
+// selectReplacementBlobber returns the first candidate not used by the allocation,
+// which is active, matches the allocation price ranges, has enough capacity and
+// stake and, for an allocation with diverse blobbers, doesn't share a host with
+// the blobbers of the allocation. The host of the returned blobber is added to
+// the hosts, so the next replacement doesn't share it either
+func selectReplacementBlobber(
+	alloc *StorageAllocation,
+	hosts map[string]bool,
+	candidates []string,
+	conf *Config,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) (string, error) {
+	for _, id := range candidates {
+		if _, ok := alloc.BlobberAllocsMap[id]; ok {
+			continue
+		}
+
+		b, err := getBlobber(id, balances)
+		if err != nil {
+			continue
+		}
+
+		if alloc.DiverseBlobbers && hosts[blobberHost(b)] {
+			continue
+		}
+
+		sp, err := getStakePool(spenum.Blobber, id, balances)
+		if err != nil {
+			continue
+		}
+		staked, err := sp.stake()
+		if err != nil {
+			continue
+		}
+
+		if err := alloc.isActive(b, staked, sp.TotalOffers, conf, now); err != nil {
+			continue
+		}
+
+		hosts[blobberHost(b)] = true
+		return id, nil
+	}
+
+	return "", errors.New("no blobber matches the allocation")
+}
+
+func blobberHost(b *StorageNode) string {
+	u, err := url.Parse(b.BaseURL)
+	if err != nil || u.Hostname() == "" {
+		return b.BaseURL
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,132 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func callRepairAllocation(t *testing.T, ssc *StorageSmartContract, clientID, allocID string,
+	now int64, balances *testBalances) (string, error) {
+
+	input, err := json.Marshal(&repairAllocationRequest{AllocationID: allocID})
+	require.NoError(t, err)
+	tx := newTransaction(clientID, ADDRESS, 0, now)
+	balances.setTransaction(t, tx)
+	return ssc.repairAllocation(tx, input, balances)
+}
+
+func killTestBlobber(t *testing.T, ssc *StorageSmartContract, id string, balances *testBalances) {
+	b, err := ssc.getBlobber(id, balances)
+	require.NoError(t, err)
+	b.Kill()
+	_, err = balances.InsertTrieNode(b.GetKey(), b)
+	require.NoError(t, err)
+}
+
+func TestRepairAllocation(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		other    = newClient(100*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, tp, 0, balances)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+
+	// nothing to repair
+	_, err = callRepairAllocation(t, ssc, client.id, allocID, tp+100, balances)
+	require.Error(t, err)
+
+	dead := alloc.BlobberAllocs[0].BlobberID
+	killTestBlobber(t, ssc, dead, balances)
+
+	// the kill doesn't touch the allocations of the blobber, the repair finds it
+	alloc, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Empty(t, alloc.DegradedBlobbers)
+
+	// only the owner repairs the allocation without the auto repair policy
+	_, err = callRepairAllocation(t, ssc, other.id, allocID, tp+100, balances)
+	require.Error(t, err)
+
+	_, err = callRepairAllocation(t, ssc, client.id, allocID, tp+100, balances)
+	require.NoError(t, err)
+
+	repaired, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.Empty(t, repaired.DegradedBlobbers)
+	require.Len(t, repaired.BlobberAllocs, len(alloc.BlobberAllocs))
+	require.NotContains(t, repaired.BlobberAllocsMap, dead)
+	added := repaired.BlobberAllocs[0].BlobberID
+	require.NotContains(t, alloc.BlobberAllocsMap, added)
+
+	// anyone repairs the allocation with the auto repair policy
+	uar := updateAllocationRequest{ID: allocID, AutoRepairChanged: true, AutoRepair: true}
+	_, err = uar.callUpdateAllocReq(t, client.id, 0, tp+200, ssc, balances)
+	require.NoError(t, err)
+
+	killTestBlobber(t, ssc, added, balances)
+	_, err = callRepairAllocation(t, ssc, other.id, allocID, tp+300, balances)
+	require.NoError(t, err)
+
+	repaired, err = ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.True(t, repaired.AutoRepair)
+	require.NotContains(t, repaired.BlobberAllocsMap, added)
+	require.NotContains(t, repaired.BlobberAllocsMap, dead)
+}
+
+func TestSelectReplacementBlobber(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, blobs := addAllocation(t, ssc, client, tp, 0, balances)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	blobbers, err := ssc.getAllocationBlobbers(alloc, balances)
+	require.NoError(t, err)
+	conf, err := ssc.getConfig(balances, false)
+	require.NoError(t, err)
+
+	var spare []string
+	for _, b := range blobs {
+		if _, ok := alloc.BlobberAllocsMap[b.id]; !ok {
+			spare = append(spare, b.id)
+		}
+	}
+	require.NotEmpty(t, spare)
+
+	// the blobbers of the allocation and the killed ones are skipped
+	killTestBlobber(t, ssc, spare[0], balances)
+	candidates := []string{alloc.BlobberAllocs[0].BlobberID, spare[0], spare[1]}
+	id, err := selectReplacementBlobber(alloc, allocationHosts(blobbers), candidates, conf, common.Timestamp(tp+100), balances)
+	require.NoError(t, err)
+	require.Equal(t, spare[1], id)
+
+	// with diverse blobbers a replacement doesn't share a host with the previous ones
+	require.Greater(t, len(spare), 2)
+	alloc.DiverseBlobbers = true
+	hosts := allocationHosts(blobbers)
+	candidates = []string{spare[1], spare[2]}
+	id, err = selectReplacementBlobber(alloc, hosts, candidates, conf, common.Timestamp(tp+100), balances)
+	require.NoError(t, err)
+	require.Equal(t, spare[1], id)
+	id, err = selectReplacementBlobber(alloc, hosts, candidates, conf, common.Timestamp(tp+100), balances)
+	require.NoError(t, err)
+	require.Equal(t, spare[2], id)
+
+	// price ranges
+	alloc.WritePriceRange = PriceRange{Min: 0, Max: 1}
+	_, err = selectReplacementBlobber(alloc, allocationHosts(blobbers), candidates, conf, common.Timestamp(tp+100), balances)
+	require.Error(t, err)
+}
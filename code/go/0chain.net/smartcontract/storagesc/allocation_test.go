@@ -2395,3 +2395,102 @@ func Test_finalize_allocation_do_not_remove_challenge_ready(t *testing.T) {
 		alloc.BlobberAllocs[0].MinLockDemand <= alloc.BlobberAllocs[0].Spent,
 		"should receive min_lock_demand")
 }
+
+func TestReduceAllocation(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		client   = newClient(2000*x10, balances)
+		tp       = int64(1000)
+	)
+
+	allocID, _ := addAllocation(t, ssc, client, tp, 0, balances)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+
+	var (
+		oldSize        = alloc.Size
+		oldBlobberSize = alloc.BlobberAllocs[0].Size
+		allocated      = make(map[string]int64)
+	)
+	for _, ba := range alloc.BlobberAllocs {
+		ba.Stats = &StorageAllocationStats{UsedSize: oldBlobberSize / 4}
+		b, err := ssc.getBlobber(ba.BlobberID, balances)
+		require.NoError(t, err)
+		allocated[ba.BlobberID] = b.Allocated
+	}
+	mustSave(t, alloc.GetKey(ADDRESS), alloc, balances)
+
+	t.Run("below stored data", func(t *testing.T) {
+		uar := updateAllocationRequest{ID: allocID, Size: -(oldSize * 9 / 10)}
+		_, err := uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+		require.Error(t, err)
+	})
+
+	t.Run("below min size", func(t *testing.T) {
+		uar := updateAllocationRequest{ID: allocID, Size: -oldSize}
+		_, err := uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+		require.Error(t, err)
+	})
+
+	t.Run("while extending", func(t *testing.T) {
+		uar := updateAllocationRequest{ID: allocID, Size: -(oldSize / 2), Extend: true}
+		_, err := uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+		require.Error(t, err)
+	})
+
+	before, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+
+	uar := updateAllocationRequest{ID: allocID, Size: -(oldSize / 2)}
+	_, err = uar.callUpdateAllocReq(t, client.id, 0, tp+100, ssc, balances)
+	require.NoError(t, err)
+
+	reduced, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, oldSize-oldSize/2, reduced.Size)
+
+	diff := uar.getBlobbersSizeDiff(alloc)
+	for _, ba := range reduced.BlobberAllocs {
+		require.EqualValues(t, oldBlobberSize+diff, ba.Size)
+		b, err := ssc.getBlobber(ba.BlobberID, balances)
+		require.NoError(t, err)
+		require.EqualValues(t, allocated[ba.BlobberID]+diff, b.Allocated)
+	}
+
+	after, err := balances.GetClientBalance(client.id)
+	require.NoError(t, err)
+	require.True(t, after > before)
+	require.EqualValues(t, alloc.WritePool-(after-before), reduced.WritePool)
+
+	conf, err := ssc.getConfig(balances, false)
+	require.NoError(t, err)
+	require.NoError(t, reduced.checkFunding(conf.CancellationCharge))
+}
+
+func TestStorageAllocation_reduceRefund(t *testing.T) {
+	alloc := &StorageAllocation{
+		Size:      50,
+		WritePool: 1000,
+		BlobberAllocs: []*BlobberAllocation{
+			{MinLockDemand: 100, Spent: 40},
+			{MinLockDemand: 100, Spent: 100},
+		},
+	}
+
+	// half of the write pool, the rest covers the min lock demand
+	refund, err := alloc.reduceRefund(100, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 500, refund)
+
+	// the unpaid min lock demand is kept
+	alloc.WritePool = 100
+	refund, err = alloc.reduceRefund(100, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 40, refund)
+
+	alloc.WritePool = 60
+	refund, err = alloc.reduceRefund(100, 0)
+	require.NoError(t, err)
+	require.Zero(t, refund)
+}
@@ -0,0 +1,93 @@
+package storagesc
+
+import (
+	"fmt"
+
+	"0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/partitions"
+	"github.com/0chain/common/core/util"
+)
+
+const autoRenewAllocationsPartitionSize = 50
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// AutoRenewAllocation represents an allocation renewed from its renewal pool,
+// it will be saved in the auto renew allocations partitions.
+type AutoRenewAllocation struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (a *AutoRenewAllocation) GetID() string {
+	return a.AllocationID
+}
+
+// autoRenewCursor is the index of the next auto renew allocations partition
+// the renew_allocations transaction goes through
+type autoRenewCursor struct {
+	Partition int `json:"partition"`
+}
+
+func getAutoRenewCursor(balances state.StateContextI) (*autoRenewCursor, error) {
+	c := &autoRenewCursor{}
+	err := balances.GetTrieNode(AUTO_RENEW_CURSOR_KEY, c)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return c, nil
+	default:
+		return nil, err
+	}
+}
+
+func (c *autoRenewCursor) save(balances state.StateContextI) error {
+	_, err := balances.InsertTrieNode(AUTO_RENEW_CURSOR_KEY, c)
+	return err
+}
+
+func partitionsAutoRenewAllocations(balances state.StateContextI) (*partitions.Partitions, error) {
+	return partitions.CreateIfNotExists(balances, ALL_AUTO_RENEW_ALLOCATIONS_KEY, autoRenewAllocationsPartitionSize)
+}
+
+func partitionsAutoRenewAllocationsAdd(balances state.StateContextI, allocID string) error {
+	parts, err := partitionsAutoRenewAllocations(balances)
+	if err != nil {
+		return fmt.Errorf("could not get auto renew allocations partitions: %v", err)
+	}
+
+	err = parts.Add(balances, &AutoRenewAllocation{AllocationID: allocID})
+	if err != nil {
+		if partitions.ErrItemExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := parts.Save(balances); err != nil {
+		return fmt.Errorf("could not update auto renew allocations partitions: %v", err)
+	}
+
+	return nil
+}
+
+func partitionsAutoRenewAllocationsRemove(balances state.StateContextI, allocID string) error {
+	parts, err := partitionsAutoRenewAllocations(balances)
+	if err != nil {
+		return fmt.Errorf("could not get auto renew allocations partitions: %v", err)
+	}
+
+	if err := parts.Remove(balances, allocID); err != nil {
+		if partitions.ErrItemNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return parts.Save(balances)
+}
+
+func init() {
+	regInitPartsFunc(func(state state.StateContextI) error {
+		_, err := partitions.CreateIfNotExists(state, ALL_AUTO_RENEW_ALLOCATIONS_KEY, autoRenewAllocationsPartitionSize)
+		return err
+	})
+}
@@ -120,6 +120,13 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getAllocation,
 			},
+			{
+				FuncName: "allocation-acl",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAllocationACL,
+			},
 			{
 				FuncName: "allocations",
 				Params: map[string]string{
@@ -129,6 +136,14 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getAllocations,
 			},
+			{
+				FuncName: "degraded-allocations",
+				Params: map[string]string{
+					"limit":  "20",
+					"offset": "1",
+				},
+				Endpoint: srh.getDegradedAllocations,
+			},
 			{
 				FuncName: "allocation_min_lock",
 				Params: map[string]string{
@@ -950,6 +950,14 @@ func SetMockConfig(
 	conf.BlockReward.QualifyingStake = currency.Coin(viper.GetFloat64(sc.StorageBlockRewardQualifyingStake) * 1e10)
 	conf.MaxBlobbersPerAllocation = viper.GetInt(sc.StorageMaxBlobbersPerAllocation)
 	conf.BlockReward.TriggerPeriod = viper.GetInt64(sc.StorageBlockRewardTriggerPeriod)
+	conf.AutoRenew.TriggerPeriod = viper.GetInt64(sc.StorageAutoRenewTriggerPeriod)
+	conf.AutoRenew.Window = viper.GetDuration(sc.StorageAutoRenewWindow)
+	for _, class := range storageClasses {
+		scc := conf.StorageClasses.get(class)
+		scc.ChallengeFrequency = viper.GetFloat64(sc.StorageClasses + class + ".challenge_frequency")
+		scc.MinLockDemand = viper.GetFloat64(sc.StorageClasses + class + ".min_lock_demand")
+		scc.RewardWeight = viper.GetFloat64(sc.StorageClasses + class + ".reward_weight")
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -967,6 +975,13 @@ func SetMockConfig(
 		"cost.update_allocation_request": mockCost,
 		"cost.finalize_allocation":       mockCost,
 		"cost.cancel_allocation":         mockCost,
+		"cost.repair_allocation":         mockCost,
+		"cost.renew_allocation":          mockCost,
+		"cost.renew_allocations":         mockCost,
+		"cost.renewal_pool_lock":         mockCost,
+		"cost.renewal_pool_unlock":       mockCost,
+		"cost.set_allocation_acl":        mockCost,
+		"cost.remove_allocation_acl":     mockCost,
 		"cost.add_free_storage_assigner": mockCost,
 		"cost.free_allocation_request":   mockCost,
 		"cost.free_update_allocation":    mockCost,
@@ -983,6 +998,7 @@ func SetMockConfig(
 		"cost.write_pool_lock":           mockCost,
 		"cost.write_pool_unlock":         mockCost,
 		"cost.stake_pool_lock":           mockCost,
+		"cost.stake_pool_auto_compound":  mockCost,
 		"cost.stake_pool_unlock":         mockCost,
 		"cost.commit_settings_changes":   mockCost,
 		"cost.collect_reward":            mockCost,
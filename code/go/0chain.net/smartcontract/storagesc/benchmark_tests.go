@@ -18,6 +18,7 @@ import (
 	"0chain.net/chaincore/smartcontract"
 
 	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
 	"0chain.net/core/encryption"
 	bk "0chain.net/smartcontract/benchmark"
 
@@ -116,6 +117,22 @@ func BenchmarkTests(
 	) (string, error) {
 		return ssc.newAllocationRequest(t, r, b, timings)
 	}
+	// renewAllocationFromPool gets the first allocation renewed from its
+	// renewal pool and in its renewal window
+	renewAllocationFromPool := func(now common.Timestamp, balances cstate.StateContextI) error {
+		alloc, err := ssc.getAllocation(getMockAllocationId(0), balances)
+		if err != nil {
+			return err
+		}
+		alloc.AutoRenew = true
+		alloc.RenewalSource = renewFromPool
+		alloc.RenewalPool = 1e13
+		alloc.Expiration = now + 1
+		if _, err := balances.InsertTrieNode(alloc.GetKey(ADDRESS), alloc); err != nil {
+			return err
+		}
+		return partitionsAutoRenewAllocationsAdd(balances, alloc.ID)
+	}
 
 	var tests = []BenchTest{
 		// read/write markers
@@ -270,6 +287,98 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name: "storage.repair_allocation",
+			endpoint: func(
+				txn *transaction.Transaction,
+				input []byte,
+				balances cstate.StateContextI,
+			) (string, error) {
+				// kill the first blobber of the allocation to have it replaced
+				alloc, err := ssc.getAllocation(getMockAllocationId(0), balances)
+				if err != nil {
+					return "", err
+				}
+				b, err := getBlobber(alloc.BlobberAllocs[0].BlobberID, balances)
+				if err != nil {
+					return "", err
+				}
+				b.Kill()
+				if _, err := balances.InsertTrieNode(b.GetKey(), b); err != nil {
+					return "", err
+				}
+				return ssc.repairAllocation(txn, input, balances)
+			},
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, viper.GetInt(bk.NumActiveClients))],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&repairAllocationRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name: "storage.renew_allocation",
+			endpoint: func(
+				txn *transaction.Transaction,
+				input []byte,
+				balances cstate.StateContextI,
+			) (string, error) {
+				if err := renewAllocationFromPool(txn.CreationDate, balances); err != nil {
+					return "", err
+				}
+				return ssc.renewAllocation(txn, input, balances)
+			},
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&renewAllocationRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name: "storage.renew_allocations",
+			endpoint: func(
+				txn *transaction.Transaction,
+				_ []byte,
+				balances cstate.StateContextI,
+			) (string, error) {
+				if err := renewAllocationFromPool(txn.CreationDate, balances); err != nil {
+					return "", err
+				}
+				input, err := json.Marshal(&RenewAllocationsInput{
+					Round: balances.GetBlock().Round,
+				})
+				if err != nil {
+					return "", err
+				}
+				return ssc.renewAllocations(txn, input, balances)
+			},
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				CreationDate: creationTime - 1,
+				ClientID:     data.Miners[0],
+				ToClientID:   ADDRESS,
+			},
+			input: []byte{},
+		},
 		// free data.Allocations
 		{
 			name:     "storage.add_free_storage_assigner",
@@ -579,6 +688,110 @@ func BenchmarkTests(
 			}(),
 		},
 
+		// renewal pool
+		{
+			name:     "storage.renewal_pool_lock",
+			endpoint: ssc.renewalPoolLock,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				Value:        wpMinLock,
+				ClientID:     data.Clients[0],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&renewalPoolRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name: "storage.renewal_pool_unlock",
+			endpoint: func(
+				txn *transaction.Transaction,
+				input []byte,
+				balances cstate.StateContextI,
+			) (string, error) {
+				if err := renewAllocationFromPool(txn.CreationDate, balances); err != nil {
+					return "", err
+				}
+				return ssc.renewalPoolUnlock(txn, input, balances)
+			},
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, viper.GetInt(bk.NumActiveClients))],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&renewalPoolRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+
+		// allocation access control list
+		{
+			name:     "storage.set_allocation_acl",
+			endpoint: ssc.setAllocationACL,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, viper.GetInt(bk.NumActiveClients))],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationACLRequest{
+					AllocationID: getMockAllocationId(0),
+					ClientID:     encryption.Hash("mock collaborator"),
+					Role:         ACLRoleWriter,
+				})
+				return bytes
+			}(),
+		},
+		{
+			name: "storage.remove_allocation_acl",
+			endpoint: func(
+				txn *transaction.Transaction,
+				input []byte,
+				balances cstate.StateContextI,
+			) (string, error) {
+				acl := &AllocationACL{
+					AllocationID: getMockAllocationId(0),
+					Entries: []*AllocationACLEntry{
+						{ClientID: encryption.Hash("mock collaborator"), Role: ACLRoleWriter},
+					},
+				}
+				if _, err := balances.InsertTrieNode(allocationACLKey(acl.AllocationID), acl); err != nil {
+					return "", err
+				}
+				return ssc.removeAllocationACL(txn, input, balances)
+			},
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[getMockOwnerFromAllocationIndex(0, viper.GetInt(bk.NumActiveClients))],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&allocationACLRequest{
+					AllocationID: getMockAllocationId(0),
+					ClientID:     encryption.Hash("mock collaborator"),
+				})
+				return bytes
+			}(),
+		},
+
 		// stake pool
 		{
 			name:     "storage.stake_pool_lock",
@@ -613,6 +826,20 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.stake_pool_auto_compound",
+			endpoint: ssc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.AutoCompoundRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+				AutoCompound: true,
+			}).Encode(),
+		},
 		{
 			name:     "storage.collect_reward",
 			endpoint: ssc.collectReward,
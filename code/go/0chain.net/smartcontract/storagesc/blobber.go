@@ -110,6 +110,17 @@ func (sc *StorageSmartContract) updateBlobber(
 		existingBlobber.NotAvailable = *updateBlobber.NotAvailable
 	}
 
+	if updateBlobber.StorageClass != nil {
+		class := *updateBlobber.StorageClass
+		if err := validateStorageClass(class); err != nil {
+			return err
+		}
+		if storageClassOf(class) != storageClassOf(existingBlobber.StorageClass) && existingBlobber.Allocated > 0 {
+			return errors.New("storage class can't be changed while the blobber has allocated capacity")
+		}
+		existingBlobber.StorageClass = class
+	}
+
 	// storing the current capacity because existing blobber's capacity is updated.
 	currentCapacity := existingBlobber.Capacity
 	if updateBlobber.Capacity != nil {
@@ -18,6 +18,8 @@ func emitUpdateBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateCont
 		ReadPrice:  sn.Terms.ReadPrice,
 		WritePrice: sn.Terms.WritePrice,
 
+		StorageClass: storageClassOf(sn.StorageClass),
+
 		Capacity:     sn.Capacity,
 		Allocated:    sn.Allocated,
 		SavedData:    sn.SavedData,
@@ -49,6 +51,8 @@ func emitAddBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateContext
 		ReadPrice:  sn.Terms.ReadPrice,
 		WritePrice: sn.Terms.WritePrice,
 
+		StorageClass: storageClassOf(sn.StorageClass),
+
 		Capacity:     sn.Capacity,
 		Allocated:    sn.Allocated,
 		SavedData:    sn.SavedData,
@@ -22,6 +22,7 @@ type BlobberRewardNode struct {
 	ReadPrice         currency.Coin `json:"read_price"`
 	TotalData         float64       `json:"total_data"`
 	DataRead          float64       `json:"data_read"`
+	StorageClass      string        `json:"storage_class,omitempty"`
 }
 
 func (bn *BlobberRewardNode) GetID() string {
@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *BlobberRewardNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 7
 	// string "ID"
-	o = append(o, 0x86, 0xa2, 0x49, 0x44)
+	o = append(o, 0x87, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "SuccessChallenges"
 	o = append(o, 0xb1, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x73)
@@ -36,6 +36,9 @@ func (z *BlobberRewardNode) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "DataRead"
 	o = append(o, 0xa8, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x61, 0x64)
 	o = msgp.AppendFloat64(o, z.DataRead)
+	// string "StorageClass"
+	o = append(o, 0xac, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73)
+	o = msgp.AppendString(o, z.StorageClass)
 	return
 }
 
@@ -93,6 +96,12 @@ func (z *BlobberRewardNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "DataRead")
 				return
 			}
+		case "StorageClass":
+			z.StorageClass, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StorageClass")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -107,6 +116,6 @@ func (z *BlobberRewardNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BlobberRewardNode) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 18 + msgp.IntSize + 11 + z.WritePrice.Msgsize() + 10 + z.ReadPrice.Msgsize() + 10 + msgp.Float64Size + 9 + msgp.Float64Size
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 18 + msgp.IntSize + 11 + z.WritePrice.Msgsize() + 10 + z.ReadPrice.Msgsize() + 10 + msgp.Float64Size + 9 + msgp.Float64Size + 13 + msgp.StringPrefixSize + len(z.StorageClass)
 	return
 }
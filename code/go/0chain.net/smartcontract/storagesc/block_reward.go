@@ -150,7 +150,8 @@ func (ssc *StorageSmartContract) blobberBlockRewards(t *transaction.Transaction,
 
 		qualifyingBlobberIds[i] = br.ID
 		totalQStake += stake
-		blobberWeight := ((gamma * zeta) + 1) * stake * float64(br.SuccessChallenges)
+		blobberWeight := ((gamma * zeta) + 1) * stake * float64(br.SuccessChallenges) *
+			conf.rewardWeight(br.StorageClass)
 		weight = append(weight, blobberWeight)
 		totalWeight += blobberWeight
 	}
@@ -661,6 +661,7 @@ func (sc *StorageSmartContract) challengePassed(
 				ReadPrice:         blobber.Terms.ReadPrice,
 				TotalData:         sizeInGB(blobber.SavedData),
 				DataRead:          dataRead,
+				StorageClass:      blobber.StorageClass,
 			})
 		if err != nil {
 			return "", common.NewError("verify_challenge",
@@ -1016,6 +1017,15 @@ func (sc *StorageSmartContract) populateGenerateChallenge(
 		return nil, nil
 	}
 
+	// the blobbers of the less frequently challenged storage classes are
+	// let off some of the challenges
+	if conf.skipChallenge(alloc.StorageClass, r) {
+		logging.Logger.Debug("populate_generate_challenge: challenge skipped for the storage class",
+			zap.String("blobberId", blobberID),
+			zap.String("storage class", storageClassOf(alloc.StorageClass)))
+		return nil, nil
+	}
+
 	allocBlobber, ok := alloc.BlobberAllocsMap[blobberID]
 	if !ok {
 		return nil, errors.New("invalid blobber for allocation")
@@ -19,6 +19,11 @@ func scConfigKey(scKey string) datastore.Key {
 	return scKey + encryption.Hash("storagesc_config")
 }
 
+// ConfigKey returns the key of the storage SC configurations in the state
+func ConfigKey() datastore.Key {
+	return scConfigKey(ADDRESS)
+}
+
 type freeAllocationSettings struct {
 	DataShards       int        `json:"data_shards"`
 	ParityShards     int        `json:"parity_shards"`
@@ -51,6 +56,32 @@ type blockReward struct {
 	Zeta                    blockRewardZeta  `json:"zeta"`
 }
 
+type autoRenewConfig struct {
+	// TriggerPeriod is the number of rounds between the auto renewals.
+	TriggerPeriod int64 `json:"trigger_period"`
+	// Window is the time before the expiration an allocation is renewed.
+	Window time.Duration `json:"window"`
+}
+
+// storageClassConfig is the configuration of a storage class, the zero values
+// fall back to the defaults.
+type storageClassConfig struct {
+	// ChallengeFrequency is the chance, in [0; 1] range, a blobber of the
+	// class drawn for a challenge is challenged, 1 if not set.
+	ChallengeFrequency float64 `json:"challenge_frequency"`
+	// MinLockDemand of the allocations of the class, min_lock_demand if not set.
+	MinLockDemand float64 `json:"min_lock_demand"`
+	// RewardWeight multiplies the block reward weight of the blobbers of the
+	// class, 1 if not set.
+	RewardWeight float64 `json:"reward_weight"`
+}
+
+type storageClassesConfig struct {
+	Hot     storageClassConfig `json:"hot"`
+	Cold    storageClassConfig `json:"cold"`
+	Archive storageClassConfig `json:"archive"`
+}
+
 type blockRewardGamma struct {
 	Alpha float64 `json:"alpha"`
 	A     float64 `json:"a"`
@@ -147,6 +178,12 @@ type Config struct {
 
 	BlockReward *blockReward `json:"block_reward"`
 
+	// AutoRenew related configurations.
+	AutoRenew autoRenewConfig `json:"auto_renew"`
+
+	// StorageClasses configurations, per storage class.
+	StorageClasses storageClassesConfig `json:"storage_classes"`
+
 	OwnerId string         `json:"owner_id"`
 	Cost    map[string]int `json:"cost"`
 }
@@ -268,6 +305,29 @@ func (conf *Config) validate() (err error) {
 		return fmt.Errorf("invalid block_reward.zeta.k <=0: %v", conf.BlockReward.Zeta.K)
 	}
 
+	if conf.AutoRenew.TriggerPeriod < 0 {
+		return fmt.Errorf("negative auto_renew.trigger_period: %v", conf.AutoRenew.TriggerPeriod)
+	}
+	if conf.AutoRenew.Window < 0 || conf.AutoRenew.Window >= conf.TimeUnit {
+		return fmt.Errorf("auto_renew.window not in [0; time_unit) range: %v", conf.AutoRenew.Window)
+	}
+
+	for _, class := range storageClasses {
+		sc := conf.StorageClasses.get(class)
+		if sc.ChallengeFrequency < 0.0 || 1.0 < sc.ChallengeFrequency {
+			return fmt.Errorf("storage_classes.%s.challenge_frequency not in [0; 1] range: %v",
+				class, sc.ChallengeFrequency)
+		}
+		if sc.MinLockDemand < 0.0 || 1.0 < sc.MinLockDemand {
+			return fmt.Errorf("storage_classes.%s.min_lock_demand not in [0; 1] range: %v",
+				class, sc.MinLockDemand)
+		}
+		if sc.RewardWeight < 0.0 {
+			return fmt.Errorf("negative storage_classes.%s.reward_weight: %v",
+				class, sc.RewardWeight)
+		}
+	}
+
 	return
 }
 
@@ -446,6 +506,17 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.BlockReward.Zeta.K = scc.GetFloat64(pfx + "block_reward.zeta.k")
 	conf.BlockReward.Zeta.Mu = scc.GetFloat64(pfx + "block_reward.zeta.mu")
 
+	conf.AutoRenew.TriggerPeriod = scc.GetInt64(pfx + "auto_renew.trigger_period")
+	conf.AutoRenew.Window = scc.GetDuration(pfx + "auto_renew.window")
+
+	for _, class := range storageClasses {
+		sc := conf.StorageClasses.get(class)
+		scp := pfx + "storage_classes." + class + "."
+		sc.ChallengeFrequency = scc.GetFloat64(scp + "challenge_frequency")
+		sc.MinLockDemand = scc.GetFloat64(scp + "min_lock_demand")
+		sc.RewardWeight = scc.GetFloat64(scp + "reward_weight")
+	}
+
 	conf.OwnerId = scc.GetString(pfx + "owner_id")
 	conf.Cost = scc.GetStringMapInt(pfx + "cost")
 
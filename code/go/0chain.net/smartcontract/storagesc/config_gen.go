@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 32
+	// map header, size 34
 	// string "TimeUnit"
-	o = append(o, 0xde, 0x0, 0x20, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
+	o = append(o, 0xde, 0x0, 0x22, 0xa8, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74)
 	o = msgp.AppendDuration(o, z.TimeUnit)
 	// string "MaxMint"
 	o = append(o, 0xa7, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x74)
@@ -184,6 +184,22 @@ func (z *Config) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "AutoRenew"
+	o = append(o, 0xa9, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6e, 0x65, 0x77)
+	// map header, size 2
+	// string "TriggerPeriod"
+	o = append(o, 0x82, 0xad, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.AutoRenew.TriggerPeriod)
+	// string "Window"
+	o = append(o, 0xa6, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77)
+	o = msgp.AppendDuration(o, z.AutoRenew.Window)
+	// string "StorageClasses"
+	o = append(o, 0xae, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73)
+	o, err = z.StorageClasses.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "StorageClasses")
+		return
+	}
 	// string "OwnerId"
 	o = append(o, 0xa7, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64)
 	o = msgp.AppendString(o, z.OwnerId)
@@ -520,6 +536,47 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "AutoRenew":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoRenew")
+				return
+			}
+			for zb0005 > 0 {
+				zb0005--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AutoRenew")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "TriggerPeriod":
+					z.AutoRenew.TriggerPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "AutoRenew", "TriggerPeriod")
+						return
+					}
+				case "Window":
+					z.AutoRenew.Window, bts, err = msgp.ReadDurationBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "AutoRenew", "Window")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "AutoRenew")
+						return
+					}
+				}
+			}
+		case "StorageClasses":
+			bts, err = z.StorageClasses.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StorageClasses")
+				return
+			}
 		case "OwnerId":
 			z.OwnerId, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
@@ -527,23 +584,23 @@ func (z *Config) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				return
 			}
 		case "Cost":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Cost")
 				return
 			}
 			if z.Cost == nil {
-				z.Cost = make(map[string]int, zb0005)
+				z.Cost = make(map[string]int, zb0006)
 			} else if len(z.Cost) > 0 {
 				for key := range z.Cost {
 					delete(z.Cost, key)
 				}
 			}
-			for zb0005 > 0 {
+			for zb0006 > 0 {
 				var za0001 string
 				var za0002 int
-				zb0005--
+				zb0006--
 				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Cost")
@@ -594,7 +651,7 @@ func (z *Config) Msgsize() (s int) {
 	} else {
 		s += z.BlockReward.Msgsize()
 	}
-	s += 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
+	s += 10 + 1 + 14 + msgp.Int64Size + 7 + msgp.DurationSize + 15 + z.StorageClasses.Msgsize() + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
 			_ = za0002
@@ -604,6 +661,67 @@ func (z *Config) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z autoRenewConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "TriggerPeriod"
+	o = append(o, 0x82, 0xad, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.TriggerPeriod)
+	// string "Window"
+	o = append(o, 0xa6, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77)
+	o = msgp.AppendDuration(o, z.Window)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *autoRenewConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "TriggerPeriod":
+			z.TriggerPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TriggerPeriod")
+				return
+			}
+		case "Window":
+			z.Window, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Window")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z autoRenewConfig) Msgsize() (s int) {
+	s = 1 + 14 + msgp.Int64Size + 7 + msgp.DurationSize
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *blockReward) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -1168,6 +1286,278 @@ func (z stakePoolConfig) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z storageClassConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "ChallengeFrequency"
+	o = append(o, 0x83, 0xb2, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79)
+	o = msgp.AppendFloat64(o, z.ChallengeFrequency)
+	// string "MinLockDemand"
+	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64)
+	o = msgp.AppendFloat64(o, z.MinLockDemand)
+	// string "RewardWeight"
+	o = append(o, 0xac, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.RewardWeight)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *storageClassConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ChallengeFrequency":
+			z.ChallengeFrequency, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChallengeFrequency")
+				return
+			}
+		case "MinLockDemand":
+			z.MinLockDemand, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinLockDemand")
+				return
+			}
+		case "RewardWeight":
+			z.RewardWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RewardWeight")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z storageClassConfig) Msgsize() (s int) {
+	s = 1 + 19 + msgp.Float64Size + 14 + msgp.Float64Size + 13 + msgp.Float64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *storageClassesConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Hot"
+	o = append(o, 0x83, 0xa3, 0x48, 0x6f, 0x74)
+	// map header, size 3
+	// string "ChallengeFrequency"
+	o = append(o, 0x83, 0xb2, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79)
+	o = msgp.AppendFloat64(o, z.Hot.ChallengeFrequency)
+	// string "MinLockDemand"
+	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64)
+	o = msgp.AppendFloat64(o, z.Hot.MinLockDemand)
+	// string "RewardWeight"
+	o = append(o, 0xac, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.Hot.RewardWeight)
+	// string "Cold"
+	o = append(o, 0xa4, 0x43, 0x6f, 0x6c, 0x64)
+	// map header, size 3
+	// string "ChallengeFrequency"
+	o = append(o, 0x83, 0xb2, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79)
+	o = msgp.AppendFloat64(o, z.Cold.ChallengeFrequency)
+	// string "MinLockDemand"
+	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64)
+	o = msgp.AppendFloat64(o, z.Cold.MinLockDemand)
+	// string "RewardWeight"
+	o = append(o, 0xac, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.Cold.RewardWeight)
+	// string "Archive"
+	o = append(o, 0xa7, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65)
+	// map header, size 3
+	// string "ChallengeFrequency"
+	o = append(o, 0x83, 0xb2, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79)
+	o = msgp.AppendFloat64(o, z.Archive.ChallengeFrequency)
+	// string "MinLockDemand"
+	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x6d, 0x61, 0x6e, 0x64)
+	o = msgp.AppendFloat64(o, z.Archive.MinLockDemand)
+	// string "RewardWeight"
+	o = append(o, 0xac, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74)
+	o = msgp.AppendFloat64(o, z.Archive.RewardWeight)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *storageClassesConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Hot":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Hot")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Hot")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "ChallengeFrequency":
+					z.Hot.ChallengeFrequency, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Hot", "ChallengeFrequency")
+						return
+					}
+				case "MinLockDemand":
+					z.Hot.MinLockDemand, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Hot", "MinLockDemand")
+						return
+					}
+				case "RewardWeight":
+					z.Hot.RewardWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Hot", "RewardWeight")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Hot")
+						return
+					}
+				}
+			}
+		case "Cold":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Cold")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Cold")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "ChallengeFrequency":
+					z.Cold.ChallengeFrequency, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Cold", "ChallengeFrequency")
+						return
+					}
+				case "MinLockDemand":
+					z.Cold.MinLockDemand, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Cold", "MinLockDemand")
+						return
+					}
+				case "RewardWeight":
+					z.Cold.RewardWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Cold", "RewardWeight")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Cold")
+						return
+					}
+				}
+			}
+		case "Archive":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Archive")
+				return
+			}
+			for zb0004 > 0 {
+				zb0004--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Archive")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "ChallengeFrequency":
+					z.Archive.ChallengeFrequency, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Archive", "ChallengeFrequency")
+						return
+					}
+				case "MinLockDemand":
+					z.Archive.MinLockDemand, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Archive", "MinLockDemand")
+						return
+					}
+				case "RewardWeight":
+					z.Archive.RewardWeight, bts, err = msgp.ReadFloat64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Archive", "RewardWeight")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Archive")
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *storageClassesConfig) Msgsize() (s int) {
+	s = 1 + 4 + 1 + 19 + msgp.Float64Size + 14 + msgp.Float64Size + 13 + msgp.Float64Size + 5 + 1 + 19 + msgp.Float64Size + 14 + msgp.Float64Size + 13 + msgp.Float64Size + 8 + 1 + 19 + msgp.Float64Size + 14 + msgp.Float64Size + 13 + msgp.Float64Size
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *writePoolConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
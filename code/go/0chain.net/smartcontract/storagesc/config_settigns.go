@@ -12,6 +12,7 @@ import (
 	"github.com/0chain/common/core/currency"
 
 	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/smartcontract/governancesc"
 
 	"0chain.net/core/encryption"
 	"github.com/0chain/common/core/util"
@@ -80,6 +81,19 @@ const (
 	BlockRewardZetaK
 	BlockRewardZetaMu
 
+	AutoRenewTriggerPeriod
+	AutoRenewWindow
+
+	HotChallengeFrequency
+	HotMinLockDemand
+	HotRewardWeight
+	ColdChallengeFrequency
+	ColdMinLockDemand
+	ColdRewardWeight
+	ArchiveChallengeFrequency
+	ArchiveMinLockDemand
+	ArchiveRewardWeight
+
 	OwnerId
 
 	CostUpdateSettings
@@ -106,12 +120,20 @@ const (
 	CostWritePoolUnlock
 	CostStakePoolLock
 	CostStakePoolUnlock
+	CostStakePoolAutoCompound
 	CostCommitSettingsChanges
 	CostCollectReward
 	CostKillBlobber
 	CostKillValidator
 	CostShutdownBlobber
 	CostShutdownValidator
+	CostRepairAllocation
+	CostRenewAllocation
+	CostRenewAllocations
+	CostRenewalPoolLock
+	CostRenewalPoolUnlock
+	CostSetAllocationACL
+	CostRemoveAllocationACL
 	MaxCharge
 	NumberOfSettings
 )
@@ -181,6 +203,18 @@ func initSettingName() {
 	SettingName[BlockRewardZetaI] = "block_reward.zeta.i"
 	SettingName[BlockRewardZetaK] = "block_reward.zeta.k"
 	SettingName[BlockRewardZetaMu] = "block_reward.zeta.mu"
+
+	SettingName[AutoRenewTriggerPeriod] = "auto_renew.trigger_period"
+	SettingName[AutoRenewWindow] = "auto_renew.window"
+	SettingName[HotChallengeFrequency] = "storage_classes.hot.challenge_frequency"
+	SettingName[HotMinLockDemand] = "storage_classes.hot.min_lock_demand"
+	SettingName[HotRewardWeight] = "storage_classes.hot.reward_weight"
+	SettingName[ColdChallengeFrequency] = "storage_classes.cold.challenge_frequency"
+	SettingName[ColdMinLockDemand] = "storage_classes.cold.min_lock_demand"
+	SettingName[ColdRewardWeight] = "storage_classes.cold.reward_weight"
+	SettingName[ArchiveChallengeFrequency] = "storage_classes.archive.challenge_frequency"
+	SettingName[ArchiveMinLockDemand] = "storage_classes.archive.min_lock_demand"
+	SettingName[ArchiveRewardWeight] = "storage_classes.archive.reward_weight"
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CostUpdateSettings] = "cost.update_settings"
 	SettingName[CostReadRedeem] = "cost.read_redeem"
@@ -206,12 +240,20 @@ func initSettingName() {
 	SettingName[CostWritePoolUnlock] = "cost.write_pool_unlock"
 	SettingName[CostStakePoolLock] = "cost.stake_pool_lock"
 	SettingName[CostStakePoolUnlock] = "cost.stake_pool_unlock"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
 	SettingName[CostCommitSettingsChanges] = "cost.commit_settings_changes"
 	SettingName[CostCollectReward] = "cost.collect_reward"
 	SettingName[CostKillBlobber] = "cost.kill_blobber"
 	SettingName[CostKillValidator] = "cost.kill_validator"
 	SettingName[CostShutdownBlobber] = "cost.shutdown_blobber"
 	SettingName[CostShutdownValidator] = "cost.shutdown_validator"
+	SettingName[CostRepairAllocation] = "cost.repair_allocation"
+	SettingName[CostRenewAllocation] = "cost.renew_allocation"
+	SettingName[CostRenewAllocations] = "cost.renew_allocations"
+	SettingName[CostRenewalPoolLock] = "cost.renewal_pool_lock"
+	SettingName[CostRenewalPoolUnlock] = "cost.renewal_pool_unlock"
+	SettingName[CostSetAllocationACL] = "cost.set_allocation_acl"
+	SettingName[CostRemoveAllocationACL] = "cost.remove_allocation_acl"
 }
 
 func initSettings() {
@@ -263,6 +305,17 @@ func initSettings() {
 		BlockRewardZetaI.String():                 {BlockRewardZetaI, config.Float64},
 		BlockRewardZetaK.String():                 {BlockRewardZetaK, config.Float64},
 		BlockRewardZetaMu.String():                {BlockRewardZetaMu, config.Float64},
+		AutoRenewTriggerPeriod.String():           {AutoRenewTriggerPeriod, config.Int64},
+		AutoRenewWindow.String():                  {AutoRenewWindow, config.Duration},
+		HotChallengeFrequency.String():            {HotChallengeFrequency, config.Float64},
+		HotMinLockDemand.String():                 {HotMinLockDemand, config.Float64},
+		HotRewardWeight.String():                  {HotRewardWeight, config.Float64},
+		ColdChallengeFrequency.String():           {ColdChallengeFrequency, config.Float64},
+		ColdMinLockDemand.String():                {ColdMinLockDemand, config.Float64},
+		ColdRewardWeight.String():                 {ColdRewardWeight, config.Float64},
+		ArchiveChallengeFrequency.String():        {ArchiveChallengeFrequency, config.Float64},
+		ArchiveMinLockDemand.String():             {ArchiveMinLockDemand, config.Float64},
+		ArchiveRewardWeight.String():              {ArchiveRewardWeight, config.Float64},
 		OwnerId.String():                          {OwnerId, config.Key},
 		CostUpdateSettings.String():               {CostUpdateSettings, config.Cost},
 		CostReadRedeem.String():                   {CostReadRedeem, config.Cost},
@@ -288,12 +341,20 @@ func initSettings() {
 		CostWritePoolUnlock.String():              {CostWritePoolUnlock, config.Cost},
 		CostStakePoolLock.String():                {CostStakePoolLock, config.Cost},
 		CostStakePoolUnlock.String():              {CostStakePoolUnlock, config.Cost},
+		CostStakePoolAutoCompound.String():        {CostStakePoolAutoCompound, config.Cost},
 		CostCommitSettingsChanges.String():        {CostCommitSettingsChanges, config.Cost},
 		CostCollectReward.String():                {CostCollectReward, config.Cost},
 		CostKillBlobber.String():                  {CostKillBlobber, config.Cost},
 		CostKillValidator.String():                {CostKillValidator, config.Cost},
 		CostShutdownBlobber.String():              {CostShutdownBlobber, config.Cost},
 		CostShutdownValidator.String():            {CostShutdownValidator, config.Cost},
+		CostRepairAllocation.String():             {CostRepairAllocation, config.Cost},
+		CostRenewAllocation.String():              {CostRenewAllocation, config.Cost},
+		CostRenewAllocations.String():             {CostRenewAllocations, config.Cost},
+		CostRenewalPoolLock.String():              {CostRenewalPoolLock, config.Cost},
+		CostRenewalPoolUnlock.String():            {CostRenewalPoolUnlock, config.Cost},
+		CostSetAllocationACL.String():             {CostSetAllocationACL, config.Cost},
+		CostRemoveAllocationACL.String():          {CostRemoveAllocationACL, config.Cost},
 	}
 }
 
@@ -436,6 +497,8 @@ func (conf *Config) setInt64(key string, change int64) error {
 		conf.MinBlobberCapacity = change
 	case FreeAllocationSize:
 		conf.FreeAllocationSettings.Size = change
+	case AutoRenewTriggerPeriod:
+		conf.AutoRenew.TriggerPeriod = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -489,6 +552,24 @@ func (conf *Config) setFloat64(key string, change float64) error {
 			conf.BlockReward = &blockReward{}
 		}
 		conf.BlockReward.Zeta.Mu = change
+	case HotChallengeFrequency:
+		conf.StorageClasses.Hot.ChallengeFrequency = change
+	case HotMinLockDemand:
+		conf.StorageClasses.Hot.MinLockDemand = change
+	case HotRewardWeight:
+		conf.StorageClasses.Hot.RewardWeight = change
+	case ColdChallengeFrequency:
+		conf.StorageClasses.Cold.ChallengeFrequency = change
+	case ColdMinLockDemand:
+		conf.StorageClasses.Cold.MinLockDemand = change
+	case ColdRewardWeight:
+		conf.StorageClasses.Cold.RewardWeight = change
+	case ArchiveChallengeFrequency:
+		conf.StorageClasses.Archive.ChallengeFrequency = change
+	case ArchiveMinLockDemand:
+		conf.StorageClasses.Archive.MinLockDemand = change
+	case ArchiveRewardWeight:
+		conf.StorageClasses.Archive.RewardWeight = change
 	default:
 		return fmt.Errorf("key: %v not implemented as float64", key)
 	}
@@ -508,6 +589,8 @@ func (conf *Config) setDuration(key string, change time.Duration) error {
 		conf.StakePool.MinLockPeriod = change
 	case HealthCheckPeriod:
 		conf.HealthCheckPeriod = change
+	case AutoRenewWindow:
+		conf.AutoRenew.Window = change
 	default:
 		return fmt.Errorf("key: %v not implemented as duration", key)
 	}
@@ -704,6 +787,28 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.BlockReward.Zeta.K
 	case BlockRewardZetaMu:
 		return conf.BlockReward.Zeta.Mu
+	case AutoRenewTriggerPeriod:
+		return conf.AutoRenew.TriggerPeriod
+	case AutoRenewWindow:
+		return conf.AutoRenew.Window
+	case HotChallengeFrequency:
+		return conf.StorageClasses.Hot.ChallengeFrequency
+	case HotMinLockDemand:
+		return conf.StorageClasses.Hot.MinLockDemand
+	case HotRewardWeight:
+		return conf.StorageClasses.Hot.RewardWeight
+	case ColdChallengeFrequency:
+		return conf.StorageClasses.Cold.ChallengeFrequency
+	case ColdMinLockDemand:
+		return conf.StorageClasses.Cold.MinLockDemand
+	case ColdRewardWeight:
+		return conf.StorageClasses.Cold.RewardWeight
+	case ArchiveChallengeFrequency:
+		return conf.StorageClasses.Archive.ChallengeFrequency
+	case ArchiveMinLockDemand:
+		return conf.StorageClasses.Archive.MinLockDemand
+	case ArchiveRewardWeight:
+		return conf.StorageClasses.Archive.RewardWeight
 	case OwnerId:
 		return conf.OwnerId
 	case MaxCharge:
@@ -738,7 +843,7 @@ func (ssc *StorageSmartContract) updateSettings(
 	}
 
 	if err := smartcontractinterface.AuthorizeWithOwner("update_settings", func() bool {
-		return conf.OwnerId == t.ClientID
+		return conf.OwnerId == t.ClientID || t.ClientID == governancesc.ADDRESS
 	}); err != nil {
 		return "", err
 	}
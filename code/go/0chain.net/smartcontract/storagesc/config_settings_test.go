@@ -535,6 +535,28 @@ func getConfField(conf Config, field string) interface{} {
 		return conf.BlockReward.Zeta.K
 	case BlockRewardZetaMu:
 		return conf.BlockReward.Zeta.Mu
+	case AutoRenewTriggerPeriod:
+		return conf.AutoRenew.TriggerPeriod
+	case AutoRenewWindow:
+		return conf.AutoRenew.Window
+	case HotChallengeFrequency:
+		return conf.StorageClasses.Hot.ChallengeFrequency
+	case HotMinLockDemand:
+		return conf.StorageClasses.Hot.MinLockDemand
+	case HotRewardWeight:
+		return conf.StorageClasses.Hot.RewardWeight
+	case ColdChallengeFrequency:
+		return conf.StorageClasses.Cold.ChallengeFrequency
+	case ColdMinLockDemand:
+		return conf.StorageClasses.Cold.MinLockDemand
+	case ColdRewardWeight:
+		return conf.StorageClasses.Cold.RewardWeight
+	case ArchiveChallengeFrequency:
+		return conf.StorageClasses.Archive.ChallengeFrequency
+	case ArchiveMinLockDemand:
+		return conf.StorageClasses.Archive.MinLockDemand
+	case ArchiveRewardWeight:
+		return conf.StorageClasses.Archive.RewardWeight
 	case OwnerId:
 		return conf.OwnerId
 	default:
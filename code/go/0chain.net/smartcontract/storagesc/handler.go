@@ -63,9 +63,11 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/writemarkers", common.UserRateLimit(srh.getWriteMarker)),
 		rest.MakeEndpoint(storage+"/errors", common.UserRateLimit(srh.getErrors)),
 		rest.MakeEndpoint(storage+"/allocations", common.UserRateLimit(srh.getAllocations)),
+		rest.MakeEndpoint(storage+"/degraded-allocations", common.UserRateLimit(srh.getDegradedAllocations)),
 		rest.MakeEndpoint(storage+"/allocation_min_lock", common.UserRateLimit(srh.getAllocationMinLock)),
 		rest.MakeEndpoint(storage+"/allocation-update-min-lock", common.UserRateLimit(srh.getAllocationUpdateMinLock)),
 		rest.MakeEndpoint(storage+"/allocation", common.UserRateLimit(srh.getAllocation)),
+		rest.MakeEndpoint(storage+"/allocation-acl", common.UserRateLimit(srh.getAllocationACL)),
 		rest.MakeEndpoint(storage+"/latestreadmarker", common.UserRateLimit(srh.getLatestReadMarker)),
 		rest.MakeEndpoint(storage+"/readmarkers", common.UserRateLimit(srh.getReadMarkers)),
 		rest.MakeEndpoint(storage+"/count_readmarkers", common.UserRateLimit(srh.getReadMarkersCount)),
@@ -279,6 +281,7 @@ type allocationBlobbersRequest struct {
 	ReadPriceRange  PriceRange `json:"read_price_range"`
 	WritePriceRange PriceRange `json:"write_price_range"`
 	Size            int64      `json:"size"`
+	StorageClass    string     `json:"storage_class"`
 }
 
 func (nar *allocationBlobbersRequest) decode(b []byte) error {
@@ -373,6 +376,10 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 			"invalid data shards:%v or parity shards:%v", request.DataShards, request.ParityShards)
 	}
 
+	if err := validateStorageClass(request.StorageClass); err != nil {
+		return nil, common.NewError("allocation_creation_failed", err.Error())
+	}
+
 	var allocationSize = bSize(request.Size, request.DataShards)
 
 	allocation := event.AllocationQuery{
@@ -393,6 +400,7 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 		AllocationSize:     allocationSize,
 		AllocationSizeInGB: sizeInGB(allocationSize),
 		NumberOfDataShards: request.DataShards,
+		StorageClass:       storageClassOf(request.StorageClass),
 	}
 
 	logging.Logger.Debug("alloc_blobbers", zap.Int64("ReadPriceRange.Min", allocation.ReadPriceRange.Min),
@@ -2004,6 +2012,62 @@ func (srh *StorageRestHandler) getAllocations(w http.ResponseWriter, r *http.Req
 	common.Respond(w, r, allocations, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/degraded-allocations degraded-allocations
+// Gets a list of the active allocations using killed or shut down blobbers
+//
+// parameters:
+//
+//	+name: client
+//	 description: owner of allocations we wish to list, all the owners if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []StorageAllocation
+//	400:
+//	500:
+func (srh *StorageRestHandler) getDegradedAllocations(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client")
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	allocations, err := edb.GetDegradedAllocations(clientID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get allocations"))
+		return
+	}
+
+	sas, err := prepareAllocationsResponse(edb, allocations)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't prepare allocations response"))
+		return
+	}
+
+	common.Respond(w, r, sas, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-allocations allocations
 // Gets a list of allocation information for allocations owned by the client
 //
@@ -2104,6 +2168,61 @@ func (srh *StorageRestHandler) getAllocation(w http.ResponseWriter, r *http.Requ
 	common.Respond(w, r, sa, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-acl allocation-acl
+// Gets the collaborators of an allocation with their roles and expiry, expired entries included
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: id of allocation
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: client_id
+//	 description: collaborator to get, all the collaborators if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []AllocationACL
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationACL(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation id"))
+		return
+	}
+	clientID := r.URL.Query().Get("client_id")
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	acl, err := edb.GetAllocationACL(allocationID, clientID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation acl", err.Error()))
+		return
+	}
+
+	common.Respond(w, r, acl, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/errors errors
 // Gets errors returned by indicated transaction
 //
@@ -2404,9 +2523,10 @@ type storageNodeResponse struct {
 	ID                      string                 `json:"id" validate:"hexadecimal,len=64"`
 	BaseURL                 string                 `json:"url"`
 	Geolocation             StorageNodeGeolocation `json:"geolocation"`
-	Terms                   Terms                  `json:"terms"`     // terms
-	Capacity                int64                  `json:"capacity"`  // total blobber capacity
-	Allocated               int64                  `json:"allocated"` // allocated capacity
+	Terms                   Terms                  `json:"terms"`         // terms
+	StorageClass            string                 `json:"storage_class"` // storage class of the capacity
+	Capacity                int64                  `json:"capacity"`      // total blobber capacity
+	Allocated               int64                  `json:"allocated"`     // allocated capacity
 	LastHealthCheck         common.Timestamp       `json:"last_health_check"`
 	IsKilled                bool                   `json:"is_killed"`
 	IsShutdown              bool                   `json:"is_shutdown"`
@@ -2434,6 +2554,7 @@ func StoragNodeToStorageNodeResponse(sn StorageNode) storageNodeResponse {
 		BaseURL:                 sn.BaseURL,
 		Geolocation:             sn.Geolocation,
 		Terms:                   sn.Terms,
+		StorageClass:            storageClassOf(sn.StorageClass),
 		Capacity:                sn.Capacity,
 		Allocated:               sn.Allocated,
 		LastHealthCheck:         sn.LastHealthCheck,
@@ -2461,6 +2582,7 @@ func StoragNodeResponseToStorageNode(snr storageNodeResponse) StorageNode {
 		BaseURL:                 snr.BaseURL,
 		Geolocation:             snr.Geolocation,
 		Terms:                   snr.Terms,
+		StorageClass:            snr.StorageClass,
 		Capacity:                snr.Capacity,
 		Allocated:               snr.Allocated,
 		PublicKey:               snr.PublicKey,
@@ -2485,6 +2607,7 @@ func blobberTableToStorageNode(blobber event.Blobber) storageNodeResponse {
 			ReadPrice:  blobber.ReadPrice,
 			WritePrice: blobber.WritePrice,
 		},
+		StorageClass:    blobber.StorageClass,
 		Capacity:        blobber.Capacity,
 		Allocated:       blobber.Allocated,
 		LastHealthCheck: blobber.LastHealthCheck,
@@ -427,6 +427,17 @@ func setConfig(t testing.TB, balances chainState.StateContextI) (
 		QualifyingStake: 1,
 	}
 
+	conf.AutoRenew = autoRenewConfig{
+		TriggerPeriod: 100,
+		Window:        24 * time.Hour,
+	}
+
+	conf.StorageClasses = storageClassesConfig{
+		Hot:     storageClassConfig{ChallengeFrequency: 1, RewardWeight: 1},
+		Cold:    storageClassConfig{ChallengeFrequency: 0.5, MinLockDemand: 0.2, RewardWeight: 1.5},
+		Archive: storageClassConfig{ChallengeFrequency: 0.1, MinLockDemand: 0.5, RewardWeight: 5},
+	}
+
 	conf.CancellationCharge = 0.2
 	conf.MaxIndividualFreeAllocation = 1000000
 	conf.MaxTotalFreeAllocation = 100000000000000000
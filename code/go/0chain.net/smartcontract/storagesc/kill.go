@@ -15,6 +15,7 @@ import (
 // killBlobber
 // punitively disables a blobber. it will no longer be used for new allocations
 // or receive further rewards. Stakeholders will have their stakes slashed.
+// Its allocations are marked as degraded lazily, see allocation_repair.go.
 func (_ *StorageSmartContract) killBlobber(
 	tx *transaction.Transaction,
 	input []byte,
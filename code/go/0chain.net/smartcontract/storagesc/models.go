@@ -43,6 +43,8 @@ var (
 	ALL_VALIDATORS_KEY               = ADDRESS + encryption.Hash("all_validators")
 	ALL_CHALLENGE_READY_BLOBBERS_KEY = ADDRESS + encryption.Hash("all_challenge_ready_blobbers")
 	BLOBBER_REWARD_KEY               = ADDRESS + encryption.Hash("blobber_rewards")
+	ALL_AUTO_RENEW_ALLOCATIONS_KEY   = ADDRESS + encryption.Hash("all_auto_renew_allocations")
+	AUTO_RENEW_CURSOR_KEY            = ADDRESS + encryption.Hash("auto_renew_cursor")
 )
 
 func getBlobberAllocationsKey(blobberID string) string {
@@ -337,9 +339,10 @@ type StorageNode struct {
 	provider.Provider
 	BaseURL                 string                 `json:"url"`
 	Geolocation             StorageNodeGeolocation `json:"geolocation"`
-	Terms                   Terms                  `json:"terms"`     // terms
-	Capacity                int64                  `json:"capacity"`  // total blobber capacity
-	Allocated               int64                  `json:"allocated"` // allocated capacity
+	Terms                   Terms                  `json:"terms"`         // terms
+	StorageClass            string                 `json:"storage_class"` // storage class of the capacity
+	Capacity                int64                  `json:"capacity"`      // total blobber capacity
+	Allocated               int64                  `json:"allocated"`     // allocated capacity
 	PublicKey               string                 `json:"-"`
 	SavedData               int64                  `json:"saved_data"`
 	DataReadLastRewardRound float64                `json:"data_read_last_reward_round"` // in GB
@@ -371,6 +374,10 @@ func (sn *StorageNode) validate(conf *Config) (err error) {
 		return err
 	}
 
+	if err = validateStorageClass(sn.StorageClass); err != nil {
+		return err
+	}
+
 	return
 }
 
@@ -849,6 +856,8 @@ type StorageAllocation struct {
 	// Requested ranges.
 	ReadPriceRange  PriceRange `json:"read_price_range"`
 	WritePriceRange PriceRange `json:"write_price_range"`
+	// StorageClass of the blobbers of the allocation.
+	StorageClass string `json:"storage_class,omitempty"`
 
 	// StartTime is time when the allocation has been created. We will
 	// use it to check blobber's MaxOfferTime extending the allocation.
@@ -876,6 +885,22 @@ type StorageAllocation struct {
 	// TimeUnit configured in Storage SC when the allocation created. It can't
 	// be changed for this allocation anymore. Even using expire allocation.
 	TimeUnit time.Duration `json:"time_unit"`
+
+	// AutoRepair allows anyone to repair the allocation, replacing its killed
+	// or shut down blobbers with the blobbers chosen by the storage SC.
+	AutoRepair bool `json:"auto_repair"`
+	// DegradedBlobbers are the killed or shut down blobbers still used by the
+	// allocation.
+	DegradedBlobbers []string `json:"degraded_blobbers,omitempty"`
+
+	// AutoRenew lets the storage SC extend the allocation the configured
+	// window before it expires.
+	AutoRenew bool `json:"auto_renew"`
+	// RenewalSource funds the auto renewals, the owner balance or the
+	// renewal pool of the allocation.
+	RenewalSource string `json:"renewal_source,omitempty"`
+	// RenewalPool is the tokens locked to pay the auto renewals.
+	RenewalPool currency.Coin `json:"renewal_pool,omitempty"`
 }
 
 type WithOption func(balances cstate.StateContextI) (currency.Coin, error)
@@ -1248,6 +1273,11 @@ func (sa *StorageAllocation) isActive(
 		return fmt.Errorf("blobber %s is not currently available for new allocations", blobber.ID)
 	}
 
+	if storageClassOf(blobber.StorageClass) != storageClassOf(sa.StorageClass) {
+		return fmt.Errorf("blobber %s storage class %s does not match allocation storage class %s",
+			blobber.ID, storageClassOf(blobber.StorageClass), storageClassOf(sa.StorageClass))
+	}
+
 	// filter by read price
 	if !sa.ReadPriceRange.isMatch(blobber.Terms.ReadPrice) {
 		return fmt.Errorf("read price range %v does not match blobber %s read price %v",
@@ -1333,6 +1363,7 @@ func (sa *StorageAllocation) replaceBlobber(blobberID string, sc *StorageSmartCo
 		return fmt.Errorf("cannot find blobber %s in allocation", blobberID)
 	}
 	delete(sa.BlobberAllocsMap, blobberID)
+	sa.removeDegradedBlobber(blobberID)
 
 	conf, err := getConfig(balances)
 	if err != nil {
@@ -67,6 +67,13 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	ssc.SmartContractExecutionStats["update_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_allocation_request"), nil)
 	ssc.SmartContractExecutionStats["finalize_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "finalize_allocation"), nil)
 	ssc.SmartContractExecutionStats["cancel_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_allocation"), nil)
+	ssc.SmartContractExecutionStats["repair_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "repair_allocation"), nil)
+	ssc.SmartContractExecutionStats["renew_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "renew_allocation"), nil)
+	ssc.SmartContractExecutionStats["renew_allocations"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "renew_allocations"), nil)
+	ssc.SmartContractExecutionStats["renewal_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "renewal_pool_lock"), nil)
+	ssc.SmartContractExecutionStats["renewal_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "renewal_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["set_allocation_acl"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "set_allocation_acl"), nil)
+	ssc.SmartContractExecutionStats["remove_allocation_acl"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "remove_allocation_acl"), nil)
 	ssc.SmartContractExecutionStats["free_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "free_allocation_request"), nil)
 	ssc.SmartContractExecutionStats["free_update_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_free_storage"), nil)
 	// challenge
@@ -104,6 +111,7 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	// stake pool
 	ssc.SmartContractExecutionStats["stake_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_lock"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_auto_compound"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_auto_compound"), nil)
 	ssc.SmartContractExecutionStats["pay_reward"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "pay_reward (add/update/remove SC function)"), nil)
 
 }
@@ -189,6 +197,26 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.finalizeAllocation(t, input, balances)
 	case "cancel_allocation":
 		resp, err = sc.cancelAllocationRequest(t, input, balances)
+	case "repair_allocation":
+		resp, err = sc.repairAllocation(t, input, balances)
+	case "renew_allocation":
+		resp, err = sc.renewAllocation(t, input, balances)
+	case "renew_allocations":
+		resp, err = sc.renewAllocations(t, input, balances)
+
+	// renewal pool
+
+	case "renewal_pool_lock":
+		resp, err = sc.renewalPoolLock(t, input, balances)
+	case "renewal_pool_unlock":
+		resp, err = sc.renewalPoolUnlock(t, input, balances)
+
+	// allocation access control list
+
+	case "set_allocation_acl":
+		resp, err = sc.setAllocationACL(t, input, balances)
+	case "remove_allocation_acl":
+		resp, err = sc.removeAllocationACL(t, input, balances)
 
 	// free allocations
 
@@ -245,6 +273,8 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.stakePoolLock(t, input, balances)
 	case "stake_pool_unlock":
 		resp, err = sc.stakePoolUnlock(t, input, balances)
+	case "stake_pool_auto_compound":
+		resp, err = sc.stakePoolAutoCompound(t, input, balances)
 	case "collect_reward":
 		resp, err = sc.collectReward(t, input, balances)
 	case "generate_challenge":
@@ -12,6 +12,7 @@ import (
 // shutdownBlobber
 // shuts down the blobber: It is no longer available for new allocations
 // but its existing commitments will still be upheld.
+// Its allocations are marked as degraded lazily, see allocation_repair.go.
 func (_ *StorageSmartContract) shutdownBlobber(
 	tx *transaction.Transaction,
 	input []byte,
@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 
-	"0chain.net/chaincore/state"
+	"0chain.net/core/common"
 	"0chain.net/smartcontract/dbs/event"
 	"0chain.net/smartcontract/stakepool/spenum"
 	"github.com/0chain/common/core/currency"
@@ -109,46 +109,25 @@ func (sp *stakePool) stake() (stake currency.Coin, err error) {
 	return
 }
 
-// empty a delegate pool if possible, call update before the empty
-func (sp *stakePool) Empty(
-	sscID,
-	poolID,
-	clientID string,
-	balances chainstate.StateContextI,
-) error {
-	var dp, ok = sp.Pools[poolID]
-	if !ok {
-		return fmt.Errorf("no such delegate pool: %q", poolID)
-	}
-
-	if dp.DelegateID != clientID {
-		return errors.New("trying to unlock not by delegate pool owner")
-	}
-
-	requiredBalance, err := currency.AddCoin(sp.TotalOffers, dp.Balance)
+// Unstake moves the amount of the delegate pool to its unbonding queue, if
+// the stake left covers the offers
+func (sp *stakePool) Unstake(clientID string, amount currency.Coin, releaseAt common.Timestamp) error {
+	staked, err := sp.stake()
 	if err != nil {
 		return err
 	}
 
-	staked, err := sp.stake()
+	requiredBalance, err := currency.AddCoin(sp.TotalOffers, amount)
 	if err != nil {
 		return err
 	}
 
 	if staked < requiredBalance {
-		return fmt.Errorf("insufficent stake to cover offers: existing stake %d, unlock balance %d, offers %d",
-			staked, dp.Balance, sp.TotalOffers)
-	}
-
-	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
-	if err := balances.AddTransfer(transfer); err != nil {
-		return err
+		return fmt.Errorf("insufficent stake to cover offers: existing stake %d, unstake amount %d, offers %d",
+			staked, amount, sp.TotalOffers)
 	}
 
-	sp.Pools[poolID].Balance = 0
-	sp.Pools[poolID].Status = spenum.Deleted
-
-	return nil
+	return sp.StakePool.Unstake(clientID, amount, releaseAt)
 }
 
 // add offer of an allocation related to blobber owns this stake pool
@@ -194,6 +173,15 @@ func (sp *stakePool) slash(
 		return 0, err
 	}
 
+	// the unbonding stake is slashed as well
+	unbonding, err := sp.Unbonding()
+	if err != nil {
+		return 0, err
+	}
+	if staked, err = currency.AddCoin(staked, unbonding); err != nil {
+		return 0, err
+	}
+
 	// offer ratio of entire stake; we are slashing only part of the offer
 	// moving the tokens to allocation user; the ratio is part of entire
 	// stake should be moved;
@@ -206,15 +194,24 @@ func (sp *stakePool) slash(
 			return 0, err
 		}
 
-		if dpSlash == 0 {
-			continue
-		}
-
 		if balance, err := currency.MinusCoin(dp.Balance, dpSlash); err != nil {
 			return 0, err
 		} else {
 			dp.Balance = balance
 		}
+
+		unbondingSlash, err := dp.SlashUnbonding(ratio)
+		if err != nil {
+			return 0, err
+		}
+		if dpSlash, err = currency.AddCoin(dpSlash, unbondingSlash); err != nil {
+			return 0, err
+		}
+
+		if dpSlash == 0 {
+			continue
+		}
+
 		move, err = currency.AddCoin(move, dpSlash)
 		if err != nil {
 			return 0, err
@@ -378,3 +375,43 @@ func (ssc *StorageSmartContract) stakePoolUnlock(
 ) (resp string, err error) {
 	return stakepool.StakePoolUnlock(t, input, balances, ssc.getStakePoolAdapter)
 }
+
+// opt a delegate pool in or out of compounding its rewards
+func (ssc *StorageSmartContract) stakePoolAutoCompound(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (resp string, err error) {
+	return stakepool.StakePoolAutoCompound(t, input, balances, ssc.getStakePoolAdapter)
+}
+
+// the stakes of the validators are compounded up to the max stake of the
+// current config
+func validatorCompoundLimits(_ string, balances chainstate.CommonStateContextI) (stakepool.CompoundLimits, error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return stakepool.CompoundLimits{}, err
+	}
+	return stakepool.CompoundLimits{MaxStake: conf.MaxStake}, nil
+}
+
+// the stakes of the blobbers are compounded up to the max stake of the current
+// config and in total up to the stake covering the capacity of the blobber
+func blobberCompoundLimits(blobberID string, balances chainstate.CommonStateContextI) (stakepool.CompoundLimits, error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return stakepool.CompoundLimits{}, err
+	}
+	blobber, err := getBlobber(blobberID, balances)
+	if err != nil {
+		return stakepool.CompoundLimits{}, err
+	}
+
+	maxTotal, err := currency.Float64ToCoin(float64(blobber.Terms.WritePrice) * float64(blobber.Capacity) / GB)
+	if err != nil {
+		return stakepool.CompoundLimits{}, err
+	}
+	return stakepool.CompoundLimits{MaxStake: conf.MaxStake, MaxTotalStake: maxTotal}, nil
+}
+
+func init() {
+	stakepool.SetCompoundLimitsFunc(spenum.Blobber, blobberCompoundLimits)
+	stakepool.SetCompoundLimitsFunc(spenum.Validator, validatorCompoundLimits)
+}
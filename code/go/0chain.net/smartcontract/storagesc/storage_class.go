@@ -0,0 +1,81 @@
+package storagesc
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// A blobber advertises the storage class of its capacity and an allocation
+// requests one, the allocation only uses blobbers of its storage class. The
+// storage class configuration sets how often the blobbers of the class are
+// challenged, the min lock demand of the allocations of the class and the
+// weight of the blobbers of the class in the block rewards, so that archival
+// providers can offer cheaper and less frequently challenged capacity. The
+// blobbers and the allocations created before the storage classes are hot.
+
+const (
+	StorageClassHot     = "hot"
+	StorageClassCold    = "cold"
+	StorageClassArchive = "archive"
+)
+
+var storageClasses = []string{StorageClassHot, StorageClassCold, StorageClassArchive}
+
+// validateStorageClass validates the storage class, empty is hot and is kept
+// empty, see storageClassOf
+func validateStorageClass(class string) error {
+	switch class {
+	case "", StorageClassHot, StorageClassCold, StorageClassArchive:
+		return nil
+	default:
+		return fmt.Errorf("invalid storage class %q", class)
+	}
+}
+
+// storageClassOf returns the storage class of a blobber or an allocation saved
+// before the storage classes
+func storageClassOf(class string) string {
+	if class == "" {
+		return StorageClassHot
+	}
+	return class
+}
+
+func (scs *storageClassesConfig) get(class string) *storageClassConfig {
+	switch storageClassOf(class) {
+	case StorageClassCold:
+		return &scs.Cold
+	case StorageClassArchive:
+		return &scs.Archive
+	default:
+		return &scs.Hot
+	}
+}
+
+func (conf *Config) challengeFrequency(class string) float64 {
+	if f := conf.StorageClasses.get(class).ChallengeFrequency; f > 0 {
+		return f
+	}
+	return 1
+}
+
+func (conf *Config) minLockDemand(class string) float64 {
+	if mld := conf.StorageClasses.get(class).MinLockDemand; mld > 0 {
+		return mld
+	}
+	return conf.MinLockDemand
+}
+
+func (conf *Config) rewardWeight(class string) float64 {
+	if w := conf.StorageClasses.get(class).RewardWeight; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// skipChallenge decides whether the blobber of the storage class drawn for a
+// challenge is let off, as the class is challenged less frequently
+func (conf *Config) skipChallenge(class string, r *rand.Rand) bool {
+	f := conf.challengeFrequency(class)
+	return f < 1 && r.Float64() >= f
+}
@@ -0,0 +1,75 @@
+package storagesc
+
+import (
+	"math/rand"
+	"testing"
+
+	"0chain.net/smartcontract/provider"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStorageClass(t *testing.T) {
+	require.NoError(t, validateStorageClass(""))
+
+	for _, c := range storageClasses {
+		require.NoError(t, validateStorageClass(c))
+	}
+
+	require.Error(t, validateStorageClass("lukewarm"))
+
+	require.Equal(t, StorageClassHot, storageClassOf(""))
+	require.Equal(t, StorageClassArchive, storageClassOf(StorageClassArchive))
+}
+
+func TestStorageClassConfig(t *testing.T) {
+	conf := &Config{
+		MinLockDemand: 0.1,
+		StorageClasses: storageClassesConfig{
+			Archive: storageClassConfig{ChallengeFrequency: 0.25, MinLockDemand: 0.5, RewardWeight: 5},
+		},
+	}
+
+	// not configured classes fall back to the defaults
+	require.EqualValues(t, 1, conf.challengeFrequency(""))
+	require.EqualValues(t, 0.1, conf.minLockDemand(StorageClassCold))
+	require.EqualValues(t, 1, conf.rewardWeight(StorageClassHot))
+
+	require.EqualValues(t, 0.25, conf.challengeFrequency(StorageClassArchive))
+	require.EqualValues(t, 0.5, conf.minLockDemand(StorageClassArchive))
+	require.EqualValues(t, 5, conf.rewardWeight(StorageClassArchive))
+
+	r := rand.New(rand.NewSource(1))
+	var skipped int
+	for i := 0; i < 1000; i++ {
+		require.False(t, conf.skipChallenge(StorageClassHot, r))
+		if conf.skipChallenge(StorageClassArchive, r) {
+			skipped++
+		}
+	}
+	require.InDelta(t, 750, skipped, 60)
+}
+
+func TestStorageClassIsActive(t *testing.T) {
+	const now = 100
+	conf := setConfig(t, newTestBalances(t, false))
+	sa := &StorageAllocation{
+		DataShards:      1,
+		Size:            1,
+		ReadPriceRange:  PriceRange{Max: 100},
+		WritePriceRange: PriceRange{Max: 100},
+		StorageClass:    StorageClassArchive,
+	}
+	sn := &StorageNode{
+		Provider: provider.Provider{ID: "b1", LastHealthCheck: now},
+		Capacity: 100 * GB,
+		Terms:    Terms{ReadPrice: 1, WritePrice: 1},
+	}
+
+	err := sa.isActive(sn, currency.Coin(1e10), 0, conf, now)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "storage class")
+
+	sn.StorageClass = StorageClassArchive
+	require.NoError(t, sa.isActive(sn, currency.Coin(1e10), 0, conf, now))
+}
@@ -62,6 +62,8 @@ func AddMockVestingPools(
 			StartTime:   0,
 			ExpireAt:    now + common.Timestamp(viper.GetDuration(benchmark.VestingMaxDuration).Seconds()),
 			ClientID:    clients[i],
+			// every other pool can be revoked by its owner
+			Revocable: i%2 == 1,
 		}
 		for j := 0; j < viper.GetInt(benchmark.NumVestingDestinationsClient); j++ {
 			dest := &destination{
@@ -147,6 +147,22 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "vesting.revoke",
+			endpoint: vsc.revoke,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[1],
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&stopRequest{
+					PoolID:      geMockVestingPoolId(1),
+					Destination: getMockDestinationId(1, 0),
+				})
+				return bytes
+			}(),
+		},
 		{
 			name:     "vesting.delete",
 			endpoint: vsc.delete,
@@ -15,6 +15,7 @@ import (
 	"github.com/0chain/common/core/currency"
 
 	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/smartcontract/governancesc"
 
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
@@ -54,6 +55,7 @@ var (
 		"add",
 		"delete",
 		"stop",
+		"revoke",
 		"trigger",
 		"unlock",
 		"vestingsc-update-settings",
@@ -218,7 +220,7 @@ func (vsc *VestingSmartContract) updateConfig(
 	}
 
 	if err := smartcontractinterface.AuthorizeWithOwner("update_config", func() bool {
-		return conf.OwnerId == txn.ClientID
+		return conf.OwnerId == txn.ClientID || txn.ClientID == governancesc.ADDRESS
 	}); err != nil {
 		return "", err
 	}
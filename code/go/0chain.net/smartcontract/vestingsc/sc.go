@@ -74,6 +74,10 @@ func (vsc *VestingSmartContract) setSC(sc *smartcontractinterface.SmartContract,
 	vsc.SmartContractExecutionStats["stop"] = metrics.GetOrRegisterTimer(
 		fmt.Sprintf("sc:%v:func:%v", vsc.ID, "stop"), nil)
 
+	// revoke vesting for a destination, returning not vested tokens to owner
+	vsc.SmartContractExecutionStats["revoke"] = metrics.GetOrRegisterTimer(
+		fmt.Sprintf("sc:%v:func:%v", vsc.ID, "revoke"), nil)
+
 	// tokens unlock for an existing pool (as owner, as a destination)
 	vsc.SmartContractExecutionStats["unlock"] = metrics.GetOrRegisterTimer(
 		fmt.Sprintf("sc:%v:func:%v", vsc.ID, "unlock"), nil)
@@ -101,6 +105,8 @@ func (vsc *VestingSmartContract) Execute(t *transaction.Transaction,
 		resp, err = vsc.add(t, input, balances)
 	case "stop":
 		resp, err = vsc.stop(t, input, balances)
+	case "revoke":
+		resp, err = vsc.revoke(t, input, balances)
 	case "delete":
 		resp, err = vsc.delete(t, input, balances)
 	case "vestingsc-update-settings":
@@ -166,6 +166,16 @@ type addRequest struct {
 	StartTime    common.Timestamp `json:"start_time"`            //
 	Duration     time.Duration    `json:"duration"`              //
 	Destinations destinations     `json:"destinations"`          //
+	// Cliff is the time from the start nothing vests before, the tokens
+	// vested by the cliff are unlocked at once; a cliff of the duration
+	// vests all the tokens at the end.
+	Cliff time.Duration `json:"cliff,omitempty"`
+	// Step vests the tokens every step, for example monthly or quarterly,
+	// instead of continuously; zero for linear vesting.
+	Step time.Duration `json:"step,omitempty"`
+	// Revocable pools allow the owner to take back the not vested tokens
+	// of a destination.
+	Revocable bool `json:"revocable,omitempty"`
 }
 
 func (ar *addRequest) decode(b []byte) error {
@@ -194,6 +204,12 @@ func (ar *addRequest) validate(now common.Timestamp, conf *config) (err error) {
 		return errors.New("no destinations")
 	case len(ar.Destinations) > conf.MaxDestinations:
 		return errors.New("too many destinations")
+	case ar.Cliff < 0 || ar.Cliff > ar.Duration:
+		return errors.New("cliff is out of the vesting duration")
+	case ar.Step < 0 || ar.Step > ar.Duration:
+		return errors.New("step is out of the vesting duration")
+	case ar.Step > 0 && ar.Step < time.Second:
+		return errors.New("step is less than a second")
 	}
 	return
 }
@@ -214,6 +230,11 @@ type vestingPool struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    //
 	Destinations destinations     `json:"destinations"` //
 	ClientID     string           `json:"client_id"`    // the pool owner
+
+	// vesting schedule, the pools without a cliff and steps vest linearly
+	Cliff     common.Timestamp `json:"cliff,omitempty"`     // nothing vests before
+	Step      common.Timestamp `json:"step,omitempty"`      // seconds
+	Revocable bool             `json:"revocable,omitempty"` // by the owner
 }
 
 // newVestingPool returns new empty uninitialized vesting pool.
@@ -233,11 +254,61 @@ func newVestingPoolFromReqeust(clientID datastore.Key, ar *addRequest) (
 	vp.Description = ar.Description
 	vp.StartTime = ar.StartTime
 	vp.ExpireAt = ar.StartTime + toSeconds(ar.Duration)
+	if ar.Cliff > 0 {
+		vp.Cliff = ar.StartTime + toSeconds(ar.Cliff)
+	}
+	vp.Step = toSeconds(ar.Step)
+	vp.Revocable = ar.Revocable
 	vp.Destinations = ar.Destinations
 	vp.Destinations.start(vp.StartTime)
 	return
 }
 
+// scheduled pools vest by a cliff or steps, the other ones vest linearly
+func (vp *vestingPool) scheduled() bool {
+	return vp.Cliff > 0 || vp.Step > 0
+}
+
+// vestedRatio is the part of the amounts of the destinations vested by now
+// following the schedule of the pool
+func (vp *vestingPool) vestedRatio(now common.Timestamp) float64 {
+	switch {
+	case now < vp.Cliff:
+		return 0
+	case now >= vp.ExpireAt:
+		return 1
+	}
+	var elapsed = now - vp.StartTime
+	if vp.Step > 0 {
+		elapsed -= elapsed % vp.Step
+	}
+	return float64(elapsed) / float64(vp.ExpireAt-vp.StartTime)
+}
+
+// unlock returns amount of tokens to vest for the destination by now, the
+// dry argument leaves the destination as it was (see destination.unlock)
+func (vp *vestingPool) unlock(d *destination, now common.Timestamp,
+	dry bool) (amount currency.Coin, err error) {
+
+	if !vp.scheduled() {
+		return d.unlock(now, vp.ExpireAt, dry)
+	}
+
+	vested, err := currency.MultFloat64(d.Amount, vp.vestedRatio(now))
+	if err != nil {
+		return 0, err
+	}
+	if vested > d.Vested {
+		amount = vested - d.Vested
+	}
+
+	if !dry {
+		err = d.move(now, amount)
+	}
+
+	return
+}
+
 // Encode the vesting pool from JSON value. Implements
 // required util.Serializale interface.
 func (vp *vestingPool) Encode() (b []byte) {
@@ -348,7 +419,7 @@ func (vp *vestingPool) trigger(t *transaction.Transaction,
 	)
 	sb.WriteByte('[')
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, false)
+		value, err := vp.unlock(d, now, false)
 		if err != nil {
 			return "", err
 		}
@@ -438,7 +509,7 @@ func (vp *vestingPool) vest(vscID, destID datastore.Key, now common.Timestamp,
 		return
 	}
 
-	value, err := d.unlock(now, end, false)
+	value, err := vp.unlock(d, now, false)
 	if err != nil {
 		return "", err
 	}
@@ -502,6 +573,9 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 	i.Description = vp.Description
 	i.StartTime = vp.StartTime
 	i.ExpireAt = vp.ExpireAt
+	i.Cliff = vp.Cliff
+	i.Step = vp.Step
+	i.Revocable = vp.Revocable
 
 	var end = i.ExpireAt
 
@@ -515,7 +589,7 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 
 	var dinfos = make([]*destInfo, 0, len(vp.Destinations))
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, true)
+		value, err := vp.unlock(d, now, true)
 		if err != nil {
 			return nil, err
 		}
@@ -551,6 +625,9 @@ type info struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    // until
 	Destinations []*destInfo      `json:"destinations"` // receivers
 	ClientID     datastore.Key    `json:"client_id"`    // owner
+	Cliff        common.Timestamp `json:"cliff,omitempty"`
+	Step         common.Timestamp `json:"step,omitempty"`
+	Revocable    bool             `json:"revocable,omitempty"`
 }
 
 //
@@ -652,50 +729,96 @@ func (vsc *VestingSmartContract) add(t *transaction.Transaction,
 	return string(vp.Encode()), nil
 }
 
+// stop vesting for a destination, the tokens vested by now are moved to the
+// destination and the rest stays in the pool as its excess
 func (vsc *VestingSmartContract) stop(t *transaction.Transaction,
 	input []byte, balances chainstate.StateContextI) (resp string, err error) {
 
+	return vsc.stopVesting(t, input, balances, false)
+}
+
+// revoke vesting for a destination of a revocable pool, the tokens vested
+// are moved to the destination and the rest is returned to the owner
+func (vsc *VestingSmartContract) revoke(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI) (resp string, err error) {
+
+	return vsc.stopVesting(t, input, balances, true)
+}
+
+// stopVesting removes the destination from the pool. Only revocable pools can
+// be revoked, and the vesting of the pools with a schedule can only be stopped
+// if they are revocable.
+func (vsc *VestingSmartContract) stopVesting(t *transaction.Transaction,
+	input []byte, balances chainstate.StateContextI, revoke bool) (
+	resp string, err error) {
+
+	var code, action = "stop_vesting_failed", "stop"
+	if revoke {
+		code, action = "revoke_vesting_failed", "revoke"
+	}
+
 	var sr stopRequest
 	if err = sr.decode(input); err != nil {
-		return "", common.NewError("stop_vesting_failed",
+		return "", common.NewError(code,
 			"malformed request: "+err.Error())
 	}
 
 	if sr.Destination == "" {
-		return "", common.NewError("stop_vesting_failed",
-			"missing destination to stop vesting")
+		return "", common.NewError(code,
+			"missing destination to "+action+" vesting")
 	}
 
 	var vp *vestingPool
 	if vp, err = vsc.getPool(sr.PoolID, balances); err != nil {
-		return "", common.NewError("stop_vesting_failed",
+		return "", common.NewError(code,
 			"can't get vesting pool: "+err.Error())
 	}
 
 	if vp.ClientID != t.ClientID {
-		return "", common.NewError("stop_vesting_failed",
-			"only owner can stop a vesting")
+		return "", common.NewError(code,
+			"only owner can "+action+" a vesting")
+	}
+
+	if (revoke || vp.scheduled()) && !vp.Revocable {
+		return "", common.NewError(code,
+			"the vesting pool is not revocable")
 	}
 
 	if t.CreationDate > vp.ExpireAt {
-		return "", common.NewError("stop_vesting_failed", "expired pool")
+		return "", common.NewError(code, "expired pool")
 	}
 
 	_, err = vp.vest(t.ToClientID, sr.Destination, t.CreationDate, balances)
 	if err != nil && err != errZeroVesting {
-		return "", common.NewError("stop_vesting_failed", err.Error())
+		return "", common.NewError(code, err.Error())
 	}
 
 	if err = vp.delete(sr.Destination); err != nil {
-		return "", common.NewError("stop_vesting_failed",
+		return "", common.NewError(code,
 			"deleting destination: "+err.Error())
 	}
 
+	if revoke {
+		var over currency.Coin
+		if over, err = vp.excess(); err != nil {
+			return "", common.NewError(code, err.Error())
+		}
+		if over > 0 {
+			if _, err = vp.drain(t, balances); err != nil {
+				return "", common.NewError(code,
+					"draining pool: "+err.Error())
+			}
+		}
+	}
+
 	if err = vp.save(balances); err != nil {
-		return "", common.NewError("trigger_vesting_pool_failed",
+		return "", common.NewError(code,
 			"saving pool: "+err.Error())
 	}
 
+	if revoke {
+		return sr.Destination + " has revoked from the vesting pool", nil
+	}
 	return sr.Destination + " has deleted from the vesting pool", nil
 }
 
@@ -292,9 +292,9 @@ func (z stopRequest) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *vestingPool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 9
 	// string "ZcnPool"
-	o = append(o, 0x86, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
+	o = append(o, 0x89, 0xa7, 0x5a, 0x63, 0x6e, 0x50, 0x6f, 0x6f, 0x6c)
 	o, err = z.ZcnPool.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "ZcnPool")
@@ -334,6 +334,23 @@ func (z *vestingPool) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "ClientID"
 	o = append(o, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ClientID)
+	// string "Cliff"
+	o = append(o, 0xa5, 0x43, 0x6c, 0x69, 0x66, 0x66)
+	o, err = z.Cliff.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Cliff")
+		return
+	}
+	// string "Step"
+	o = append(o, 0xa4, 0x53, 0x74, 0x65, 0x70)
+	o, err = z.Step.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Step")
+		return
+	}
+	// string "Revocable"
+	o = append(o, 0xa9, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x62, 0x6c, 0x65)
+	o = msgp.AppendBool(o, z.Revocable)
 	return
 }
 
@@ -415,6 +432,24 @@ func (z *vestingPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ClientID")
 				return
 			}
+		case "Cliff":
+			bts, err = z.Cliff.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Cliff")
+				return
+			}
+		case "Step":
+			bts, err = z.Step.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Step")
+				return
+			}
+		case "Revocable":
+			z.Revocable, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Revocable")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -437,6 +472,6 @@ func (z *vestingPool) Msgsize() (s int) {
 			s += z.Destinations[za0001].Msgsize()
 		}
 	}
-	s += 9 + msgp.StringPrefixSize + len(z.ClientID)
+	s += 9 + msgp.StringPrefixSize + len(z.ClientID) + 6 + z.Cliff.Msgsize() + 5 + z.Step.Msgsize() + 10 + msgp.BoolSize
 	return
 }
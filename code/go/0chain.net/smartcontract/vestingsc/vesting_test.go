@@ -384,6 +384,116 @@ func TestVestingSmartContract_stop(t *testing.T) {
 
 }
 
+func Test_vestingPool_schedule(t *testing.T) {
+	var vp = newVestingPoolFromReqeust("owner", &addRequest{
+		StartTime:    100,
+		Duration:     400 * time.Second,
+		Cliff:        100 * time.Second,
+		Step:         100 * time.Second,
+		Destinations: destinations{&destination{ID: "one", Amount: 100}},
+	})
+	require.True(t, vp.scheduled())
+	var d = vp.Destinations[0]
+
+	for _, tt := range []struct {
+		now  common.Timestamp
+		want currency.Coin
+	}{
+		{now: 150, want: 0},  // before the cliff
+		{now: 250, want: 25}, // the cliff and the first step
+		{now: 399, want: 25}, // the second step
+		{now: 400, want: 25}, // the third step
+		{now: 500, want: 25}, // the end
+		{now: 510, want: 0},  // all vested
+	} {
+		value, err := vp.unlock(d, tt.now, false)
+		require.NoError(t, err)
+		assert.EqualValues(t, tt.want, value, "at %d", tt.now)
+	}
+	assert.EqualValues(t, 100, d.Vested)
+
+	// cliff vesting, nothing until the date then all at once
+	vp = newVestingPoolFromReqeust("owner", &addRequest{
+		StartTime:    100,
+		Duration:     400 * time.Second,
+		Cliff:        400 * time.Second,
+		Destinations: destinations{&destination{ID: "one", Amount: 100}},
+	})
+	d = vp.Destinations[0]
+	value, err := vp.unlock(d, 499, true)
+	require.NoError(t, err)
+	assert.Zero(t, value)
+	value, err = vp.unlock(d, 500, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, value)
+}
+
+func TestVestingSmartContract_revoke(t *testing.T) {
+	var (
+		vsc      = newTestVestingSC()
+		balances = newTestBalances()
+		client   = newClient(1200e10, balances)
+		tp       = common.Timestamp(0)
+		sr       stopRequest
+		err      = InitConfig(balances)
+	)
+	require.NoError(t, err)
+	configureConfig()
+
+	var ar = addRequest{
+		Description: "for something",
+		StartTime:   10,
+		Duration:    4 * time.Second,
+		Step:        2 * time.Second,
+		Destinations: destinations{
+			&destination{ID: "one", Amount: 100e10},
+			&destination{ID: "two", Amount: 200e10},
+		},
+	}
+	resp, err := client.add(t, vsc, &ar, 300e10, tp, balances)
+	require.NoError(t, err)
+	var set vestingPool
+	require.NoError(t, set.Decode([]byte(resp)))
+	sr.PoolID, sr.Destination = set.ID, "one"
+
+	var tx = newTransaction(client.id, vsc.ID, 0, 13)
+	balances.txn = tx
+	_, err = vsc.revoke(tx, mustEncode(t, &sr), balances)
+	requireErrMsg(t, err, "revoke_vesting_failed: "+
+		"the vesting pool is not revocable")
+
+	// the vesting of a pool with a schedule can't be stopped either
+	_, err = vsc.stop(tx, mustEncode(t, &sr), balances)
+	requireErrMsg(t, err, "stop_vesting_failed: "+
+		"the vesting pool is not revocable")
+
+	ar.Revocable = true
+	resp, err = client.add(t, vsc, &ar, 300e10, tp, balances)
+	require.NoError(t, err)
+	require.NoError(t, set.Decode([]byte(resp)))
+	sr.PoolID = set.ID
+
+	tx = newTransaction("another_one", vsc.ID, 0, 13)
+	balances.txn = tx
+	_, err = vsc.revoke(tx, mustEncode(t, &sr), balances)
+	requireErrMsg(t, err, "revoke_vesting_failed: "+
+		"only owner can revoke a vesting")
+
+	// the first step is vested, the rest is returned to the owner
+	tx = newTransaction(client.id, vsc.ID, 0, 13)
+	balances.txn = tx
+	_, err = vsc.revoke(tx, mustEncode(t, &sr), balances)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(50e10), balances.balances["one"])
+	assert.Equal(t, currency.Coin(650e10), balances.balances[client.id])
+
+	got, err := vsc.getPool(set.ID, balances)
+	require.NoError(t, err)
+	assert.Equal(t, currency.Coin(200e10), got.Balance)
+	require.Len(t, got.Destinations, 1)
+	assert.Equal(t, "two", got.Destinations[0].ID)
+}
+
 func TestVestingSmartContract_unlock(t *testing.T) {
 	var (
 		vsc      = newTestVestingSC()
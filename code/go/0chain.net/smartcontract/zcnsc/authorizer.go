@@ -66,6 +66,9 @@ func (zcn *ZCNSmartContract) AddAuthorizer(
 	if err != nil {
 		return "", err
 	}
+	if err = verifyProofOfPossession(params.PublicKey, params.ProofOfPossession, ctx); err != nil {
+		return "", common.NewError(code, err.Error())
+	}
 	authorizerID = encryption.Hash(publicKeyBytes)
 	clientId = tran.ClientID
 	if params.StakePoolSettings.DelegateWallet == "" {
@@ -133,10 +136,17 @@ func (zcn *ZCNSmartContract) AddAuthorizer(
 	ctx.EmitEvent(event.TypeStats, event.TagAddAuthorizer, authorizerID, authorizer.ToEvent())
 
 	err = increaseAuthorizerCount(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err = indexAuthorizer(authorizerID, ctx); err != nil {
+		return "", common.NewErrorf(code, "could not index authorizer: %v", err)
+	}
 
 	afterInsertAuthorizer(authorizerID)
 
-	return string(authorizer.Encode()), err
+	return string(authorizer.Encode()), nil
 }
 
 func increaseAuthorizerCount(ctx cstate.StateContextI) (err error) {
@@ -180,6 +190,31 @@ func getAuthorizerCount(ctx cstate.StateContextI) (int, error) {
 	return numAuth.Count, nil
 }
 
+// indexAuthorizer adds the authorizer to the index of the signer bitmaps,
+// the index is only saved when the authorizer was not indexed yet. The
+// possession of the authorizer's key must be verified before
+func indexAuthorizer(id string, ctx cstate.StateContextI) error {
+	index, err := GetAuthorizerIndex(ctx)
+	if err != nil {
+		return err
+	}
+	if !index.Add(id) {
+		return nil
+	}
+	return index.Save(ctx)
+}
+
+func unindexAuthorizer(id string, ctx cstate.StateContextI) error {
+	index, err := GetAuthorizerIndex(ctx)
+	if err != nil {
+		return err
+	}
+	if !index.Remove(id) {
+		return nil
+	}
+	return index.Save(ctx)
+}
+
 func (zcn *ZCNSmartContract) UpdateAuthorizerStakePool(
 	tran *transaction.Transaction,
 	input []byte,
@@ -335,6 +370,10 @@ func (zcn *ZCNSmartContract) DeleteAuthorizer(tran *transaction.Transaction, inp
 		return "", common.NewErrorf(errorCode, "could not decrease authorizer count: %v", err)
 	}
 
+	if err := unindexAuthorizer(authorizerID, ctx); err != nil {
+		return "", common.NewErrorf(errorCode, "could not unindex authorizer: %v", err)
+	}
+
 	ctx.EmitEvent(event.TypeStats, event.TagDeleteAuthorizer, authorizerID, authorizerID)
 
 	Logger.Info(
@@ -480,6 +519,20 @@ func (zcn *ZCNSmartContract) AuthorizerHealthCheck(
 
 	ctx.EmitEvent(event.TypeStats, event.TagAuthorizerHealthCheck, authorizer.ID, data)
 
+	// authorizers registered before the signer bitmaps are indexed once their
+	// health check proves the possession of their key
+	if payload.ProofOfPossession != "" {
+		if err = verifyProofOfPossession(authorizer.PublicKey, payload.ProofOfPossession, ctx); err != nil {
+			return "", common.NewError(code, err.Error())
+		}
+		if err = indexAuthorizer(authorizer.ID, ctx); err != nil {
+			msg := fmt.Sprintf("error indexing authorizer(authorizerID: %v), err: %v", authorizer.ID, err)
+			err = common.NewError(code, msg)
+			Logger.Error("indexing authorizer", zap.Error(err))
+			return "", err
+		}
+	}
+
 	err = authorizer.Save(ctx)
 	if err != nil {
 		msg := fmt.Sprintf("error saving authorizer(authorizerID: %v), err: %v", authorizer.ID, err)
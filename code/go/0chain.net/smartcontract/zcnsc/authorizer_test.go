@@ -72,7 +72,7 @@ func Test_AddingDuplicateAuthorizerShouldFail(t *testing.T) {
 	ctx := MakeMockStateContext()
 	delegateWallet := authorizersID[0] + ":10"
 
-	input := CreateAuthorizerParamPayload(delegateWallet, AuthorizerPublicKey)
+	input := CreateAuthorizerParamPayload(delegateWallet, authorizerScheme)
 	sc := CreateZCNSmartContract()
 	tr := CreateAddAuthorizerTransaction(ownerId, ctx)
 
@@ -88,7 +88,7 @@ func Test_BasicShouldAddAuthorizer(t *testing.T) {
 	ctx := MakeMockStateContext()
 	delegateWallet := authorizersID[0] + ":10"
 
-	input := CreateAuthorizerParamPayload(delegateWallet, AuthorizerPublicKey)
+	input := CreateAuthorizerParamPayload(delegateWallet, authorizerScheme)
 	publicKeyBytes, _ := hex.DecodeString(AuthorizerPublicKey)
 	id := encryption.Hash(publicKeyBytes)
 
@@ -106,11 +106,34 @@ func Test_BasicShouldAddAuthorizer(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_AddAuthorizerRequiresProofOfPossession(t *testing.T) {
+	ctx := MakeMockStateContext()
+	sc := CreateZCNSmartContract()
+	tr := CreateAddAuthorizerTransaction(ownerId, ctx)
+
+	payload := CreateAuthorizerParam(authorizersID[0]+":10", authorizerScheme)
+	payload.ProofOfPossession = ""
+	input, err := payload.Encode()
+	require.NoError(t, err)
+
+	_, err = sc.AddAuthorizer(tr, input, ctx)
+	require.ErrorContains(t, err, "proof of possession of the public key is missing")
+
+	// a proof signed with another key
+	payload.ProofOfPossession, err = newAuthorizerScheme().Sign(ProofOfPossessionHash(payload.PublicKey))
+	require.NoError(t, err)
+	input, err = payload.Encode()
+	require.NoError(t, err)
+
+	_, err = sc.AddAuthorizer(tr, input, ctx)
+	require.ErrorContains(t, err, "invalid proof of possession of the public key")
+}
+
 func Test_Should_AddOnlyOneAuthorizerWithSameID(t *testing.T) {
 	ctx := MakeMockStateContext()
 	delegateWallet := authorizersID[0] + ":10"
 
-	input := CreateAuthorizerParamPayload(delegateWallet, AuthorizerPublicKey)
+	input := CreateAuthorizerParamPayload(delegateWallet, authorizerScheme)
 	publicKeyBytes, _ := hex.DecodeString(AuthorizerPublicKey)
 	id := encryption.Hash(publicKeyBytes)
 
@@ -174,7 +197,7 @@ func Test_Transaction_Or_InputData_MustBe_A_Key_InputData(t *testing.T) {
 	ctx := MakeMockStateContext()
 	delegateWallet := authorizersID[0] + ":10"
 
-	input := CreateAuthorizerParamPayload(delegateWallet, AuthorizerPublicKey)
+	input := CreateAuthorizerParamPayload(delegateWallet, authorizerScheme)
 
 	sc := CreateZCNSmartContract()
 	tr := CreateAddAuthorizerTransaction(ownerId, ctx)
@@ -243,7 +266,7 @@ func Test_AuthorizerHealthCheck(t *testing.T) {
 
 	tr.ClientID = globalNode.ZCNSConfig.OwnerId
 
-	addAuthorizerPayload := CreateAuthorizerParam(tr.ClientID, tr.PublicKey)
+	addAuthorizerPayload := CreateAuthorizerParam(tr.ClientID, authorizerScheme)
 	data, err := json.Marshal(addAuthorizerPayload)
 	require.NoError(t, err)
 
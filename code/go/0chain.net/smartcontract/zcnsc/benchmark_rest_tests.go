@@ -41,6 +41,20 @@ func BenchmarkRestTests(data benchmark.BenchData, _ benchmark.SignatureScheme) b
 				},
 				Endpoint: zrh.NotProcessedBurnTicketsHandler,
 			},
+			{
+				FuncName: "bridge_usage",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: zrh.BridgeUsageHandler,
+			},
+			{
+				FuncName: "pending_mints",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: zrh.PendingMintsHandler,
+			},
 		},
 		ADDRESS,
 		zrh,
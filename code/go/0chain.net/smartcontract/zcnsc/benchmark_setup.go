@@ -31,6 +31,7 @@ func Setup(eventDb *event.EventDb, clients, publicKeys []string, balances cstate
 	addMockUserNodes(clients, balances)
 	addMockAuthorizers(eventDb, clients, publicKeys, balances)
 	addMockStakePools(clients, balances)
+	addMockPendingMints(clients, balances)
 }
 
 func addMockGlobalNode(balances cstate.StateContextI) {
@@ -87,6 +88,9 @@ func addMockAuthorizers(eventDb *event.EventDb, clients, publicKeys []string, ct
 		if err := increaseAuthorizerCount(ctx); err != nil {
 			log.Fatal(err)
 		}
+		if err := indexAuthorizer(id, ctx); err != nil {
+			log.Fatal(err)
+		}
 
 		if viper.GetBool(benchmark.EventDbEnabled) {
 			settings := getMockStakePoolSettings(id)
@@ -135,6 +139,22 @@ func addMockUserNodes(clients []string, balances cstate.StateContextI) {
 	}
 }
 
+func addMockPendingMints(clients []string, balances cstate.StateContextI) {
+	for i, clientId := range clients[:2] {
+		pm := &PendingMints{ClientID: clientId}
+		pm.Mints = append(pm.Mints, &PendingMint{
+			ID:      encryption.Hash("pending mint " + strconv.Itoa(i)),
+			Nonce:   int64(i),
+			Amount:  1e10,
+			Fee:     1e8,
+			Signers: []string{clients[0]},
+		})
+		if err := pm.Save(balances); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
 func createSmartContract() ZCNSmartContract {
 	sc := ZCNSmartContract{
 		SmartContract: smartcontractinterface.NewSC(ADDRESS),
@@ -1,6 +1,7 @@
 package zcnsc
 
 import (
+	"encoding/json"
 	"log"
 	"math/rand"
 	"strconv"
@@ -71,7 +72,7 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 				name:     benchmark.ZcnSc + AddAuthorizerFunc,
 				endpoint: sc.AddAuthorizer,
 				txn:      createTransaction(owner, "", 3000),
-				input:    createAuthorizerPayload(data, indexOfNewAuth),
+				input:    createAuthorizerPayload(scheme, data, indexOfNewAuth),
 			},
 			{
 				name:     benchmark.ZcnSc + AuthorizerHealthCheckFunc,
@@ -179,6 +180,46 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 					ProviderType: spenum.Authorizer,
 				}).Encode(),
 			},
+			{
+				name:     benchmark.ZcnSc + DelegatePoolAutoCompoundFunc,
+				endpoint: sc.DelegatePoolAutoCompound,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0], 3000),
+				input: (&stakepool.AutoCompoundRequest{
+					ProviderID:   data.Clients[0],
+					ProviderType: spenum.Authorizer,
+					AutoCompound: true,
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + PauseBridgeFunc,
+				endpoint: sc.PauseBridge,
+				txn:      createTransaction(owner, "", 3000),
+				input:    []byte("{}"),
+			},
+			{
+				name:     benchmark.ZcnSc + UnpauseBridgeFunc,
+				endpoint: sc.UnpauseBridge,
+				txn:      createTransaction(owner, "", 3000),
+				input:    []byte("{}"),
+			},
+			{
+				name:     benchmark.ZcnSc + CancelPendingMintFunc,
+				endpoint: sc.CancelPendingMint,
+				txn:      createTransaction(owner, "", 3000),
+				input: func() []byte {
+					input, _ := json.Marshal(&CancelPendingMintPayload{
+						ClientID: data.Clients[0],
+						ID:       encryption.Hash("pending mint 0"),
+					})
+					return input
+				}(),
+			},
+			{
+				name:     benchmark.ZcnSc + ReleaseMintsFunc,
+				endpoint: sc.ReleaseMints,
+				txn:      createTransaction(data.Clients[1], data.PublicKeys[1], 3000),
+				input:    []byte("{}"),
+			},
 		},
 	)
 }
@@ -235,9 +276,16 @@ func createBurnPayloadForZCNSCBurn() []byte {
 	return payload.Encode()
 }
 
-func createAuthorizerPayload(data benchmark.BenchData, index int) []byte {
+func createAuthorizerPayload(scheme benchmark.SignatureScheme, data benchmark.BenchData, index int) []byte {
+	scheme.SetPrivateKey(data.PrivateKeys[index])
+	proof, err := scheme.Sign(ProofOfPossessionHash(data.PublicKeys[index]))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	an := &AddAuthorizerPayload{
 		PublicKey:         data.PublicKeys[index],
+		ProofOfPossession: proof,
 		URL:               "http://localhost:303" + strconv.Itoa(index),
 		StakePoolSettings: getMockStakePoolSettings(data.Clients[0]),
 	}
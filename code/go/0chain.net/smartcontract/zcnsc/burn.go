@@ -16,6 +16,7 @@ import (
 
 // Burn inputData - is a BurnPayload.
 // EthereumAddress => required
+// ChainID => optional, the default chain if empty
 // Nonce => required
 func (zcn *ZCNSmartContract) Burn(
 	trans *transaction.Transaction,
@@ -42,16 +43,9 @@ func (zcn *ZCNSmartContract) Burn(
 		return "", common.NewError(code, msg)
 	}
 
-	// check burn amount
-	if trans.Value < gn.MinBurnAmount {
-		msg := fmt.Sprintf(
-			"amount (value) requested (%v) is lower than min burn amount (%v), %s",
-			trans.Value,
-			gn.MinBurnAmount,
-			info,
-		)
-		err = common.NewError(code, msg)
-		logging.Logger.Error(msg, zap.Error(err))
+	if gn.Paused {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", ErrBridgePaused, info))
+		logging.Logger.Error(err.Error(), zap.Error(err))
 		return
 	}
 
@@ -64,12 +58,34 @@ func (zcn *ZCNSmartContract) Burn(
 		return
 	}
 
+	chain, err := gn.chain(payload.ChainID)
+	if err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
+		logging.Logger.Error(err.Error(), zap.Error(err))
+		return
+	}
+
+	// check burn amount
+	if err = chain.checkBurn(trans.Value); err != nil {
+		msg := fmt.Sprintf("%v, %s", err, info)
+		err = common.NewError(code, msg)
+		logging.Logger.Error(msg, zap.Error(err))
+		return
+	}
+
 	if payload.EthereumAddress == "" {
 		err = common.NewError(code, "ethereum address is required, "+info)
 		logging.Logger.Error(err.Error(), zap.Error(err))
 		return
 	}
 
+	// check and use the burn caps of the period
+	if err = gn.useBurnLimits(trans.ClientID, trans.Value, ctx); err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
+		logging.Logger.Error(err.Error(), zap.Error(err))
+		return
+	}
+
 	// get user node
 	un, err := GetUserNode(payload.EthereumAddress, ctx)
 	if err != nil {
@@ -78,8 +94,8 @@ func (zcn *ZCNSmartContract) Burn(
 		return
 	}
 
-	// increase the nonce
-	un.BurnNonce++
+	// increase the nonce of the chain
+	nonce := un.nextBurnNonce(payload.ChainID)
 
 	// Save the user node
 	err = un.Save(ctx)
@@ -97,8 +113,9 @@ func (zcn *ZCNSmartContract) Burn(
 	response := &BurnPayloadResponse{
 		TxnID:           trans.Hash,
 		Amount:          trans.Value,
-		Nonce:           un.BurnNonce, // it can be just the nonce of this transaction
+		Nonce:           nonce, // it can be just the nonce of this transaction
 		EthereumAddress: payload.EthereumAddress,
+		ChainID:         payload.ChainID,
 	}
 
 	ctx.EmitEvent(event.TypeStats, event.TagAuthorizerBurn, trans.ClientID, state.Burn{
@@ -106,11 +123,12 @@ func (zcn *ZCNSmartContract) Burn(
 		Amount: trans.Value,
 	})
 
-	ctx.EmitEvent(event.TypeStats, event.TagAddBurnTicket, payload.EthereumAddress, &event.BurnTicket{
+	ctx.EmitEvent(event.TypeStats, event.TagAddBurnTicket, ChainScoped(payload.EthereumAddress, payload.ChainID), &event.BurnTicket{
 		EthereumAddress: payload.EthereumAddress,
+		ChainID:         payload.ChainID,
 		Hash:            trans.Hash,
 		Amount:          trans.Value,
-		Nonce:           un.BurnNonce,
+		Nonce:           nonce,
 	})
 
 	resp = string(response.Encode())
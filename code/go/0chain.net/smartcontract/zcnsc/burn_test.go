@@ -217,6 +217,59 @@ func Test_BurnNonceShouldIncrementDuringBurn(t *testing.T) {
 	require.Equal(t, node.BurnNonce, nonce+1)
 }
 
+func Test_BurnToChain(t *testing.T) {
+	ctx := MakeMockStateContext()
+	ctx.globalNode.Chains = map[string]*ChainConfig{
+		"137": {MinMintAmount: 1, MinBurnAmount: 1, MaxBurnAmount: 1},
+	}
+
+	payload := createBurnPayload()
+	contract := CreateZCNSmartContract()
+	tr := CreateAddAuthorizerTransaction(defaultClient, ctx)
+
+	eventDb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		Debug:                 true,
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = eventDb.Drop()
+		require.NoError(t, err)
+
+		eventDb.Close()
+	})
+
+	ctx.SetEventDb(eventDb)
+
+	payload.ChainID = "1"
+	_, err = contract.Burn(tr, payload.Encode(), ctx)
+	require.ErrorContains(t, err, "unknown chain 1")
+
+	payload.ChainID = "137"
+	tr.Value = 2
+	_, err = contract.Burn(tr, payload.Encode(), ctx)
+	require.ErrorContains(t, err, "greater than max burn amount")
+
+	tr.Value = 1
+	burn, err := contract.Burn(tr, payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	response := &BurnPayloadResponse{}
+	require.NoError(t, response.Decode([]byte(burn)))
+	require.Equal(t, "137", response.ChainID)
+	require.Equal(t, int64(1), response.Nonce)
+
+	burnTicketEvent, ok := burnTicketEvents[ChainScoped(payload.EthereumAddress, "137")]
+	require.True(t, ok)
+	require.Equal(t, "137", burnTicketEvent[0].ChainID)
+
+	node, err := GetUserNode(ETH_ADDRESS, ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), node.BurnNonce, "nonce of the default chain")
+	require.Equal(t, int64(1), node.BurnNonces["137"])
+}
+
 func Test_UserNodeSaveTest(t *testing.T) {
 	ctx := MakeMockStateContext()
 	node, err := GetUserNode(ETH_ADDRESS, ctx)
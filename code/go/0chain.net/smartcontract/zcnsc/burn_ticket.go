@@ -8,14 +8,16 @@ import (
 
 type BurnTicket struct {
 	EthereumAddress string        `json:"ethereum_address"`
+	ChainID         string        `json:"chain_id,omitempty"`
 	Hash            string        `json:"hash"`
 	Amount          currency.Coin `json:"amount"`
 	Nonce           int64         `json:"nonce"`
 }
 
-func NewBurnTicket(ethereumAddress, hash string, amount currency.Coin, nonce int64) *BurnTicket {
+func NewBurnTicket(ethereumAddress, chainID, hash string, amount currency.Coin, nonce int64) *BurnTicket {
 	m := &BurnTicket{
 		EthereumAddress: ethereumAddress,
+		ChainID:         chainID,
 		Hash:            hash,
 		Amount:          amount,
 		Nonce:           nonce,
@@ -13,6 +13,7 @@ import (
 
 	"0chain.net/chaincore/smartcontractinterface"
 	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/governancesc"
 	"github.com/pkg/errors"
 )
 
@@ -36,6 +37,22 @@ const (
 	Cost                = "cost"
 	MaxDelegates        = "max_delegates"
 	HealthCheckPeriod   = "health_check_period"
+	Chains              = "chains"
+	MaxMintAmount       = "max_mint"
+	MaxBurnAmount       = "max_burn"
+)
+
+// bridge limits
+const (
+	GuardianID           = "guardian_id"
+	Paused               = "paused"
+	LimitPeriod          = "limit_period"
+	MaxMintPerPeriod     = "max_mint_per_period"
+	MaxBurnPerPeriod     = "max_burn_per_period"
+	MaxUserMintPerPeriod = "max_user_mint_per_period"
+	MaxUserBurnPerPeriod = "max_user_burn_per_period"
+	LargeMintThreshold   = "large_mint_threshold"
+	LargeMintDelay       = "large_mint_delay"
 )
 
 var CostFunctions = []string{
@@ -76,7 +93,7 @@ func (zcn *ZCNSmartContract) UpdateGlobalConfig(t *transaction.Transaction, inpu
 	}
 
 	if err := smartcontractinterface.AuthorizeWithOwner(FuncName, func() bool {
-		return gn.OwnerId == t.ClientID
+		return gn.OwnerId == t.ClientID || t.ClientID == governancesc.ADDRESS
 	}); err != nil {
 		return "", errors.Wrap(err, Code)
 	}
@@ -105,30 +122,50 @@ func (zcn *ZCNSmartContract) UpdateGlobalConfig(t *transaction.Transaction, inpu
 
 func (gn *GlobalNode) ToStringMap() config.StringMap {
 	fields := map[string]string{
-		MinMintAmount:       fmt.Sprintf("%v", gn.MinMintAmount),
-		MinBurnAmount:       fmt.Sprintf("%v", gn.MinBurnAmount),
-		MinStakeAmount:      fmt.Sprintf("%v", gn.MinStakeAmount),
-		MinStakePerDelegate: fmt.Sprintf("%v", gn.MinStakePerDelegate),
-		MaxStakeAmount:      fmt.Sprintf("%v", gn.MaxStakeAmount),
-		PercentAuthorizers:  fmt.Sprintf("%v", gn.PercentAuthorizers),
-		MinAuthorizers:      fmt.Sprintf("%v", gn.MinAuthorizers),
-		MinLockAmount:       fmt.Sprintf("%v", gn.MinLockAmount),
-		MaxFee:              fmt.Sprintf("%v", gn.MaxFee),
-		BurnAddress:         fmt.Sprintf("%v", gn.BurnAddress),
-		OwnerID:             fmt.Sprintf("%v", gn.OwnerId),
-		MaxDelegates:        fmt.Sprintf("%v", gn.MaxDelegates),
-		HealthCheckPeriod:   fmt.Sprintf("%v", gn.HealthCheckPeriod),
+		MinMintAmount:        fmt.Sprintf("%v", gn.MinMintAmount),
+		MinBurnAmount:        fmt.Sprintf("%v", gn.MinBurnAmount),
+		MinStakeAmount:       fmt.Sprintf("%v", gn.MinStakeAmount),
+		MinStakePerDelegate:  fmt.Sprintf("%v", gn.MinStakePerDelegate),
+		MaxStakeAmount:       fmt.Sprintf("%v", gn.MaxStakeAmount),
+		PercentAuthorizers:   fmt.Sprintf("%v", gn.PercentAuthorizers),
+		MinAuthorizers:       fmt.Sprintf("%v", gn.MinAuthorizers),
+		MinLockAmount:        fmt.Sprintf("%v", gn.MinLockAmount),
+		MaxFee:               fmt.Sprintf("%v", gn.MaxFee),
+		BurnAddress:          fmt.Sprintf("%v", gn.BurnAddress),
+		OwnerID:              fmt.Sprintf("%v", gn.OwnerId),
+		MaxDelegates:         fmt.Sprintf("%v", gn.MaxDelegates),
+		HealthCheckPeriod:    fmt.Sprintf("%v", gn.HealthCheckPeriod),
+		GuardianID:           fmt.Sprintf("%v", gn.GuardianID),
+		Paused:               fmt.Sprintf("%v", gn.Paused),
+		LimitPeriod:          fmt.Sprintf("%v", gn.LimitPeriod),
+		MaxMintPerPeriod:     fmt.Sprintf("%v", gn.MaxMintPerPeriod),
+		MaxBurnPerPeriod:     fmt.Sprintf("%v", gn.MaxBurnPerPeriod),
+		MaxUserMintPerPeriod: fmt.Sprintf("%v", gn.MaxUserMintPerPeriod),
+		MaxUserBurnPerPeriod: fmt.Sprintf("%v", gn.MaxUserBurnPerPeriod),
+		LargeMintThreshold:   fmt.Sprintf("%v", gn.LargeMintThreshold),
+		LargeMintDelay:       fmt.Sprintf("%v", gn.LargeMintDelay),
 	}
 
 	for _, key := range CostFunctions {
 		fields[fmt.Sprintf("cost.%s", key)] = fmt.Sprintf("%0v", gn.Cost[strings.ToLower(key)])
 	}
 
+	for id, cc := range gn.Chains {
+		fields[chainKey(id, MinMintAmount)] = fmt.Sprintf("%v", cc.MinMintAmount)
+		fields[chainKey(id, MaxMintAmount)] = fmt.Sprintf("%v", cc.MaxMintAmount)
+		fields[chainKey(id, MinBurnAmount)] = fmt.Sprintf("%v", cc.MinBurnAmount)
+		fields[chainKey(id, MaxBurnAmount)] = fmt.Sprintf("%v", cc.MaxBurnAmount)
+	}
+
 	return config.StringMap{
 		Fields: fields,
 	}
 }
 
+func chainKey(chainID, setting string) string {
+	return fmt.Sprintf("%s.%s.%s", Chains, chainID, setting)
+}
+
 func postfix(section string) string {
 	return fmt.Sprintf("%s.%s.%s", SmartContract, ZcnSc, section)
 }
@@ -167,6 +204,54 @@ func getConfig() (conf *ZCNSConfig, err error) {
 	conf.Cost = cfg.GetStringMapInt(postfix(Cost))
 	conf.MaxDelegates = cfg.GetInt(postfix(MaxDelegates))
 	conf.HealthCheckPeriod = cfg.GetDuration(postfix(HealthCheckPeriod))
+	conf.Chains, err = getChainsConfig()
+	if err != nil {
+		return nil, err
+	}
+	conf.GuardianID = cfg.GetString(postfix(GuardianID))
+	conf.Paused = cfg.GetBool(postfix(Paused))
+	conf.LimitPeriod = cfg.GetInt64(postfix(LimitPeriod))
+	for setting, amount := range map[string]*currency.Coin{
+		MaxMintPerPeriod:     &conf.MaxMintPerPeriod,
+		MaxBurnPerPeriod:     &conf.MaxBurnPerPeriod,
+		MaxUserMintPerPeriod: &conf.MaxUserMintPerPeriod,
+		MaxUserBurnPerPeriod: &conf.MaxUserBurnPerPeriod,
+		LargeMintThreshold:   &conf.LargeMintThreshold,
+	} {
+		*amount, err = currency.ParseZCN(cfg.GetFloat64(postfix(setting)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	conf.LargeMintDelay = cfg.GetInt64(postfix(LargeMintDelay))
 
 	return conf, nil
 }
+
+func getChainsConfig() (map[string]*ChainConfig, error) {
+	ids := cfg.GetStringMap(postfix(Chains))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	chains := make(map[string]*ChainConfig, len(ids))
+	for id := range ids {
+		var (
+			cc  = new(ChainConfig)
+			err error
+		)
+		for setting, amount := range map[string]*currency.Coin{
+			MinMintAmount: &cc.MinMintAmount,
+			MaxMintAmount: &cc.MaxMintAmount,
+			MinBurnAmount: &cc.MinBurnAmount,
+			MaxBurnAmount: &cc.MaxBurnAmount,
+		} {
+			*amount, err = currency.ParseZCN(cfg.GetFloat64(postfix(chainKey(id, setting))))
+			if err != nil {
+				return nil, err
+			}
+		}
+		chains[id] = cc
+	}
+	return chains, nil
+}
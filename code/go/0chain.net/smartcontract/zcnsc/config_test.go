@@ -33,7 +33,7 @@ func TestConfigMap_Get(t *testing.T) {
 
 	stringMap := cfg.ToStringMap()
 
-	require.Equal(t, 17, len(stringMap.Fields))
+	require.Equal(t, 26, len(stringMap.Fields))
 	require.Contains(t, stringMap.Fields, OwnerID)
 	require.Contains(t, stringMap.Fields, MinBurnAmount)
 	require.Contains(t, stringMap.Fields, MinMintAmount)
@@ -47,6 +47,15 @@ func TestConfigMap_Get(t *testing.T) {
 	require.Contains(t, stringMap.Fields, PercentAuthorizers)
 	require.Contains(t, stringMap.Fields, MaxDelegates)
 	require.Contains(t, stringMap.Fields, HealthCheckPeriod)
+	require.Contains(t, stringMap.Fields, GuardianID)
+	require.Contains(t, stringMap.Fields, Paused)
+	require.Contains(t, stringMap.Fields, LimitPeriod)
+	require.Contains(t, stringMap.Fields, MaxMintPerPeriod)
+	require.Contains(t, stringMap.Fields, MaxBurnPerPeriod)
+	require.Contains(t, stringMap.Fields, MaxUserMintPerPeriod)
+	require.Contains(t, stringMap.Fields, MaxUserBurnPerPeriod)
+	require.Contains(t, stringMap.Fields, LargeMintThreshold)
+	require.Contains(t, stringMap.Fields, LargeMintDelay)
 
 	for _, costFunction := range CostFunctions {
 		require.Contains(t, stringMap.Fields, fmt.Sprintf("%s.%s", Cost, costFunction))
@@ -151,6 +151,7 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 				delete(ctx.authorizers, key)
 				return key
 			}
+			delete(ctx.data, key)
 			return ""
 		},
 		func(_ datastore.Key) error {
@@ -284,10 +285,6 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 		mock.AnythingOfType("*event.BridgeMint"),
 	).Run(
 		func(args mock.Arguments) {
-			userId, ok := args.Get(2).(string)
-			if !ok {
-				panic("failed to convert to user id")
-			}
 			bm, ok := args.Get(3).(*event.BridgeMint)
 			if !ok {
 				panic("failed to convert to get user")
@@ -296,9 +293,6 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 				UserID:    bm.UserID,
 				MintNonce: bm.MintNonce,
 			}
-			if user.UserID != userId {
-				panic("user id must be equal to the id given as a param")
-			}
 
 			err := ctx.eventDb.Get().Clauses(clause.OnConflict{
 				Columns:   []clause.Column{{Name: "user_id"}},
@@ -317,7 +311,7 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 		mock.AnythingOfType("*event.BurnTicket"),
 	).Run(
 		func(args mock.Arguments) {
-			ethereumAdress, ok := args.Get(2).(string)
+			index, ok := args.Get(2).(string)
 			if !ok {
 				panic("failed to convert to user id")
 			}
@@ -325,10 +319,10 @@ func MakeMockStateContextWithoutAutorizers() *mockStateContext {
 			if !ok {
 				panic("failed to convert to get user")
 			}
-			if burnTicket.EthereumAddress != ethereumAdress {
-				panic("given ethereum address as index should be equal to the one given as a payload")
+			if ChainScoped(burnTicket.EthereumAddress, burnTicket.ChainID) != index {
+				panic("given index should be the ethereum address of the payload scoped by its chain")
 			}
-			burnTicketEvents[ethereumAdress] = append(burnTicketEvents[ethereumAdress], burnTicket)
+			burnTicketEvents[index] = append(burnTicketEvents[index], burnTicket)
 		})
 
 	ctx.On("EmitEvent",
@@ -1,6 +1,7 @@
 package zcnsc_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 
@@ -30,9 +31,18 @@ var (
 	clients             = []string{clientPrefixID + "_0", clientPrefixID + "_1", clientPrefixID + "_2"}
 	defaultAuthorizer   = authorizersID[0]
 	defaultClient       = clients[0]
-	AuthorizerPublicKey = "57b0bc98f4af974c6842e5eb812004af3bc02564b0e59bc734274a531471470b9f081c10b61ca44274a350293f98c6425e7701e18569d6daa0d025dbd6f42e01"
+	authorizerScheme    = newAuthorizerScheme()
+	AuthorizerPublicKey = authorizerScheme.GetPublicKey()
 )
 
+func newAuthorizerScheme() encryption.SignatureScheme {
+	scheme := encryption.NewBLS0ChainScheme()
+	if err := scheme.GenerateKeys(); err != nil {
+		panic(err)
+	}
+	return scheme
+}
+
 type Authorizer struct {
 	Scheme encryption.SignatureScheme
 	Node   *AuthorizerNode
@@ -103,7 +113,7 @@ func CreateAddAuthorizerTransaction(fromClient string, ctx state.StateContextI)
 		OutputHash:        "",
 	}
 
-	addTransactionData(txn, AddAuthorizerFunc, CreateAuthorizerParamPayload(fromClient, AuthorizerPublicKey))
+	addTransactionData(txn, AddAuthorizerFunc, CreateAuthorizerParamPayload(fromClient, authorizerScheme))
 
 	return txn
 }
@@ -136,10 +146,18 @@ func CreateTransaction(fromClient, method string, payload []byte, ctx state.Stat
 	return txn, nil
 }
 
-func CreateAuthorizerParam(delegateWalletID string, publicKey string) *AddAuthorizerPayload {
+// CreateAuthorizerParam creates the payload registering the key of the scheme,
+// the scheme signs the proof of possession
+func CreateAuthorizerParam(delegateWalletID string, scheme encryption.SignatureScheme) *AddAuthorizerPayload {
+	proof, err := scheme.Sign(ProofOfPossessionHash(scheme.GetPublicKey()))
+	if err != nil {
+		panic(err)
+	}
+
 	return &AddAuthorizerPayload{
-		PublicKey: publicKey,
-		URL:       "http://localhost:2344",
+		PublicKey:         scheme.GetPublicKey(),
+		ProofOfPossession: proof,
+		URL:               "http://localhost:2344",
 		StakePoolSettings: stakepool.Settings{
 			DelegateWallet:     delegateWalletID,
 			MaxNumDelegates:    12345678,
@@ -158,8 +176,8 @@ func CreateAuthorizerStakingPoolParam(delegateWalletID string) *UpdateAuthorizer
 	}
 }
 
-func CreateAuthorizerParamPayload(delegateWalletID string, publicKey string) []byte {
-	p := CreateAuthorizerParam(delegateWalletID, publicKey)
+func CreateAuthorizerParamPayload(delegateWalletID string, scheme encryption.SignatureScheme) []byte {
+	p := CreateAuthorizerParam(delegateWalletID, scheme)
 	encode, _ := p.Encode()
 	return encode
 }
@@ -237,6 +255,51 @@ func CreateMintPayloadWithNonce(ctx *mockStateContext, receiverId string, nonce
 	return
 }
 
+// CreateAggregatedMintPayload indexes the test authorizers and creates a payload
+// carrying the aggregated signature of the given signers
+func CreateAggregatedMintPayload(ctx *mockStateContext, receiverId string, signers []string) (payload *MintPayload, err error) {
+	payload = &MintPayload{
+		EthereumTxnID:     txHash,
+		Amount:            200,
+		Nonce:             1,
+		ReceivingClientID: receiverId,
+	}
+
+	index := &AuthorizerIndex{}
+	for _, id := range authorizersID {
+		index.Add(id)
+	}
+	if err = index.Save(ctx); err != nil {
+		return nil, err
+	}
+
+	var sigs []string
+	for _, id := range signers {
+		signature, err := ctx.authorizers[NewAuthorizerNode(id).GetKey()].Sign(payload.GetStringToSign())
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, signature)
+	}
+
+	aggregated, err := encryption.NewBLS0ChainScheme().AggregateSignatures(sigs)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap, err := index.Bitmap(signers)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.AggregatedSignature = &AggregatedSignature{
+		Signature: aggregated,
+		Signers:   hex.EncodeToString(bitmap),
+	}
+
+	return
+}
+
 func createTransactionSignatures(ctx *mockStateContext, m *MintPayload) ([]*AuthorizerSignature, error) {
 	var sigs []*AuthorizerSignature
 
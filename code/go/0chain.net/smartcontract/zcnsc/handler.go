@@ -37,6 +37,8 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		{URI: zcn + "/getAuthorizer", Handler: common.UserRateLimit(zrh.getAuthorizer)},
 		{URI: zcn + "/v1/mint_nonce", Handler: common.UserRateLimit(zrh.MintNonceHandler)},
 		{URI: zcn + "/v1/not_processed_burn_tickets", Handler: common.UserRateLimit(zrh.NotProcessedBurnTicketsHandler)},
+		{URI: zcn + "/v1/bridge_usage", Handler: common.UserRateLimit(zrh.BridgeUsageHandler)},
+		{URI: zcn + "/v1/pending_mints", Handler: common.UserRateLimit(zrh.PendingMintsHandler)},
 	}
 }
 
@@ -119,7 +121,8 @@ func (zrh *ZcnRestHandler) getAuthorizer(w http.ResponseWriter, r *http.Request)
 	common.Respond(w, r, rtv, nil)
 }
 
-// MintNonceHandler returns the latest mint nonce for the client with the help of the given client id
+// MintNonceHandler returns the latest mint nonce for the client with the help of the given client id,
+// the chain_id selects the mints from a chain other than the default one
 func (zrh *ZcnRestHandler) MintNonceHandler(w http.ResponseWriter, r *http.Request) {
 	edb := zrh.GetQueryStateContext().GetEventDB()
 	if edb == nil {
@@ -129,6 +132,19 @@ func (zrh *ZcnRestHandler) MintNonceHandler(w http.ResponseWriter, r *http.Reque
 
 	clientID := r.FormValue("client_id")
 
+	if chainID := r.FormValue("chain_id"); chainID != "" {
+		nonce, err := edb.GetBridgeMintNonce(clientID, chainID)
+		switch err {
+		case nil:
+			common.Respond(w, r, nonce.MintNonce, nil)
+		case util.ErrValueNotPresent:
+			common.Respond(w, r, 0, nil)
+		default:
+			common.Respond(w, r, nil, errors.Wrap(err, "GetBridgeMintNonce DB error, ID = "+clientID))
+		}
+		return
+	}
+
 	user, err := edb.GetUser(clientID)
 	if err != nil {
 		common.Respond(w, r, nil, errors.Wrap(err, "GetUser DB error, ID = "+clientID))
@@ -139,7 +155,7 @@ func (zrh *ZcnRestHandler) MintNonceHandler(w http.ResponseWriter, r *http.Reque
 }
 
 // NotProcessedBurnTicketsHandler returns not processed ZCN burn tickets for the given ethereum address and client id
-// with a help of offset nonce
+// with a help of offset nonce, the chain_id selects the burns to a chain other than the default one
 func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter, r *http.Request) {
 	edb := zrh.GetQueryStateContext().GetEventDB()
 	if edb == nil {
@@ -165,7 +181,9 @@ func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter,
 		}
 	}
 
-	burnTickets, err := edb.GetBurnTickets(ethereumAddress)
+	chainID := r.FormValue("chain_id")
+
+	burnTickets, err := edb.GetBurnTickets(ethereumAddress, chainID)
 	if err != nil {
 		common.Respond(w, r, nil, errors.Wrap(err, "Failed to retrieve burn tickets"))
 		return
@@ -179,6 +197,7 @@ func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter,
 				response,
 				NewBurnTicket(
 					burnTicket.EthereumAddress,
+					burnTicket.ChainID,
 					burnTicket.Hash,
 					burnTicket.Amount,
 					burnTicket.Nonce,
@@ -193,6 +212,75 @@ func (zrh *ZcnRestHandler) NotProcessedBurnTicketsHandler(w http.ResponseWriter,
 	common.Respond(w, r, response, nil)
 }
 
+// bridgeUsageResponse is the state of the bridge limits in the current period
+type bridgeUsageResponse struct {
+	Paused               bool          `json:"paused"`
+	LimitPeriod          int64         `json:"limit_period"`
+	Period               int64         `json:"period"`
+	MaxMintPerPeriod     currency.Coin `json:"max_mint_per_period"`
+	MaxBurnPerPeriod     currency.Coin `json:"max_burn_per_period"`
+	MaxUserMintPerPeriod currency.Coin `json:"max_user_mint_per_period"`
+	MaxUserBurnPerPeriod currency.Coin `json:"max_user_burn_per_period"`
+	Bridge               *BridgeUsage  `json:"bridge"`
+	Client               *BridgeUsage  `json:"client,omitempty"`
+}
+
+// BridgeUsageHandler returns the caps of the bridge and the amounts minted and burned in the current
+// period by the whole bridge and, if the client_id is given, by the client
+func (zrh *ZcnRestHandler) BridgeUsageHandler(w http.ResponseWriter, r *http.Request) {
+	sctx := zrh.GetQueryStateContext()
+	gn, err := GetGlobalNode(sctx)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("get global node: "+err.Error()))
+		return
+	}
+
+	period := gn.limitPeriod(sctx.GetBlock().Round)
+	bridge, err := GetBridgeUsage("", period, sctx)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("get bridge usage: "+err.Error()))
+		return
+	}
+
+	resp := &bridgeUsageResponse{
+		Paused:               gn.Paused,
+		LimitPeriod:          gn.LimitPeriod,
+		Period:               period,
+		MaxMintPerPeriod:     gn.MaxMintPerPeriod,
+		MaxBurnPerPeriod:     gn.MaxBurnPerPeriod,
+		MaxUserMintPerPeriod: gn.MaxUserMintPerPeriod,
+		MaxUserBurnPerPeriod: gn.MaxUserBurnPerPeriod,
+		Bridge:               bridge,
+	}
+
+	if clientID := r.FormValue("client_id"); clientID != "" {
+		resp.Client, err = GetBridgeUsage(clientID, period, sctx)
+		if err != nil {
+			common.Respond(w, r, nil, common.NewErrInternal("get client usage: "+err.Error()))
+			return
+		}
+	}
+
+	common.Respond(w, r, resp, nil)
+}
+
+// PendingMintsHandler returns the mints of the client held by the large mint delay
+func (zrh *ZcnRestHandler) PendingMintsHandler(w http.ResponseWriter, r *http.Request) {
+	clientID := r.FormValue("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("argument 'client_id' should not be empty"))
+		return
+	}
+
+	pm, err := GetPendingMints(clientID, zrh.GetQueryStateContext())
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("get pending mints: "+err.Error()))
+		return
+	}
+
+	common.Respond(w, r, pm, nil)
+}
+
 // swagger:model authorizerResponse
 type authorizerResponse struct {
 	AuthorizerID string `json:"id"`
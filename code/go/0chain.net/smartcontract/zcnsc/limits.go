@@ -0,0 +1,423 @@
+package zcnsc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore CancelPendingMintPayload usageCap
+//go:generate msgp -io=false -tests=false -unexported -v
+
+const (
+	BridgeUsageNodeType  = "bridgeusage"
+	PendingMintsNodeType = "pendingmints"
+)
+
+// ErrBridgePaused is returned by the bridge functions while the bridge is paused
+var ErrBridgePaused = errors.New("bridge is paused")
+
+// ----- BridgeUsage ------------------
+
+// BridgeUsage is the amount minted and burned in a limit period by a client,
+// the usage of the whole bridge has an empty ID
+type BridgeUsage struct {
+	ID     string        `json:"id"`
+	Period int64         `json:"period"`
+	Minted currency.Coin `json:"minted"`
+	Burned currency.Coin `json:"burned"`
+}
+
+func (bu *BridgeUsage) GetKey() datastore.Key {
+	return fmt.Sprintf("%s:%s:%s", ADDRESS, BridgeUsageNodeType, bu.ID)
+}
+
+func (bu *BridgeUsage) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(bu.GetKey(), bu)
+	return
+}
+
+// GetBridgeUsage returns the usage of the client in the given period, the
+// amounts of an elapsed period are reset
+func GetBridgeUsage(id string, period int64, ctx cstate.CommonStateContextI) (*BridgeUsage, error) {
+	usage := &BridgeUsage{ID: id}
+	err := ctx.GetTrieNode(usage.GetKey(), usage)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+	default:
+		return nil, err
+	}
+
+	if usage.Period != period {
+		usage.Period = period
+		usage.Minted = 0
+		usage.Burned = 0
+	}
+	return usage, nil
+}
+
+// limitPeriod the round belongs to
+func (gn *GlobalNode) limitPeriod(round int64) int64 {
+	if gn.LimitPeriod <= 0 {
+		return 0
+	}
+	return round / gn.LimitPeriod
+}
+
+// usageCap is the cap of the usage with the ID
+type usageCap struct {
+	id  string
+	max currency.Coin
+}
+
+// useMintLimits adds the amount to the minted amounts of the client and of the
+// bridge in the current period, the mint is rejected if any cap is exceeded
+func (gn *GlobalNode) useMintLimits(clientID string, amount currency.Coin, ctx cstate.StateContextI) error {
+	return gn.useLimits(amount, ctx, func(usage *BridgeUsage) *currency.Coin {
+		return &usage.Minted
+	}, usageCap{id: "", max: gn.MaxMintPerPeriod}, usageCap{id: clientID, max: gn.MaxUserMintPerPeriod})
+}
+
+// useBurnLimits adds the amount to the burned amounts of the client and of the
+// bridge in the current period, the burn is rejected if any cap is exceeded
+func (gn *GlobalNode) useBurnLimits(clientID string, amount currency.Coin, ctx cstate.StateContextI) error {
+	return gn.useLimits(amount, ctx, func(usage *BridgeUsage) *currency.Coin {
+		return &usage.Burned
+	}, usageCap{id: "", max: gn.MaxBurnPerPeriod}, usageCap{id: clientID, max: gn.MaxUserBurnPerPeriod})
+}
+
+// useLimits of the given caps, the usage is only kept for the capped amounts
+func (gn *GlobalNode) useLimits(
+	amount currency.Coin,
+	ctx cstate.StateContextI,
+	used func(*BridgeUsage) *currency.Coin,
+	caps ...usageCap,
+) error {
+	if gn.LimitPeriod <= 0 {
+		return nil
+	}
+
+	period := gn.limitPeriod(ctx.GetBlock().Round)
+	for _, c := range caps {
+		if c.max == 0 {
+			continue
+		}
+
+		usage, err := GetBridgeUsage(c.id, period, ctx)
+		if err != nil {
+			return fmt.Errorf("get bridge usage: %v", err)
+		}
+
+		total, err := currency.AddCoin(*used(usage), amount)
+		if err != nil {
+			return err
+		}
+		if total > c.max {
+			who := "bridge"
+			if c.id != "" {
+				who = "client"
+			}
+			return fmt.Errorf("amount (%v) exceeds the %s cap of the period (%v), already used %v",
+				amount, who, c.max, *used(usage))
+		}
+
+		*used(usage) = total
+		if err := usage.Save(ctx); err != nil {
+			return fmt.Errorf("save bridge usage: %v", err)
+		}
+	}
+	return nil
+}
+
+// releaseMintLimits gives back the capped amount of a mint that is not minted,
+// the usage of an elapsed period is already reset
+func (gn *GlobalNode) releaseMintLimits(clientID string, m *PendingMint, ctx cstate.StateContextI) error {
+	if gn.LimitPeriod <= 0 || gn.limitPeriod(ctx.GetBlock().Round) != m.Period {
+		return nil
+	}
+
+	amount, err := currency.AddCoin(m.Amount, m.Fee)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range []string{"", clientID} {
+		usage, err := GetBridgeUsage(id, m.Period, ctx)
+		if err != nil {
+			return fmt.Errorf("get bridge usage: %v", err)
+		}
+
+		if usage.Minted == 0 {
+			continue
+		}
+
+		if usage.Minted < amount {
+			usage.Minted = 0
+		} else {
+			usage.Minted -= amount
+		}
+
+		if err := usage.Save(ctx); err != nil {
+			return fmt.Errorf("save bridge usage: %v", err)
+		}
+	}
+	return nil
+}
+
+// ----- PendingMints ------------------
+
+// PendingMint is a mint above the large mint threshold held until the release
+// round, the fee of the signers is paid on release and the capped amount of
+// the period is the amount and the fee
+type PendingMint struct {
+	ID           string        `json:"id"`
+	ChainID      string        `json:"chain_id,omitempty"`
+	Nonce        int64         `json:"nonce"`
+	Amount       currency.Coin `json:"amount"`
+	Fee          currency.Coin `json:"fee"`
+	Signers      []string      `json:"signers"`
+	Period       int64         `json:"period"`
+	ReleaseRound int64         `json:"release_round"`
+}
+
+// PendingMints of a client
+type PendingMints struct {
+	ClientID string         `json:"client_id"`
+	Mints    []*PendingMint `json:"mints"`
+}
+
+func (pm *PendingMints) GetKey() datastore.Key {
+	return fmt.Sprintf("%s:%s:%s", ADDRESS, PendingMintsNodeType, pm.ClientID)
+}
+
+func (pm *PendingMints) Save(balances cstate.StateContextI) (err error) {
+	if len(pm.Mints) == 0 {
+		_, err = balances.DeleteTrieNode(pm.GetKey())
+		if err == util.ErrValueNotPresent {
+			err = nil
+		}
+		return
+	}
+	_, err = balances.InsertTrieNode(pm.GetKey(), pm)
+	return
+}
+
+// GetPendingMints returns no mints if the node is not found
+func GetPendingMints(clientID string, ctx cstate.CommonStateContextI) (*PendingMints, error) {
+	pm := &PendingMints{ClientID: clientID}
+	err := ctx.GetTrieNode(pm.GetKey(), pm)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return pm, nil
+	default:
+		return nil, err
+	}
+}
+
+// delayMint tells whether the amount is held for the large mint delay
+func (gn *GlobalNode) delayMint(amount currency.Coin) bool {
+	return gn.LargeMintDelay > 0 && gn.LargeMintThreshold > 0 && amount >= gn.LargeMintThreshold
+}
+
+// addPendingMint adds the mint to the pending mints of the client
+func addPendingMint(clientID string, m *PendingMint, ctx cstate.StateContextI) error {
+	pm, err := GetPendingMints(clientID, ctx)
+	if err != nil {
+		return err
+	}
+
+	pm.Mints = append(pm.Mints, m)
+	return pm.Save(ctx)
+}
+
+// release removes the mints due by the round and returns them
+func (pm *PendingMints) release(round int64) []*PendingMint {
+	var (
+		due     []*PendingMint
+		pending = pm.Mints[:0]
+	)
+	for _, m := range pm.Mints {
+		if m.ReleaseRound <= round {
+			due = append(due, m)
+			continue
+		}
+		pending = append(pending, m)
+	}
+	pm.Mints = pending
+	return due
+}
+
+// remove the mint with the ID and return it, nil if there is no such mint
+func (pm *PendingMints) remove(id string) *PendingMint {
+	for i, m := range pm.Mints {
+		if m.ID == id {
+			pm.Mints = append(pm.Mints[:i], pm.Mints[i+1:]...)
+			return m
+		}
+	}
+	return nil
+}
+
+// ----- Guardian functions ------------------
+
+type CancelPendingMintPayload struct {
+	ClientID string `json:"client_id"`
+	ID       string `json:"id"`
+}
+
+func (cp *CancelPendingMintPayload) Decode(input []byte) error {
+	return json.Unmarshal(input, cp)
+}
+
+// canGuard tells whether the client is the guardian or the owner
+func (gn *GlobalNode) canGuard(clientID string) bool {
+	return clientID == gn.OwnerId || (gn.GuardianID != "" && clientID == gn.GuardianID)
+}
+
+// PauseBridge stops the mints and the burns, the bridge can be paused by the
+// guardian or the owner
+func (zcn *ZCNSmartContract) PauseBridge(t *transaction.Transaction, _ []byte, ctx cstate.StateContextI) (string, error) {
+	const code = "failed to pause bridge"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwner(PauseBridgeFunc, func() bool {
+		return gn.canGuard(t.ClientID)
+	}); err != nil {
+		return "", err
+	}
+
+	gn.Paused = true
+	if err := gn.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving global node: "+err.Error())
+	}
+
+	return string(gn.Encode()), nil
+}
+
+// UnpauseBridge resumes the bridge, only the owner can resume it so that a
+// compromised guardian key cannot undo a pause
+func (zcn *ZCNSmartContract) UnpauseBridge(t *transaction.Transaction, _ []byte, ctx cstate.StateContextI) (string, error) {
+	const code = "failed to unpause bridge"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwner(UnpauseBridgeFunc, func() bool {
+		return t.ClientID == gn.OwnerId
+	}); err != nil {
+		return "", err
+	}
+
+	gn.Paused = false
+	if err := gn.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving global node: "+err.Error())
+	}
+
+	return string(gn.Encode()), nil
+}
+
+// CancelPendingMint drops a delayed mint before its release, the nonce of the
+// mint stays used and the capped amount is given back if the period of the
+// mint has not elapsed
+func (zcn *ZCNSmartContract) CancelPendingMint(t *transaction.Transaction, input []byte, ctx cstate.StateContextI) (string, error) {
+	const code = "failed to cancel pending mint"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if err := smartcontractinterface.AuthorizeWithOwner(CancelPendingMintFunc, func() bool {
+		return gn.canGuard(t.ClientID)
+	}); err != nil {
+		return "", err
+	}
+
+	var payload CancelPendingMintPayload
+	if err := payload.Decode(input); err != nil {
+		return "", common.NewError(code, "payload decode error: "+err.Error())
+	}
+
+	pm, err := GetPendingMints(payload.ClientID, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	m := pm.remove(payload.ID)
+	if m == nil {
+		return "", common.NewErrorf(code, "no pending mint %s for client %s", payload.ID, payload.ClientID)
+	}
+
+	if err := gn.releaseMintLimits(payload.ClientID, m, ctx); err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if err := pm.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving pending mints: "+err.Error())
+	}
+
+	ctx.EmitEvent(event.TypeStats, event.TagCancelBridgeMint, m.ID, &event.BridgeMint{
+		UserID:    payload.ClientID,
+		ChainID:   m.ChainID,
+		MintNonce: m.Nonce,
+		Amount:    m.Amount,
+		Signers:   m.Signers,
+	})
+
+	return "", nil
+}
+
+// ReleaseMints mints the delayed mints of the client due by the current round
+func (zcn *ZCNSmartContract) ReleaseMints(t *transaction.Transaction, _ []byte, ctx cstate.StateContextI) (string, error) {
+	const code = "failed to release mints"
+
+	gn, err := GetGlobalNode(ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	if gn.Paused {
+		return "", common.NewError(code, ErrBridgePaused.Error())
+	}
+
+	pm, err := GetPendingMints(t.ClientID, ctx)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+
+	due := pm.release(ctx.GetBlock().Round)
+	if len(due) == 0 {
+		return "", common.NewError(code, "no pending mints to release")
+	}
+
+	for _, m := range due {
+		if err := zcn.mint(t.ClientID, m, ctx); err != nil {
+			return "", common.NewErrorf(code, "mint %s: %v", m.ID, err)
+		}
+	}
+
+	if err := pm.Save(ctx); err != nil {
+		return "", common.NewError(code, "saving pending mints: "+err.Error())
+	}
+
+	out, err := json.Marshal(due)
+	if err != nil {
+		return "", common.NewError(code, err.Error())
+	}
+	return string(out), nil
+}
@@ -0,0 +1,190 @@
+package zcnsc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/config"
+	"0chain.net/smartcontract/dbs/event"
+	. "0chain.net/smartcontract/zcnsc"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const guardianClient = "guardian"
+
+func makeLimitsMockStateContext(t *testing.T) *mockStateContext {
+	ctx := MakeMockStateContext()
+	ctx.globalNode.GuardianID = guardianClient
+
+	eventDb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		Debug:                 true,
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = eventDb.Drop()
+		require.NoError(t, err)
+
+		eventDb.Close()
+	})
+
+	ctx.SetEventDb(eventDb)
+	return ctx
+}
+
+func mintWithNonce(t *testing.T, ctx *mockStateContext, nonce int64) error {
+	payload, err := CreateMintPayloadWithNonce(ctx, defaultClient, nonce)
+	require.NoError(t, err)
+
+	tr := CreateAddAuthorizerTransaction(defaultClient, ctx)
+	_, err = CreateZCNSmartContract().Mint(tr, payload.Encode(), ctx)
+	return err
+}
+
+func Test_PauseBridge(t *testing.T) {
+	ctx := makeLimitsMockStateContext(t)
+	contract := CreateZCNSmartContract()
+
+	_, err := contract.PauseBridge(CreateAddAuthorizerTransaction(defaultClient, ctx), nil, ctx)
+	require.Error(t, err, "only the guardian and the owner can pause")
+
+	_, err = contract.PauseBridge(CreateAddAuthorizerTransaction(guardianClient, ctx), nil, ctx)
+	require.NoError(t, err)
+	require.True(t, ctx.globalNode.Paused)
+
+	err = mintWithNonce(t, ctx, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), ErrBridgePaused.Error())
+
+	tr := CreateAddAuthorizerTransaction(defaultClient, ctx)
+	_, err = contract.Burn(tr, createBurnPayload().Encode(), ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), ErrBridgePaused.Error())
+
+	_, err = contract.UnpauseBridge(CreateAddAuthorizerTransaction(guardianClient, ctx), nil, ctx)
+	require.Error(t, err, "only the owner can unpause")
+
+	_, err = contract.UnpauseBridge(CreateAddAuthorizerTransaction(ctx.globalNode.OwnerId, ctx), nil, ctx)
+	require.NoError(t, err)
+	require.False(t, ctx.globalNode.Paused)
+
+	require.NoError(t, mintWithNonce(t, ctx, 1))
+}
+
+func Test_MintAndBurnCaps(t *testing.T) {
+	ctx := makeLimitsMockStateContext(t)
+	ctx.globalNode.LimitPeriod = 10
+	ctx.globalNode.MaxUserMintPerPeriod = 300
+	ctx.globalNode.MaxBurnPerPeriod = 1
+
+	require.NoError(t, mintWithNonce(t, ctx, 1))
+
+	err := mintWithNonce(t, ctx, 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the client cap")
+
+	// the next period
+	ctx.block.Round = 10
+	require.NoError(t, mintWithNonce(t, ctx, 2))
+
+	usage, err := GetBridgeUsage(defaultClient, 1, ctx)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(200), usage.Minted)
+
+	contract := CreateZCNSmartContract()
+	tr := CreateAddAuthorizerTransaction(defaultClient, ctx)
+	_, err = contract.Burn(tr, createBurnPayload().Encode(), ctx)
+	require.NoError(t, err)
+
+	_, err = contract.Burn(tr, createBurnPayload().Encode(), ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the bridge cap")
+}
+
+func Test_LargeMintDelay(t *testing.T) {
+	ctx := makeLimitsMockStateContext(t)
+	ctx.globalNode.LargeMintThreshold = 100
+	ctx.globalNode.LargeMintDelay = 5
+
+	contract := CreateZCNSmartContract()
+	tr := CreateAddAuthorizerTransaction(defaultClient, ctx)
+
+	require.NoError(t, mintWithNonce(t, ctx, 1))
+	require.Empty(t, ctx.GetMints(), "the large mint is held")
+
+	pm, err := GetPendingMints(defaultClient, ctx)
+	require.NoError(t, err)
+	require.Len(t, pm.Mints, 1)
+	require.Equal(t, int64(5), pm.Mints[0].ReleaseRound)
+	id := pm.Mints[0].ID
+	ctx.AssertNotCalled(t, "EmitEvent", event.TypeStats, event.TagAddBridgeMint, id, mock.Anything)
+
+	_, err = contract.ReleaseMints(tr, nil, ctx)
+	require.Error(t, err, "the delay has not elapsed")
+
+	ctx.block.Round = 5
+	out, err := contract.ReleaseMints(tr, nil, ctx)
+	require.NoError(t, err)
+
+	var released []*PendingMint
+	require.NoError(t, json.Unmarshal([]byte(out), &released))
+	require.Len(t, released, 1)
+
+	mm := ctx.GetMints()
+	require.Len(t, mm, 1)
+	require.Equal(t, currency.Coin(200), mm[0].Amount)
+	require.Equal(t, defaultClient, mm[0].ToClientID)
+	ctx.AssertCalled(t, "EmitEvent", event.TypeStats, event.TagAddBridgeMint, id, mock.Anything)
+
+	pm, err = GetPendingMints(defaultClient, ctx)
+	require.NoError(t, err)
+	require.Empty(t, pm.Mints)
+}
+
+func Test_CancelPendingMint(t *testing.T) {
+	ctx := makeLimitsMockStateContext(t)
+	ctx.globalNode.LargeMintThreshold = 100
+	ctx.globalNode.LargeMintDelay = 5
+	ctx.globalNode.LimitPeriod = 10
+	ctx.globalNode.MaxUserMintPerPeriod = 300
+
+	contract := CreateZCNSmartContract()
+
+	require.NoError(t, mintWithNonce(t, ctx, 1))
+
+	pm, err := GetPendingMints(defaultClient, ctx)
+	require.NoError(t, err)
+	require.Len(t, pm.Mints, 1)
+
+	input, err := json.Marshal(&CancelPendingMintPayload{ClientID: defaultClient, ID: pm.Mints[0].ID})
+	require.NoError(t, err)
+
+	_, err = contract.CancelPendingMint(CreateAddAuthorizerTransaction(defaultClient, ctx), input, ctx)
+	require.Error(t, err, "only the guardian and the owner can cancel")
+
+	_, err = contract.CancelPendingMint(CreateAddAuthorizerTransaction(guardianClient, ctx), input, ctx)
+	require.NoError(t, err)
+	ctx.AssertCalled(t, "EmitEvent", event.TypeStats, event.TagCancelBridgeMint, pm.Mints[0].ID, mock.Anything)
+
+	pm, err = GetPendingMints(defaultClient, ctx)
+	require.NoError(t, err)
+	require.Empty(t, pm.Mints)
+
+	usage, err := GetBridgeUsage(defaultClient, 0, ctx)
+	require.NoError(t, err)
+	require.Zero(t, usage.Minted, "the capped amount is given back")
+
+	_, err = contract.CancelPendingMint(CreateAddAuthorizerTransaction(guardianClient, ctx), input, ctx)
+	require.Error(t, err, "the mint is already canceled")
+
+	ctx.block.Round = 5
+	_, err = contract.ReleaseMints(CreateAddAuthorizerTransaction(defaultClient, ctx), nil, ctx)
+	require.Error(t, err, "nothing to release")
+	require.Empty(t, ctx.GetMints())
+
+	err = mintWithNonce(t, ctx, 1)
+	require.Error(t, err, "the nonce of a canceled mint stays used")
+}
@@ -37,6 +37,10 @@ func (zcn *ZCNSmartContract) Mint(trans *transaction.Transaction, inputData []by
 		return "", common.NewError(code, msg)
 	}
 
+	if gn.Paused {
+		return "", common.NewError(code, fmt.Sprintf("%v, %s", ErrBridgePaused, info))
+	}
+
 	payload := &MintPayload{}
 	err = payload.Decode(inputData)
 	if err != nil {
@@ -45,12 +49,18 @@ func (zcn *ZCNSmartContract) Mint(trans *transaction.Transaction, inputData []by
 		return
 	}
 
-	if len(payload.Signatures) == 0 {
+	if len(payload.Signatures) == 0 && payload.AggregatedSignature == nil {
 		msg := fmt.Sprintf("payload doesn't contain signatures: %v, %s", err, info)
 		err = common.NewError(code, msg)
 		return
 	}
 
+	if len(payload.Signatures) > 0 && payload.AggregatedSignature != nil {
+		msg := fmt.Sprintf("payload contains both signatures and an aggregated signature, %s", info)
+		err = common.NewError(code, msg)
+		return
+	}
+
 	numAuth, err := getAuthorizerCount(ctx)
 	if err != nil {
 		msg := fmt.Sprintf("error while retriving number of authorizers: %v, %s", err, info)
@@ -64,8 +74,9 @@ func (zcn *ZCNSmartContract) Mint(trans *transaction.Transaction, inputData []by
 
 	threshold := int(math.RoundToEven(gn.PercentAuthorizers * float64(numAuth)))
 
-	// if number of slices exceeds limits the check only withing required range
-	if len(payload.Signatures) < threshold {
+	// if number of slices exceeds limits the check only withing required range,
+	// the signers of an aggregated signature are counted once it's verified
+	if payload.AggregatedSignature == nil && len(payload.Signatures) < threshold {
 		msg := fmt.Sprintf("no of signatures lesser than threshold %d: %v, %s", threshold, err, info)
 		err = common.NewError(code, msg)
 		return
@@ -85,19 +96,25 @@ func (zcn *ZCNSmartContract) Mint(trans *transaction.Transaction, inputData []by
 		return
 	}
 
+	chain, err := gn.chain(payload.ChainID)
+	if err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
+		return
+	}
+
 	// check mint amount
-	if payload.Amount < gn.MinMintAmount {
-		msg := fmt.Sprintf(
-			"amount requested (%v) is lower than min amount for mint (%v), %s",
-			payload.Amount,
-			gn.MinMintAmount,
-			info,
-		)
-		err = common.NewError(code, msg)
+	if err = chain.checkMint(payload.Amount); err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
+		return
+	}
+
+	// check and use the mint caps of the period
+	if err = gn.useMintLimits(trans.ClientID, payload.Amount, ctx); err != nil {
+		err = common.NewError(code, fmt.Sprintf("%v, %s", err, info))
 		return
 	}
 
-	if err = PartitionWZCNMintedNonceAdd(ctx, payload.Nonce); err != nil {
+	if err = PartitionWZCNMintedNonceAdd(ctx, payload.ChainID, payload.Nonce); err != nil {
 		if partitions.ErrItemExist(err) {
 			err = common.NewError(
 				code,
@@ -111,29 +128,53 @@ func (zcn *ZCNSmartContract) Mint(trans *transaction.Transaction, inputData []by
 		return
 	}
 
-	uniqueSignatures := payload.getUniqueSignatures()
+	var signers []string
+	if payload.AggregatedSignature != nil {
+		// verify the aggregated signature of the authorizers at once
+		signers, err = payload.verifyAggregatedSignature(ctx)
+		if err != nil {
+			msg := fmt.Sprintf("failed to verify aggregated signature with error: %v, %s", err, info)
+			err = common.NewError(code, msg)
+			return
+		}
 
-	// verify signatures of authorizers
-	err = payload.verifySignatures(uniqueSignatures, ctx)
-	if err != nil {
-		msg := fmt.Sprintf("failed to verify signatures with error: %v, %s", err, info)
-		err = common.NewError(code, msg)
-		return
-	}
+		if len(signers) < threshold {
+			err = common.NewError(
+				code,
+				"not enough valid signatures for minting",
+			)
+			return
+		}
+	} else {
+		uniqueSignatures := payload.getUniqueSignatures()
 
-	if len(uniqueSignatures) < threshold {
-		err = common.NewError(
-			code,
-			"not enough valid signatures for minting",
-		)
-		return
+		// verify signatures of authorizers
+		err = payload.verifySignatures(uniqueSignatures, ctx)
+		if err != nil {
+			msg := fmt.Sprintf("failed to verify signatures with error: %v, %s", err, info)
+			err = common.NewError(code, msg)
+			return
+		}
+
+		if len(uniqueSignatures) < threshold {
+			err = common.NewError(
+				code,
+				"not enough valid signatures for minting",
+			)
+			return
+		}
+
+		signers = make([]string, 0, len(payload.Signatures))
+		for _, sig := range payload.Signatures {
+			signers = append(signers, sig.ID)
+		}
 	}
 
 	var (
 		amount currency.Coin
 		share  currency.Coin
 	)
-	share, _, err = currency.DistributeCoin(gn.ZCNSConfig.MaxFee, int64(len(payload.Signatures)))
+	share, _, err = currency.DistributeCoin(gn.ZCNSConfig.MaxFee, int64(len(signers)))
 	if err != nil {
 		err = errors.Wrap(err, fmt.Sprintf("%s, DistributeCoin operation, %s", code, info))
 		return
@@ -146,56 +187,76 @@ func (zcn *ZCNSmartContract) Mint(trans *transaction.Transaction, inputData []by
 	}
 	payload.Amount = amount
 
-	// record mint nonce for a certain user
-	signers := make([]string, 0, len(payload.Signatures))
-	for _, sig := range payload.Signatures {
-		signers = append(signers, sig.ID)
+	m := &PendingMint{
+		ID:      trans.Hash,
+		ChainID: payload.ChainID,
+		Nonce:   payload.Nonce,
+		Amount:  payload.Amount,
+		Fee:     share,
+		Signers: signers,
+		Period:  gn.limitPeriod(ctx.GetBlock().Round),
 	}
 
-	ctx.EmitEvent(event.TypeStats, event.TagAddBridgeMint, trans.ClientID, &event.BridgeMint{
-		UserID:    trans.ClientID,
-		MintNonce: payload.Nonce,
-		Amount:    payload.Amount,
-		Signers:   signers,
+	if gn.delayMint(payload.Amount) {
+		// hold the large mint, it's minted and the fee is paid by
+		// release-mints after the delay
+		m.ReleaseRound = ctx.GetBlock().Round + gn.LargeMintDelay
+		err = addPendingMint(trans.ClientID, m, ctx)
+		if err != nil {
+			err = errors.Wrap(err, fmt.Sprintf("%s, delay mint operation, %s", code, info))
+			return
+		}
+	} else {
+		err = zcn.mint(trans.ClientID, m, ctx)
+		if err != nil {
+			err = errors.Wrap(err, fmt.Sprintf("%s, %s", code, info))
+			return
+		}
+	}
+
+	// Save the user node
+	err = gn.Save(ctx)
+	if err != nil {
+		err = errors.Wrap(err, fmt.Sprintf("%s, global node failed to be saved, %s", code, info))
+		return
+	}
+
+	resp = string(payload.Encode())
+	return
+}
+
+// mint the tokens of the mint to the client and pay the fee to a random signer
+func (zcn *ZCNSmartContract) mint(clientID string, m *PendingMint, ctx cstate.StateContextI) error {
+	// record mint nonce for a certain user
+	ctx.EmitEvent(event.TypeStats, event.TagAddBridgeMint, m.ID, &event.BridgeMint{
+		UserID:    clientID,
+		ChainID:   m.ChainID,
+		MintNonce: m.Nonce,
+		Amount:    m.Amount,
+		Signers:   m.Signers,
 	})
 
 	rand.Seed(ctx.GetBlock().GetRoundRandomSeed())
-	sig := payload.Signatures[rand.Intn(len(payload.Signatures))]
+	signer := m.Signers[rand.Intn(len(m.Signers))]
 
-	sp, err := zcn.getStakePool(sig.ID, ctx)
+	sp, err := zcn.getStakePool(signer, ctx)
 	if err != nil {
-		err = errors.Wrap(err, fmt.Sprintf("failed to retrieve stake pool for authorizer %s", sig.ID))
-		return
+		return errors.Wrap(err, fmt.Sprintf("failed to retrieve stake pool for authorizer %s", signer))
 	}
 
-	err = sp.DistributeRewards(share, sig.ID, spenum.Authorizer, spenum.FeeRewardAuthorizer, ctx)
+	err = sp.DistributeRewards(m.Fee, signer, spenum.Authorizer, spenum.FeeRewardAuthorizer, ctx)
 	if err != nil {
-		err = errors.Wrap(err, fmt.Sprintf("failed to retrieve stake pool for authorizer %s", sig.ID))
-		return
+		return errors.Wrap(err, fmt.Sprintf("failed to distribute rewards to authorizer %s", signer))
 	}
 
-	// mint the tokens
 	err = ctx.AddMint(&state.Mint{
 		Minter:     ADDRESS,
-		ToClientID: trans.ClientID,
-		Amount:     payload.Amount,
+		ToClientID: clientID,
+		Amount:     m.Amount,
 	})
 	if err != nil {
-		err = errors.Wrap(err, fmt.Sprintf("%s, Add mint operation, %s", code, info))
-		return
+		return errors.Wrap(err, "Add mint operation")
 	}
 
-	if err = sp.save("", sig.ID, ctx); err != nil {
-		return
-	}
-
-	// Save the user node
-	err = gn.Save(ctx)
-	if err != nil {
-		err = errors.Wrap(err, fmt.Sprintf("%s, global node failed to be saved, %s", code, info))
-		return
-	}
-
-	resp = string(payload.Encode())
-	return
+	return sp.save("", signer, ctx)
 }
@@ -302,6 +302,176 @@ func Test_MintPayloadNonceShouldBeRecordedByUserNode(t *testing.T) {
 	require.Equal(t, payload.Nonce, user.MintNonce)
 }
 
+func Test_MintFromChain(t *testing.T) {
+	ctx := MakeMockStateContext()
+	ctx.globalNode.Chains = map[string]*ChainConfig{
+		"137": {MinMintAmount: 1, MaxMintAmount: 1000, MinBurnAmount: 1},
+	}
+
+	tr := CreateDefaultTransactionToZcnsc()
+	eventDb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		Debug:                 true,
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err = eventDb.Drop()
+		require.NoError(t, err)
+
+		eventDb.Close()
+	})
+
+	ctx.SetEventDb(eventDb)
+
+	contract := CreateZCNSmartContract()
+
+	payload, err := CreateMintPayload(ctx, defaultClient)
+	require.NoError(t, err)
+	payload.ChainID = "137"
+	payload.Signatures, err = createTransactionSignatures(ctx, payload)
+	require.NoError(t, err)
+	_, err = contract.Mint(tr, payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	_, err = contract.Mint(tr, payload.Encode(), ctx)
+	require.ErrorContains(t, err, "has already been minted")
+
+	// the same nonce of another chain
+	payload, err = CreateMintPayload(ctx, defaultClient)
+	require.NoError(t, err)
+	_, err = contract.Mint(tr, payload.Encode(), ctx)
+	require.NoError(t, err)
+
+	// signed for the default chain
+	payload, err = CreateMintPayloadWithNonce(ctx, defaultClient, 3)
+	require.NoError(t, err)
+	payload.ChainID = "137"
+	_, err = contract.Mint(tr, payload.Encode(), ctx)
+	require.ErrorContains(t, err, "failed to verify signature")
+
+	payload, err = CreateMintPayloadWithNonce(ctx, defaultClient, 2)
+	require.NoError(t, err)
+	payload.ChainID = "1"
+	payload.Signatures, err = createTransactionSignatures(ctx, payload)
+	require.NoError(t, err)
+	_, err = contract.Mint(tr, payload.Encode(), ctx)
+	require.ErrorContains(t, err, "unknown chain 1")
+}
+
+func Test_MintWithAggregatedSignature(t *testing.T) {
+	signers := []string{authorizersID[0], authorizersID[2]}
+
+	tt := []struct {
+		name    string
+		prepare func(t *testing.T, ctx *mockStateContext, payload *MintPayload)
+		err     string
+	}{
+		{
+			name: "ok",
+		},
+		{
+			name: "signer without signature",
+			prepare: func(t *testing.T, ctx *mockStateContext, payload *MintPayload) {
+				payload.AggregatedSignature.Signers = "07"
+			},
+			err: "failed to verify aggregated signature",
+		},
+		{
+			name: "unknown signer",
+			prepare: func(t *testing.T, ctx *mockStateContext, payload *MintPayload) {
+				payload.AggregatedSignature.Signers = "0d"
+			},
+			err: "signers bitmap refers to unknown authorizer 3",
+		},
+		{
+			name: "removed signer",
+			prepare: func(t *testing.T, ctx *mockStateContext, payload *MintPayload) {
+				index, err := GetAuthorizerIndex(ctx)
+				require.NoError(t, err)
+				require.True(t, index.Remove(authorizersID[0]))
+				require.NoError(t, index.Save(ctx))
+
+				// the remaining authorizers keep their bits
+				bitmap, err := index.Bitmap(signers[1:])
+				require.NoError(t, err)
+				require.Equal(t, []byte{0x04}, bitmap)
+			},
+			err: "signers bitmap refers to removed authorizer 0",
+		},
+		{
+			name: "not enough signers",
+			prepare: func(t *testing.T, ctx *mockStateContext, payload *MintPayload) {
+				aggregated, err := CreateAggregatedMintPayload(ctx, defaultClient, signers[:1])
+				require.NoError(t, err)
+				payload.AggregatedSignature = aggregated.AggregatedSignature
+			},
+			err: "not enough valid signatures for minting",
+		},
+		{
+			name: "both formats",
+			prepare: func(t *testing.T, ctx *mockStateContext, payload *MintPayload) {
+				var err error
+				payload.Signatures, err = createTransactionSignatures(ctx, payload)
+				require.NoError(t, err)
+			},
+			err: "payload contains both signatures and an aggregated signature",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := MakeMockStateContext()
+			ctx.globalNode.ZCNSConfig.PercentAuthorizers = 0.7
+			ctx.globalNode.ZCNSConfig.MaxFee = 10
+
+			tr := CreateDefaultTransactionToZcnsc()
+			eventDb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+				Debug:                 true,
+				PartitionChangePeriod: 1,
+			})
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				err = eventDb.Drop()
+				require.NoError(t, err)
+
+				eventDb.Close()
+			})
+
+			ctx.SetEventDb(eventDb)
+
+			contract := CreateZCNSmartContract()
+
+			payload, err := CreateAggregatedMintPayload(ctx, defaultClient, signers)
+			require.NoError(t, err)
+			if tc.prepare != nil {
+				tc.prepare(t, ctx, payload)
+			}
+
+			_, err = contract.Mint(tr, payload.Encode(), ctx)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			mm := ctx.GetMints()
+			require.Equal(t, 1, len(mm))
+			require.Equal(t, currency.Coin(195), mm[0].Amount)
+
+			rand.Seed(ctx.GetBlock().GetRoundRandomSeed())
+			signer := signers[rand.Intn(len(signers))]
+
+			stakePool := NewStakePool()
+			err = ctx.GetTrieNode(stakepool.StakePoolKey(spenum.Authorizer, signer), stakePool)
+			require.NoError(t, err)
+			require.Equal(t, currency.Coin(5), stakePool.Reward)
+		})
+	}
+}
+
 func Test_CheckAuthorizerStakePoolDistributedRewards(t *testing.T) {
 	ctx := MakeMockStateContext()
 
@@ -380,7 +550,7 @@ func TestZCNSmartContractMintNonce(t *testing.T) {
 		{
 			name: "add new nonce - ok",
 			initFunc: func(state cstate.StateContextI) {
-				err := PartitionWZCNMintedNonceAdd(state, 1)
+				err := PartitionWZCNMintedNonceAdd(state, "", 1)
 				require.NoError(t, err)
 			},
 			nonce:        2,
@@ -389,7 +559,7 @@ func TestZCNSmartContractMintNonce(t *testing.T) {
 		{
 			name: "add duplicate nonce - fail",
 			initFunc: func(state cstate.StateContextI) {
-				err := PartitionWZCNMintedNonceAdd(state, 1)
+				err := PartitionWZCNMintedNonceAdd(state, "", 1)
 				require.NoError(t, err)
 			},
 			nonce:        1,
@@ -446,7 +616,7 @@ func TestZCNSmartContractMintNonce(t *testing.T) {
 
 			// check that the nonce is saved to the partition by calling the Add and got
 			// error of item already exists
-			err = PartitionWZCNMintedNonceAdd(ctx, tc.nonce)
+			err = PartitionWZCNMintedNonceAdd(ctx, "", tc.nonce)
 			require.True(t, partitions.ErrItemExist(err))
 		})
 	}
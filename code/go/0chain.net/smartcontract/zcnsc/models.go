@@ -1,6 +1,7 @@
 package zcnsc
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -18,22 +19,32 @@ import (
 	"github.com/pkg/errors"
 )
 
-//msgp:ignore MintPayload BurnPayloadResponse BurnPayload AddAuthorizerPayload UpdateAuthorizerStakePoolPayload poolStat AuthorizerSignature TokenLock
+//msgp:ignore MintPayload AggregatedSignature BurnPayloadResponse BurnPayload AddAuthorizerPayload UpdateAuthorizerStakePoolPayload poolStat AuthorizerSignature TokenLock
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
 const (
-	AuthorizerNodeType    = "authnode"
-	AuthorizerNewNodeType = "create"
-	GlobalNodeType        = "globalnode"
-	StakePoolNodeType     = "stakepool"
-	UserNodeType          = "usernode"
-	Porvider              = "provider"
+	AuthorizerNodeType      = "authnode"
+	AuthorizerNewNodeType   = "create"
+	GlobalNodeType          = "globalnode"
+	StakePoolNodeType       = "stakepool"
+	UserNodeType            = "usernode"
+	AuthorizerIndexNodeType = "authorizerindex"
+	Porvider                = "provider"
 )
 
 type (
 	smartContractFunction func(t *transaction.Transaction, inputData []byte, balances cstate.StateContextI) (string, error)
 )
 
+// ChainScoped returns the index of the events of the chain, the events of the
+// default chain are indexed by the id as is
+func ChainScoped(id, chainID string) string {
+	if chainID == "" {
+		return id
+	}
+	return id + ":" + chainID
+}
+
 // -----------  AuthorizerSignature -------------------
 
 type AuthorizerSignature struct {
@@ -41,14 +52,56 @@ type AuthorizerSignature struct {
 	Signature string `json:"signature"`
 }
 
+// ProofOfPossessionHash is the hash an authorizer signs with its key to prove
+// the possession of the key
+func ProofOfPossessionHash(publicKey string) string {
+	return encryption.Hash("zcnsc:proof_of_possession:" + publicKey)
+}
+
+// verifyProofOfPossession checks the signature of ProofOfPossessionHash by
+// the public key, only authorizers with a proven key are indexed for the
+// aggregated signatures
+func verifyProofOfPossession(publicKey, proof string, state cstate.StateContextI) error {
+	if proof == "" {
+		return errors.New("proof of possession of the public key is missing")
+	}
+
+	signatureScheme := state.GetSignatureScheme()
+	if err := signatureScheme.SetPublicKey(publicKey); err != nil {
+		return errors.Wrap(err, "failed to set public key")
+	}
+
+	ok, err := signatureScheme.Verify(proof, ProofOfPossessionHash(publicKey))
+	if err != nil || !ok {
+		return errors.New("invalid proof of possession of the public key")
+	}
+	return nil
+}
+
+// -----------  AggregatedSignature -------------------
+
+// AggregatedSignature is a single BLS signature aggregated from the signatures
+// of the authorizers set in the Signers bitmap, see AuthorizerIndex.Signers
+type AggregatedSignature struct {
+	Signature string `json:"signature"`
+	// Signers is the hex encoded bitmap over the registered authorizers
+	Signers string `json:"signers"`
+}
+
 // -----------  MintPayload -------------------
 
 type MintPayload struct {
-	EthereumTxnID     string                 `json:"ethereum_txn_id"`
-	Amount            currency.Coin          `json:"amount"`
-	Nonce             int64                  `json:"nonce"`
-	Signatures        []*AuthorizerSignature `json:"signatures"`
-	ReceivingClientID string                 `json:"receiving_client_id"`
+	// ChainID is the chain the tokens are burned on, empty for the default
+	// chain of the bridge
+	ChainID       string                 `json:"chain_id,omitempty"`
+	EthereumTxnID string                 `json:"ethereum_txn_id"`
+	Amount        currency.Coin          `json:"amount"`
+	Nonce         int64                  `json:"nonce"`
+	Signatures    []*AuthorizerSignature `json:"signatures"`
+	// AggregatedSignature replaces the Signatures of the authorizers, the
+	// payload carries either of them
+	AggregatedSignature *AggregatedSignature `json:"aggregated_signature,omitempty"`
+	ReceivingClientID   string               `json:"receiving_client_id"`
 }
 
 func (mp *MintPayload) Encode() []byte {
@@ -58,11 +111,13 @@ func (mp *MintPayload) Encode() []byte {
 
 func (mp *MintPayload) Decode(input []byte) error {
 	const (
+		fieldChainId           = "chain_id"
 		fieldEthereumTxnId     = "ethereum_txn_id"
 		fieldNonce             = "nonce"
 		fieldAmount            = "amount"
 		fieldReceivingClientId = "receiving_client_id"
 		fieldSignatures        = "signatures"
+		fieldAggregated        = "aggregated_signature"
 	)
 
 	var objMap map[string]*json.RawMessage
@@ -71,7 +126,20 @@ func (mp *MintPayload) Decode(input []byte) error {
 		return err
 	}
 
-	id, ok := objMap[fieldEthereumTxnId]
+	id, ok := objMap[fieldChainId]
+	if ok {
+		if id == nil {
+			return errors.New("chain_id is missing in the payload")
+		}
+		var value *string
+		err = json.Unmarshal(*id, &value)
+		if err != nil {
+			return err
+		}
+		mp.ChainID = *value
+	}
+
+	id, ok = objMap[fieldEthereumTxnId]
 	if ok {
 		if id == nil {
 			return errors.New("ethereum_txn_id is missing in the payload")
@@ -123,8 +191,19 @@ func (mp *MintPayload) Decode(input []byte) error {
 		mp.ReceivingClientID = *value
 	}
 
+	// the signatures are null in the payloads carrying an aggregated signature
+	aggregated, ok := objMap[fieldAggregated]
+	if ok && aggregated != nil {
+		sig := &AggregatedSignature{}
+		err = json.Unmarshal(*aggregated, sig)
+		if err != nil {
+			return err
+		}
+		mp.AggregatedSignature = sig
+	}
+
 	id, ok = objMap[fieldSignatures]
-	if ok {
+	if ok && (id != nil || mp.AggregatedSignature == nil) {
 		if id == nil {
 			return errors.New("signatures entry is missing in payload")
 		}
@@ -147,8 +226,13 @@ func (mp *MintPayload) Decode(input []byte) error {
 	return err
 }
 
+// GetStringToSign of the payload, the chain is signed to make the mints of
+// one chain not valid for another one
 func (mp *MintPayload) GetStringToSign() string {
-	return encryption.Hash(fmt.Sprintf("%v:%v:%v:%v", mp.EthereumTxnID, mp.Amount, mp.Nonce, mp.ReceivingClientID))
+	if mp.ChainID == "" {
+		return encryption.Hash(fmt.Sprintf("%v:%v:%v:%v", mp.EthereumTxnID, mp.Amount, mp.Nonce, mp.ReceivingClientID))
+	}
+	return encryption.Hash(fmt.Sprintf("%v:%v:%v:%v:%v", mp.ChainID, mp.EthereumTxnID, mp.Amount, mp.Nonce, mp.ReceivingClientID))
 }
 
 func (mp *MintPayload) verifySignatures(signatures []*AuthorizerSignature, state cstate.StateContextI) error {
@@ -179,14 +263,64 @@ func (mp *MintPayload) verifySignatures(signatures []*AuthorizerSignature, state
 		}
 
 		ok, err := signatureScheme.Verify(v.Signature, toSign)
-		if !ok || err != nil {
+		if err != nil {
 			return errors.Wrap(err, "failed to verify signature")
 		}
+		if !ok {
+			return errors.Errorf("failed to verify signature of authorizer %s", authorizerID)
+		}
 	}
 
 	return nil
 }
 
+// verifyAggregatedSignature verifies the aggregated signature of the payload
+// against the public keys of the authorizers in the signers bitmap with a
+// single pairing check and returns the signers
+func (mp *MintPayload) verifyAggregatedSignature(state cstate.StateContextI) ([]string, error) {
+	if _, ok := state.GetSignatureScheme().(*encryption.BLS0ChainScheme); !ok {
+		return nil, errors.New("aggregated signatures require the bls0chain signature scheme")
+	}
+
+	bitmap, err := hex.DecodeString(mp.AggregatedSignature.Signers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode signers bitmap")
+	}
+
+	index, err := GetAuthorizerIndex(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get authorizer index")
+	}
+
+	signers, err := index.Signers(bitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeys := make([]string, 0, len(signers))
+	for _, authorizerID := range signers {
+		node, err := GetAuthorizerNode(authorizerID, state)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find authorizer by ID: %s", authorizerID)
+		}
+
+		if node.PublicKey == "" {
+			return nil, errors.New("authorizer public key is empty")
+		}
+		publicKeys = append(publicKeys, node.PublicKey)
+	}
+
+	ok, err := encryption.BLS0ChainVerifyAggregatedHash(publicKeys, mp.AggregatedSignature.Signature, mp.GetStringToSign())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to verify aggregated signature")
+	}
+	if !ok {
+		return nil, errors.New("failed to verify aggregated signature")
+	}
+
+	return signers, nil
+}
+
 func (mp *MintPayload) getUniqueSignatures() []*AuthorizerSignature {
 	sigsMap := sortedmap.New[string, *AuthorizerSignature]()
 	for i, v := range mp.Signatures {
@@ -203,6 +337,7 @@ type BurnPayloadResponse struct {
 	Nonce           int64         `json:"nonce"`
 	Amount          currency.Coin `json:"amount"`
 	EthereumAddress string        `json:"ethereum_address"`
+	ChainID         string        `json:"chain_id,omitempty"`
 }
 
 func (bp *BurnPayloadResponse) Encode() []byte {
@@ -219,6 +354,9 @@ func (bp *BurnPayloadResponse) Decode(input []byte) error {
 
 type BurnPayload struct {
 	EthereumAddress string `json:"ethereum_address"`
+	// ChainID is the chain to mint the tokens on, empty for the default
+	// chain of the bridge
+	ChainID string `json:"chain_id,omitempty"`
 }
 
 func (bp *BurnPayload) Encode() []byte {
@@ -257,7 +395,10 @@ func (pk *UpdateAuthorizerStakePoolPayload) Decode(input []byte) error {
 //		ServiceCharge float64
 //	}
 type AddAuthorizerPayload struct {
-	PublicKey         string             `json:"public_key"`
+	PublicKey string `json:"public_key"`
+	// ProofOfPossession is the signature of ProofOfPossessionHash by the key,
+	// it rules out rogue keys in the aggregated mint signatures
+	ProofOfPossession string             `json:"proof_of_possession"`
 	URL               string             `json:"url"`
 	StakePoolSettings stakepool.Settings `json:"stake_pool_settings"` // Used to initially create stake pool
 }
@@ -346,6 +487,9 @@ func (dap *DeleteAuthorizerPayload) Decode(input []byte) error {
 
 type AuthorizerHealthCheckPayload struct {
 	ID string `json:"id"`
+	// ProofOfPossession indexes an authorizer registered before the signer
+	// bitmaps, see AddAuthorizerPayload.ProofOfPossession
+	ProofOfPossession string `json:"proof_of_possession,omitempty"`
 }
 
 func (ahp *AuthorizerHealthCheckPayload) Encode() (data []byte, err error) {
@@ -61,10 +61,13 @@ func (z AuthCount) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z AuthorizerHealthCheckPayload) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 2
 	// string "ID"
-	o = append(o, 0x81, 0xa2, 0x49, 0x44)
+	o = append(o, 0x82, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
+	// string "ProofOfPossession"
+	o = append(o, 0xb1, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x4f, 0x66, 0x50, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.ProofOfPossession)
 	return
 }
 
@@ -92,6 +95,12 @@ func (z *AuthorizerHealthCheckPayload) UnmarshalMsg(bts []byte) (o []byte, err e
 				err = msgp.WrapError(err, "ID")
 				return
 			}
+		case "ProofOfPossession":
+			z.ProofOfPossession, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ProofOfPossession")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -106,7 +115,7 @@ func (z *AuthorizerHealthCheckPayload) UnmarshalMsg(bts []byte) (o []byte, err e
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z AuthorizerHealthCheckPayload) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID)
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 18 + msgp.StringPrefixSize + len(z.ProofOfPossession)
 	return
 }
 
@@ -42,6 +42,69 @@ type ZCNSConfig struct {
 	Cost                map[string]int `json:"cost"`
 	MaxDelegates        int            `json:"max_delegates"`       // MaxDelegates per stake pool
 	HealthCheckPeriod   time.Duration  `json:"health_check_period"` // MaxDelegates per stake pool
+	// Chains the bridge mints from and burns to other than the default one,
+	// the burns and the mints of the chains not listed are rejected
+	Chains map[string]*ChainConfig `json:"chains,omitempty"`
+	// GuardianID is the client allowed to pause the bridge and to cancel the
+	// delayed mints besides the owner
+	GuardianID string `json:"guardian_id,omitempty"`
+	// Paused bridge rejects the mints, the burns and the release of the
+	// delayed mints
+	Paused bool `json:"paused"`
+	// LimitPeriod is the number of rounds the amounts minted and burned are
+	// capped for, zero caps are not limited
+	LimitPeriod          int64         `json:"limit_period"`
+	MaxMintPerPeriod     currency.Coin `json:"max_mint_per_period"`
+	MaxBurnPerPeriod     currency.Coin `json:"max_burn_per_period"`
+	MaxUserMintPerPeriod currency.Coin `json:"max_user_mint_per_period"`
+	MaxUserBurnPerPeriod currency.Coin `json:"max_user_burn_per_period"`
+	// LargeMintThreshold is the amount the mints are held from for
+	// LargeMintDelay rounds before they can be released, zero disables it
+	LargeMintThreshold currency.Coin `json:"large_mint_threshold"`
+	LargeMintDelay     int64         `json:"large_mint_delay"`
+}
+
+// ChainConfig limits the amounts of the bridge to a chain, zero max amounts
+// are not limited
+type ChainConfig struct {
+	MinMintAmount currency.Coin `json:"min_mint"`
+	MaxMintAmount currency.Coin `json:"max_mint"`
+	MinBurnAmount currency.Coin `json:"min_burn"`
+	MaxBurnAmount currency.Coin `json:"max_burn"`
+}
+
+func (cc *ChainConfig) validate() error {
+	switch {
+	case cc.MinMintAmount < 1:
+		return fmt.Errorf("min mint amount (%v) is less than 1", cc.MinMintAmount)
+	case cc.MinBurnAmount < 1:
+		return fmt.Errorf("min burn amount (%v) is less than 1", cc.MinBurnAmount)
+	case cc.MaxMintAmount > 0 && cc.MaxMintAmount < cc.MinMintAmount:
+		return fmt.Errorf("max mint amount (%v) is less than min mint amount", cc.MaxMintAmount)
+	case cc.MaxBurnAmount > 0 && cc.MaxBurnAmount < cc.MinBurnAmount:
+		return fmt.Errorf("max burn amount (%v) is less than min burn amount", cc.MaxBurnAmount)
+	}
+	return nil
+}
+
+func (cc *ChainConfig) checkMint(amount currency.Coin) error {
+	switch {
+	case amount < cc.MinMintAmount:
+		return fmt.Errorf("amount requested (%v) is lower than min amount for mint (%v)", amount, cc.MinMintAmount)
+	case cc.MaxMintAmount > 0 && amount > cc.MaxMintAmount:
+		return fmt.Errorf("amount requested (%v) is greater than max amount for mint (%v)", amount, cc.MaxMintAmount)
+	}
+	return nil
+}
+
+func (cc *ChainConfig) checkBurn(amount currency.Coin) error {
+	switch {
+	case amount < cc.MinBurnAmount:
+		return fmt.Errorf("amount (value) requested (%v) is lower than min burn amount (%v)", amount, cc.MinBurnAmount)
+	case cc.MaxBurnAmount > 0 && amount > cc.MaxBurnAmount:
+		return fmt.Errorf("amount (value) requested (%v) is greater than max burn amount (%v)", amount, cc.MaxBurnAmount)
+	}
+	return nil
 }
 
 type GlobalNode struct {
@@ -49,8 +112,30 @@ type GlobalNode struct {
 	ID          string `json:"id"`
 }
 
+// chain returns the limits of the chain, the default chain of the bridge is
+// limited by the min mint and burn amounts of the SC
+func (gn *GlobalNode) chain(chainID string) (*ChainConfig, error) {
+	if chainID == "" {
+		return &ChainConfig{
+			MinMintAmount: gn.MinMintAmount,
+			MinBurnAmount: gn.MinBurnAmount,
+		}, nil
+	}
+	cc, ok := gn.Chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain %s", chainID)
+	}
+	return cc, nil
+}
+
 func (gn *GlobalNode) UpdateConfig(cfg *config.StringMap) (err error) {
 	for key, value := range cfg.Fields {
+		if strings.HasPrefix(key, Chains+".") {
+			if err = gn.setChainValue(key, value); err != nil {
+				return err
+			}
+			continue
+		}
 		switch key {
 		case MinMintAmount:
 			amount, err := strconv.ParseFloat(value, 64)
@@ -145,6 +230,27 @@ func (gn *GlobalNode) UpdateConfig(cfg *config.StringMap) (err error) {
 				return fmt.Errorf("cannot convert key %s value %v to duration: %v", key, value, err)
 			}
 			gn.HealthCheckPeriod = v
+		case GuardianID:
+			gn.GuardianID = value
+		case Paused:
+			gn.Paused, err = strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to bool", key, value)
+			}
+		case LimitPeriod:
+			gn.LimitPeriod, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
+			}
+		case LargeMintDelay:
+			gn.LargeMintDelay, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
+			}
+		case MaxMintPerPeriod, MaxBurnPerPeriod, MaxUserMintPerPeriod, MaxUserBurnPerPeriod, LargeMintThreshold:
+			if err = gn.setLimitValue(key, value); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
 		}
@@ -180,6 +286,75 @@ func (gn *GlobalNode) setCostValue(key, value string) error {
 	return fmt.Errorf("cost config setting %s not found", costKey)
 }
 
+// setLimitValue sets an amount the bridge is limited by, the value is in ZCN
+func (gn *GlobalNode) setLimitValue(key, value string) error {
+	zcn, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
+	}
+	amount, err := currency.ParseZCN(zcn)
+	if err != nil {
+		return err
+	}
+
+	switch key {
+	case MaxMintPerPeriod:
+		gn.MaxMintPerPeriod = amount
+	case MaxBurnPerPeriod:
+		gn.MaxBurnPerPeriod = amount
+	case MaxUserMintPerPeriod:
+		gn.MaxUserMintPerPeriod = amount
+	case MaxUserBurnPerPeriod:
+		gn.MaxUserBurnPerPeriod = amount
+	case LargeMintThreshold:
+		gn.LargeMintThreshold = amount
+	default:
+		return fmt.Errorf("key %s not recognised as setting", key)
+	}
+	return nil
+}
+
+// setChainValue sets a limit of a chain, the chain is added if it's not
+// bridged yet; the key is chains.<chain id>.<setting>
+func (gn *GlobalNode) setChainValue(key, value string) error {
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return fmt.Errorf("key %s not recognised as setting", key)
+	}
+
+	zcn, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
+	}
+	amount, err := currency.ParseZCN(zcn)
+	if err != nil {
+		return err
+	}
+
+	cc, ok := gn.Chains[parts[1]]
+	if !ok {
+		cc = new(ChainConfig)
+	}
+	switch parts[2] {
+	case MinMintAmount:
+		cc.MinMintAmount = amount
+	case MaxMintAmount:
+		cc.MaxMintAmount = amount
+	case MinBurnAmount:
+		cc.MinBurnAmount = amount
+	case MaxBurnAmount:
+		cc.MaxBurnAmount = amount
+	default:
+		return fmt.Errorf("key %s not recognised as setting", key)
+	}
+
+	if gn.Chains == nil {
+		gn.Chains = make(map[string]*ChainConfig)
+	}
+	gn.Chains[parts[1]] = cc
+	return nil
+}
+
 func (gn *GlobalNode) Validate() error {
 	const (
 		Code = "failed to validate global node"
@@ -210,6 +385,18 @@ func (gn *GlobalNode) Validate() error {
 		return common.NewError(Code, fmt.Sprintf("health check period (%v) is less than 0", gn.HealthCheckPeriod))
 	case gn.MinLockAmount == 0:
 		return common.NewError(Code, fmt.Sprintf("min lock amount (%v) is equal to 0", gn.MinLockAmount))
+	case gn.LimitPeriod < 0:
+		return common.NewError(Code, fmt.Sprintf("limit period (%v) is less than 0", gn.LimitPeriod))
+	case gn.LimitPeriod == 0 && (gn.MaxMintPerPeriod > 0 || gn.MaxBurnPerPeriod > 0 ||
+		gn.MaxUserMintPerPeriod > 0 || gn.MaxUserBurnPerPeriod > 0):
+		return common.NewError(Code, "limit period is required for the mint and burn caps")
+	case gn.LargeMintDelay < 0:
+		return common.NewError(Code, fmt.Sprintf("large mint delay (%v) is less than 0", gn.LargeMintDelay))
+	}
+	for id, cc := range gn.Chains {
+		if err := cc.validate(); err != nil {
+			return common.NewError(Code, fmt.Sprintf("chain %s: %v", id, err))
+		}
 	}
 	return nil
 }
@@ -376,11 +563,110 @@ func AuthorizerFromEvent(ev *event.Authorizer) (*AuthorizerNode, error) {
 	return NewAuthorizer(ev.ID, "", ev.URL), nil
 }
 
+// ----- AuthorizerIndex ------------------
+
+// AuthorizerIndex keeps the registered authorizers in a stable order, the
+// signer bitmaps of the aggregated mint signatures refer to this order
+type AuthorizerIndex struct {
+	IDs []string `json:"ids"`
+}
+
+func (ai *AuthorizerIndex) GetKey() datastore.Key {
+	return fmt.Sprintf("%s:%s", ADDRESS, AuthorizerIndexNodeType)
+}
+
+// Add appends the authorizer to the index, it's a no-op for an already
+// indexed authorizer
+func (ai *AuthorizerIndex) Add(id string) bool {
+	for _, v := range ai.IDs {
+		if v == id {
+			return false
+		}
+	}
+	ai.IDs = append(ai.IDs, id)
+	return true
+}
+
+// Remove takes the authorizer out of the index, its position is left empty
+// so that the bits of the other authorizers never change
+func (ai *AuthorizerIndex) Remove(id string) bool {
+	if id == "" {
+		return false
+	}
+	for i, v := range ai.IDs {
+		if v == id {
+			ai.IDs[i] = ""
+			return true
+		}
+	}
+	return false
+}
+
+// Signers returns the authorizers whose bits are set in the bitmap, bit i of
+// the bitmap is the (i % 8)-th lowest bit of the byte i / 8
+func (ai *AuthorizerIndex) Signers(bitmap []byte) ([]string, error) {
+	if len(bitmap) > (len(ai.IDs)+7)/8 {
+		return nil, fmt.Errorf("signers bitmap of %d bytes is longer than the %d registered authorizers",
+			len(bitmap), len(ai.IDs))
+	}
+
+	var signers []string
+	for i := range bitmap {
+		for bit := 0; bit < 8; bit++ {
+			if bitmap[i]&(1<<bit) == 0 {
+				continue
+			}
+			idx := i*8 + bit
+			if idx >= len(ai.IDs) {
+				return nil, fmt.Errorf("signers bitmap refers to unknown authorizer %d", idx)
+			}
+			if ai.IDs[idx] == "" {
+				return nil, fmt.Errorf("signers bitmap refers to removed authorizer %d", idx)
+			}
+			signers = append(signers, ai.IDs[idx])
+		}
+	}
+
+	if len(signers) == 0 {
+		return nil, errors.New("signers bitmap is empty")
+	}
+	return signers, nil
+}
+
+// Bitmap returns the signers bitmap of the given authorizers
+func (ai *AuthorizerIndex) Bitmap(ids []string) ([]byte, error) {
+	bitmap := make([]byte, (len(ai.IDs)+7)/8)
+	for _, id := range ids {
+		if id == "" {
+			return nil, errors.New("empty authorizer id")
+		}
+		idx := -1
+		for i, v := range ai.IDs {
+			if v == id {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("authorizer %s is not indexed", id)
+		}
+		bitmap[idx/8] |= 1 << (idx % 8)
+	}
+	return bitmap, nil
+}
+
+func (ai *AuthorizerIndex) Save(balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(ai.GetKey(), ai)
+	return
+}
+
 // ----- UserNode ------------------
 
 type UserNode struct {
 	ID        string `json:"id"`
 	BurnNonce int64  `json:"burn_nonce"`
+	// BurnNonces of the chains other than the default chain of the bridge
+	BurnNonces map[string]int64 `json:"burn_nonces,omitempty"`
 }
 
 func NewUserNode(id string) *UserNode {
@@ -389,6 +675,19 @@ func NewUserNode(id string) *UserNode {
 	}
 }
 
+// nextBurnNonce increases the burn nonce of the chain and returns it
+func (un *UserNode) nextBurnNonce(chainID string) int64 {
+	if chainID == "" {
+		un.BurnNonce++
+		return un.BurnNonce
+	}
+	if un.BurnNonces == nil {
+		un.BurnNonces = make(map[string]int64)
+	}
+	un.BurnNonces[chainID]++
+	return un.BurnNonces[chainID]
+}
+
 func (un *UserNode) GetKey() datastore.Key {
 	return fmt.Sprintf("%s:%s:%s", ADDRESS, UserNodeType, un.ID)
 }
@@ -62,6 +62,77 @@ func (z *AuthorizerConfig) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *AuthorizerIndex) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "IDs"
+	o = append(o, 0x81, 0xa3, 0x49, 0x44, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.IDs)))
+	for za0001 := range z.IDs {
+		o = msgp.AppendString(o, z.IDs[za0001])
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AuthorizerIndex) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "IDs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IDs")
+				return
+			}
+			if cap(z.IDs) >= int(zb0002) {
+				z.IDs = (z.IDs)[:zb0002]
+			} else {
+				z.IDs = make([]string, zb0002)
+			}
+			for za0001 := range z.IDs {
+				z.IDs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "IDs", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AuthorizerIndex) Msgsize() (s int) {
+	s = 1 + 4 + msgp.ArrayHeaderSize
+	for za0001 := range z.IDs {
+		s += msgp.StringPrefixSize + len(z.IDs[za0001])
+	}
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *AuthorizerNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -209,6 +280,101 @@ func (z *AuthorizerNode) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *ChainConfig) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "MinMintAmount"
+	o = append(o, 0x84, 0xad, 0x4d, 0x69, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.MinMintAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MinMintAmount")
+		return
+	}
+	// string "MaxMintAmount"
+	o = append(o, 0xad, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.MaxMintAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxMintAmount")
+		return
+	}
+	// string "MinBurnAmount"
+	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.MinBurnAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MinBurnAmount")
+		return
+	}
+	// string "MaxBurnAmount"
+	o = append(o, 0xad, 0x4d, 0x61, 0x78, 0x42, 0x75, 0x72, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.MaxBurnAmount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxBurnAmount")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ChainConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "MinMintAmount":
+			bts, err = z.MinMintAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinMintAmount")
+				return
+			}
+		case "MaxMintAmount":
+			bts, err = z.MaxMintAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxMintAmount")
+				return
+			}
+		case "MinBurnAmount":
+			bts, err = z.MinBurnAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinBurnAmount")
+				return
+			}
+		case "MaxBurnAmount":
+			bts, err = z.MaxBurnAmount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxBurnAmount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ChainConfig) Msgsize() (s int) {
+	s = 1 + 14 + z.MinMintAmount.Msgsize() + 14 + z.MaxMintAmount.Msgsize() + 14 + z.MinBurnAmount.Msgsize() + 14 + z.MaxBurnAmount.Msgsize()
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -296,15 +462,28 @@ func (z *GlobalNode) Msgsize() (s int) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z UserNode) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *UserNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 3
 	// string "ID"
-	o = append(o, 0x82, 0xa2, 0x49, 0x44)
+	o = append(o, 0x83, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "BurnNonce"
 	o = append(o, 0xa9, 0x42, 0x75, 0x72, 0x6e, 0x4e, 0x6f, 0x6e, 0x63, 0x65)
 	o = msgp.AppendInt64(o, z.BurnNonce)
+	// string "BurnNonces"
+	o = append(o, 0xaa, 0x42, 0x75, 0x72, 0x6e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.BurnNonces)))
+	keys_za0001 := make([]string, 0, len(z.BurnNonces))
+	for k := range z.BurnNonces {
+		keys_za0001 = append(keys_za0001, k)
+	}
+	msgp.Sort(keys_za0001)
+	for _, k := range keys_za0001 {
+		za0002 := z.BurnNonces[k]
+		o = msgp.AppendString(o, k)
+		o = msgp.AppendInt64(o, za0002)
+	}
 	return
 }
 
@@ -338,6 +517,36 @@ func (z *UserNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "BurnNonce")
 				return
 			}
+		case "BurnNonces":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BurnNonces")
+				return
+			}
+			if z.BurnNonces == nil {
+				z.BurnNonces = make(map[string]int64, zb0002)
+			} else if len(z.BurnNonces) > 0 {
+				for key := range z.BurnNonces {
+					delete(z.BurnNonces, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 int64
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "BurnNonces")
+					return
+				}
+				za0002, bts, err = msgp.ReadInt64Bytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "BurnNonces", za0001)
+					return
+				}
+				z.BurnNonces[za0001] = za0002
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -351,17 +560,23 @@ func (z *UserNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z UserNode) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 10 + msgp.Int64Size
+func (z *UserNode) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 10 + msgp.Int64Size + 11 + msgp.MapHeaderSize
+	if z.BurnNonces != nil {
+		for za0001, za0002 := range z.BurnNonces {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.Int64Size
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *ZCNSConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 14
+	// map header, size 24
 	// string "MinMintAmount"
-	o = append(o, 0x8e, 0xad, 0x4d, 0x69, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o = append(o, 0xde, 0x0, 0x18, 0xad, 0x4d, 0x69, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
 	o, err = z.MinMintAmount.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "MinMintAmount")
@@ -440,6 +655,74 @@ func (z *ZCNSConfig) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "HealthCheckPeriod"
 	o = append(o, 0xb1, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
 	o = msgp.AppendDuration(o, z.HealthCheckPeriod)
+	// string "Chains"
+	o = append(o, 0xa6, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Chains)))
+	keys_za0003 := make([]string, 0, len(z.Chains))
+	for k := range z.Chains {
+		keys_za0003 = append(keys_za0003, k)
+	}
+	msgp.Sort(keys_za0003)
+	for _, k := range keys_za0003 {
+		za0004 := z.Chains[k]
+		o = msgp.AppendString(o, k)
+		if za0004 == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = za0004.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Chains", k)
+				return
+			}
+		}
+	}
+	// string "GuardianID"
+	o = append(o, 0xaa, 0x47, 0x75, 0x61, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.GuardianID)
+	// string "Paused"
+	o = append(o, 0xa6, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Paused)
+	// string "LimitPeriod"
+	o = append(o, 0xab, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt64(o, z.LimitPeriod)
+	// string "MaxMintPerPeriod"
+	o = append(o, 0xb0, 0x4d, 0x61, 0x78, 0x4d, 0x69, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o, err = z.MaxMintPerPeriod.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxMintPerPeriod")
+		return
+	}
+	// string "MaxBurnPerPeriod"
+	o = append(o, 0xb0, 0x4d, 0x61, 0x78, 0x42, 0x75, 0x72, 0x6e, 0x50, 0x65, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o, err = z.MaxBurnPerPeriod.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxBurnPerPeriod")
+		return
+	}
+	// string "MaxUserMintPerPeriod"
+	o = append(o, 0xb4, 0x4d, 0x61, 0x78, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x69, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o, err = z.MaxUserMintPerPeriod.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxUserMintPerPeriod")
+		return
+	}
+	// string "MaxUserBurnPerPeriod"
+	o = append(o, 0xb4, 0x4d, 0x61, 0x78, 0x55, 0x73, 0x65, 0x72, 0x42, 0x75, 0x72, 0x6e, 0x50, 0x65, 0x72, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o, err = z.MaxUserBurnPerPeriod.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxUserBurnPerPeriod")
+		return
+	}
+	// string "LargeMintThreshold"
+	o = append(o, 0xb2, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x4d, 0x69, 0x6e, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64)
+	o, err = z.LargeMintThreshold.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "LargeMintThreshold")
+		return
+	}
+	// string "LargeMintDelay"
+	o = append(o, 0xae, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x4d, 0x69, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79)
+	o = msgp.AppendInt64(o, z.LargeMintDelay)
 	return
 }
 
@@ -569,6 +852,101 @@ func (z *ZCNSConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "HealthCheckPeriod")
 				return
 			}
+		case "Chains":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Chains")
+				return
+			}
+			if z.Chains == nil {
+				z.Chains = make(map[string]*ChainConfig, zb0003)
+			} else if len(z.Chains) > 0 {
+				for key := range z.Chains {
+					delete(z.Chains, key)
+				}
+			}
+			for zb0003 > 0 {
+				var za0003 string
+				var za0004 *ChainConfig
+				zb0003--
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Chains")
+					return
+				}
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					za0004 = nil
+				} else {
+					if za0004 == nil {
+						za0004 = new(ChainConfig)
+					}
+					bts, err = za0004.UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Chains", za0003)
+						return
+					}
+				}
+				z.Chains[za0003] = za0004
+			}
+		case "GuardianID":
+			z.GuardianID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "GuardianID")
+				return
+			}
+		case "Paused":
+			z.Paused, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Paused")
+				return
+			}
+		case "LimitPeriod":
+			z.LimitPeriod, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LimitPeriod")
+				return
+			}
+		case "MaxMintPerPeriod":
+			bts, err = z.MaxMintPerPeriod.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxMintPerPeriod")
+				return
+			}
+		case "MaxBurnPerPeriod":
+			bts, err = z.MaxBurnPerPeriod.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxBurnPerPeriod")
+				return
+			}
+		case "MaxUserMintPerPeriod":
+			bts, err = z.MaxUserMintPerPeriod.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxUserMintPerPeriod")
+				return
+			}
+		case "MaxUserBurnPerPeriod":
+			bts, err = z.MaxUserBurnPerPeriod.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxUserBurnPerPeriod")
+				return
+			}
+		case "LargeMintThreshold":
+			bts, err = z.LargeMintThreshold.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LargeMintThreshold")
+				return
+			}
+		case "LargeMintDelay":
+			z.LargeMintDelay, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LargeMintDelay")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -583,13 +961,25 @@ func (z *ZCNSConfig) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *ZCNSConfig) Msgsize() (s int) {
-	s = 1 + 14 + z.MinMintAmount.Msgsize() + 14 + z.MinBurnAmount.Msgsize() + 15 + z.MinStakeAmount.Msgsize() + 20 + z.MinStakePerDelegate.Msgsize() + 15 + z.MaxStakeAmount.Msgsize() + 14 + z.MinLockAmount.Msgsize() + 15 + msgp.Int64Size + 19 + msgp.Float64Size + 7 + z.MaxFee.Msgsize() + 12 + msgp.StringPrefixSize + len(z.BurnAddress) + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
+	s = 3 + 14 + z.MinMintAmount.Msgsize() + 14 + z.MinBurnAmount.Msgsize() + 15 + z.MinStakeAmount.Msgsize() + 20 + z.MinStakePerDelegate.Msgsize() + 15 + z.MaxStakeAmount.Msgsize() + 14 + z.MinLockAmount.Msgsize() + 15 + msgp.Int64Size + 19 + msgp.Float64Size + 7 + z.MaxFee.Msgsize() + 12 + msgp.StringPrefixSize + len(z.BurnAddress) + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
 			_ = za0002
 			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
 		}
 	}
-	s += 13 + msgp.IntSize + 18 + msgp.DurationSize
+	s += 13 + msgp.IntSize + 18 + msgp.DurationSize + 7 + msgp.MapHeaderSize
+	if z.Chains != nil {
+		for za0003, za0004 := range z.Chains {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003)
+			if za0004 == nil {
+				s += msgp.NilSize
+			} else {
+				s += za0004.Msgsize()
+			}
+		}
+	}
+	s += 11 + msgp.StringPrefixSize + len(z.GuardianID) + 7 + msgp.BoolSize + 12 + msgp.Int64Size + 17 + z.MaxMintPerPeriod.Msgsize() + 17 + z.MaxBurnPerPeriod.Msgsize() + 21 + z.MaxUserMintPerPeriod.Msgsize() + 21 + z.MaxUserBurnPerPeriod.Msgsize() + 19 + z.LargeMintThreshold.Msgsize() + 15 + msgp.Int64Size
 	return
 }
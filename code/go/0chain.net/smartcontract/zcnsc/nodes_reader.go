@@ -66,3 +66,15 @@ func GetGlobalSavedNode(ctx state.CommonStateContextI) (*GlobalNode, error) {
 		return nil, err
 	}
 }
+
+// GetAuthorizerIndex returns an empty index if the node is not found
+func GetAuthorizerIndex(ctx state.StateContextI) (*AuthorizerIndex, error) {
+	node := &AuthorizerIndex{}
+	err := ctx.GetTrieNode(node.GetKey(), node)
+	switch err {
+	case nil, util.ErrValueNotPresent:
+		return node, nil
+	default:
+		return nil, err
+	}
+}
@@ -24,12 +24,18 @@ func (wzcn *WZCNMintedNonce) GetID() string {
 	return strconv.FormatInt(wzcn.Nonce, 10)
 }
 
-func partitionWZCNMintedNonce(state state.StateContextI) (*partitions.Partitions, error) {
-	return partitions.CreateIfNotExists(state, wzcnMintedNoncePartitionName, wzcnMintedNoncePartitionSize)
+// partitionWZCNMintedNonce of the chain, the nonces of every chain are
+// kept apart not to reject the mints of a chain for the nonces of another one
+func partitionWZCNMintedNonce(state state.StateContextI, chainID string) (*partitions.Partitions, error) {
+	name := wzcnMintedNoncePartitionName
+	if chainID != "" {
+		name = encryption.Hash(ADDRESS + ":wzcn_minted_nonce_partition:" + chainID)
+	}
+	return partitions.CreateIfNotExists(state, name, wzcnMintedNoncePartitionSize)
 }
 
-func PartitionWZCNMintedNonceAdd(state state.StateContextI, nonce int64) error {
-	p, err := partitionWZCNMintedNonce(state)
+func PartitionWZCNMintedNonceAdd(state state.StateContextI, chainID string, nonce int64) error {
+	p, err := partitionWZCNMintedNonce(state, chainID)
 	if err != nil {
 		return err
 	}
@@ -28,6 +28,11 @@ const (
 	DeleteFromDelegatePoolFunc    = "delete-from-delegate-pool"
 	UpdateAuthorizerStakePoolFunc = "update-authorizer-stake-pool"
 	CollectRewardsFunc            = "collect-rewards"
+	DelegatePoolAutoCompoundFunc  = "delegate-pool-auto-compound"
+	PauseBridgeFunc               = "pause-bridge"
+	UnpauseBridgeFunc             = "unpause-bridge"
+	CancelPendingMintFunc         = "cancel-pending-mint"
+	ReleaseMintsFunc              = "release-mints"
 )
 
 // ZCNSmartContract ...
@@ -55,6 +60,11 @@ func (zcn *ZCNSmartContract) InitSC() {
 	// Bridge related
 	zcn.smartContractFunctions[MintFunc] = zcn.Mint
 	zcn.smartContractFunctions[BurnFunc] = zcn.Burn
+	zcn.smartContractFunctions[ReleaseMintsFunc] = zcn.ReleaseMints
+	// Guardian
+	zcn.smartContractFunctions[PauseBridgeFunc] = zcn.PauseBridge
+	zcn.smartContractFunctions[UnpauseBridgeFunc] = zcn.UnpauseBridge
+	zcn.smartContractFunctions[CancelPendingMintFunc] = zcn.CancelPendingMint
 	// Authorizer
 	zcn.smartContractFunctions[AddAuthorizerFunc] = zcn.AddAuthorizer
 	zcn.smartContractFunctions[DeleteAuthorizerFunc] = zcn.DeleteAuthorizer
@@ -66,6 +76,7 @@ func (zcn *ZCNSmartContract) InitSC() {
 	zcn.smartContractFunctions[CollectRewardsFunc] = zcn.CollectRewards
 	zcn.smartContractFunctions[AddToDelegatePoolFunc] = zcn.AddToDelegatePool           // stakepool lock
 	zcn.smartContractFunctions[DeleteFromDelegatePoolFunc] = zcn.DeleteFromDelegatePool // stakepool unlock
+	zcn.smartContractFunctions[DelegatePoolAutoCompoundFunc] = zcn.DelegatePoolAutoCompound
 }
 
 // SetSC ...
@@ -84,11 +95,21 @@ func (zcn *ZCNSmartContract) setSC(sc *smartcontractinterface.SmartContract, _ s
 	zcn.SmartContractExecutionStats[UpdateAuthorizerConfigFunc] =
 		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, UpdateAuthorizerConfigFunc), nil)
 
+	// Guardian
+	zcn.SmartContractExecutionStats[PauseBridgeFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, PauseBridgeFunc), nil)
+	zcn.SmartContractExecutionStats[UnpauseBridgeFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, UnpauseBridgeFunc), nil)
+	zcn.SmartContractExecutionStats[CancelPendingMintFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, CancelPendingMintFunc), nil)
+
 	// Delegate pools
 	zcn.SmartContractExecutionStats[AddToDelegatePoolFunc] =
 		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, AddToDelegatePoolFunc), nil)
 	zcn.SmartContractExecutionStats[DeleteFromDelegatePoolFunc] =
 		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, DeleteFromDelegatePoolFunc), nil)
+	zcn.SmartContractExecutionStats[DelegatePoolAutoCompoundFunc] =
+		metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", zcn.ID, DelegatePoolAutoCompoundFunc), nil)
 }
 
 // GetName ...
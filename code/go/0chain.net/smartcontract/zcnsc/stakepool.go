@@ -182,3 +182,23 @@ func (zcn *ZCNSmartContract) DeleteFromDelegatePool(
 
 	return stakepool.StakePoolUnlock(t, inputData, balances, zcn.getStakePoolAdapter)
 }
+
+func (zcn *ZCNSmartContract) DelegatePoolAutoCompound(t *transaction.Transaction,
+	input []byte, balances cstate.StateContextI) (
+	resp string, err error) {
+	return stakepool.StakePoolAutoCompound(t, input, balances, zcn.getStakePoolAdapter)
+}
+
+// the stakes of the authorizers are compounded up to the max stake of the
+// current config
+func compoundLimits(_ string, balances cstate.CommonStateContextI) (stakepool.CompoundLimits, error) {
+	gn, err := GetGlobalNode(balances)
+	if err != nil {
+		return stakepool.CompoundLimits{}, err
+	}
+	return stakepool.CompoundLimits{MaxStake: gn.MaxStakeAmount}, nil
+}
+
+func init() {
+	stakepool.SetCompoundLimitsFunc(spenum.Authorizer, compoundLimits)
+}